@@ -2,6 +2,7 @@ package clusters
 
 import (
 	"fmt"
+	"os"
 
 	gcorecloud "github.com/G-Core/gcorelabscloud-go"
 	"github.com/G-Core/gcorelabscloud-go/client/dbaas/postgres/v1/client"
@@ -112,6 +113,75 @@ var clusterRemoveDatabaseCommand = cli.Command{
 	Flags:     flags.WaitCommandFlags,
 }
 
+var clusterScaleCommand = cli.Command{
+	Name:      "scale",
+	Usage:     "Change the flavor of a PostgreSQL cluster",
+	Category:  "clusters",
+	ArgsUsage: `<cluster_name>`,
+	Action:    scaleAction,
+	Flags:     scaleFlags(),
+}
+
+var clusterResizeStorageCommand = cli.Command{
+	Name:      "resize-storage",
+	Usage:     "Grow the storage of a PostgreSQL cluster",
+	Category:  "clusters",
+	ArgsUsage: `<cluster_name>`,
+	Action:    resizeStorageAction,
+	Flags:     resizeStorageFlags(),
+}
+
+var clusterSetPoolerModeCommand = cli.Command{
+	Name:      "set-pooler-mode",
+	Usage:     "Switch the connection pooler mode of a PostgreSQL cluster",
+	Category:  "clusters",
+	ArgsUsage: `<cluster_name>`,
+	Action:    setPoolerModeAction,
+	Flags:     setPoolerModeFlags(),
+}
+
+var clusterConnectionStringCommand = cli.Command{
+	Name:      "connection-string",
+	Usage:     "Show the connection string of a database and user of a PostgreSQL cluster",
+	Category:  "clusters",
+	ArgsUsage: `<cluster_name>`,
+	Action:    connectionStringAction,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "db-name",
+			Usage:    "name of the database",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     "user-name",
+			Usage:    "name of the user",
+			Required: true,
+		},
+		&cli.IntFlag{
+			Name:  "port",
+			Usage: "port of the cluster host instead of the one returned by the API, e.g. a server port bypassing the pooler",
+		},
+	},
+}
+
+var clusterValidatePGConfCommand = cli.Command{
+	Name:     "validate-pg-conf",
+	Usage:    "Validate pg.conf settings locally",
+	Category: "clusters",
+	Action:   validatePGConfAction,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:      "file",
+			Usage:     "path of a file with pg.conf settings",
+			TakesFile: true,
+		},
+		&cli.StringFlag{
+			Name:  "pg-config-conf",
+			Usage: `pg.conf settings (e.g. "\nmax_connections = 100\nwork_mem = 4MB")`,
+		},
+	},
+}
+
 func showClusterAction(c *cli.Context) error {
 	clusterName := c.Args().First()
 	if clusterName == "" {
@@ -236,17 +306,14 @@ func createClusterAction(c *cli.Context) error {
 		},
 	}
 
+	if err := clusters.ValidatePGConf(opts.PGServerConfiguration.PGConf); err != nil {
+		return cli.Exit(fmt.Errorf("invalid pg-config-conf: %w", err), 1)
+	}
+
 	// add user with custom role attributes if specified, otherwise use default
-	roleAttributes := make([]clusters.RoleAttribute, 0)
-	if c.IsSet("user-role-attribute") {
-		for _, attr := range c.StringSlice("user-role-attribute") {
-			if !clusters.IsValidRoleAttribute(attr) {
-				return cli.Exit(fmt.Sprintf("invalid role attribute: %s", attr), 1)
-			}
-			roleAttributes = append(roleAttributes, clusters.RoleAttribute(attr))
-		}
-	} else {
-		roleAttributes = defaultRoleAttributes
+	roleAttributes, err := roleAttributesFlag(c)
+	if err != nil {
+		return cli.Exit(err, 1)
 	}
 	opts.Users = []clusters.PgUserOpts{
 		{
@@ -377,6 +444,9 @@ func updateClusterAction(c *cli.Context) error {
 	if c.IsSet("pg-config-conf") || c.IsSet("pg-config-version") || c.IsSet("pg-config-pooler-mode") {
 		opts.PGServerConfiguration = &clusters.PGServerConfigurationUpdateOpts{}
 		if c.IsSet("pg-config-conf") {
+			if err := clusters.ValidatePGConf(c.String("pg-config-conf")); err != nil {
+				return cli.Exit(fmt.Errorf("invalid pg-config-conf: %w", err), 1)
+			}
 			opts.PGServerConfiguration.PGConf = c.String("pg-config-conf")
 		}
 		if c.IsSet("pg-config-version") {
@@ -414,6 +484,21 @@ func updateClusterAction(c *cli.Context) error {
 	return nil
 }
 
+// roleAttributesFlag returns the role attributes of the user-role-attribute flag, the default ones when it is not set.
+func roleAttributesFlag(c *cli.Context) ([]clusters.RoleAttribute, error) {
+	if !c.IsSet("user-role-attribute") {
+		return defaultRoleAttributes, nil
+	}
+	roleAttributes := make([]clusters.RoleAttribute, 0)
+	for _, attr := range c.StringSlice("user-role-attribute") {
+		if !clusters.IsValidRoleAttribute(attr) {
+			return nil, fmt.Errorf("invalid role attribute: %s", attr)
+		}
+		roleAttributes = append(roleAttributes, clusters.RoleAttribute(attr))
+	}
+	return roleAttributes, nil
+}
+
 func addUserAction(c *cli.Context) error {
 	clusterName := c.Args().First()
 	if clusterName == "" {
@@ -427,44 +512,15 @@ func addUserAction(c *cli.Context) error {
 		return cli.Exit(err, 1)
 	}
 
-	// first, get the existing users
-	cluster, err := clusters.Get(pgClient, clusterName).Extract()
+	roleAttributes, err := roleAttributesFlag(c)
 	if err != nil {
 		return cli.Exit(err, 1)
 	}
-	newUsers := make([]clusters.PgUserOpts, 0)
-	for _, user := range cluster.Users {
-		if user.Name == c.String("user-name") {
-			return cli.Exit(fmt.Sprintf("user with name %s already exists in cluster %s",
-				c.String("user-name"), clusterName), 1)
-		}
-		newUsers = append(newUsers, clusters.PgUserOpts{
-			Name:           user.Name,
-			RoleAttributes: user.RoleAttributes,
-		})
-	}
-
-	// second, add the new user
-	newUser := clusters.PgUserOpts{Name: c.String("user-name")}
-	if c.IsSet("user-role-attribute") {
-		newUser.RoleAttributes = make([]clusters.RoleAttribute, 0)
-		for _, attr := range c.StringSlice("user-role-attribute") {
-			if !clusters.IsValidRoleAttribute(attr) {
-				return cli.Exit(fmt.Sprintf("invalid role attribute: %s", attr), 1)
-			}
-			newUser.RoleAttributes = append(newUser.RoleAttributes, clusters.RoleAttribute(attr))
-		}
-	} else {
-		newUser.RoleAttributes = defaultRoleAttributes
-	}
-	newUsers = append(newUsers, newUser)
-
-	// finally, update the cluster with the new list of users
-	result := clusters.Update(pgClient, clusterName, clusters.UpdateOpts{Users: newUsers})
-	if err := handleTaskResult(c, result, pgClient, clusterName); err != nil {
-		return err
-	}
-	return nil
+	result := clusters.AddUser(pgClient, clusterName, clusters.PgUserOpts{
+		Name:           c.String("user-name"),
+		RoleAttributes: roleAttributes,
+	})
+	return handleTaskResult(c, result, pgClient, clusterName)
 }
 
 func removeUserAction(c *cli.Context) error {
@@ -481,37 +537,52 @@ func removeUserAction(c *cli.Context) error {
 		return cli.Exit(err, 1)
 	}
 
-	// first, get the existing users
-	cluster, err := clusters.Get(pgClient, clusterName).Extract()
+	result := clusters.RemoveUser(pgClient, clusterName, userName)
+	return handleTaskResult(c, result, pgClient, clusterName)
+}
+
+func addDatabaseAction(c *cli.Context) error {
+	clusterName := c.Args().First()
+	if clusterName == "" {
+		_ = cli.ShowCommandHelp(c, "add-database")
+		return cli.Exit("cluster_name is required", 1)
+	}
+
+	pgClient, err := client.NewPostgresClientV1(c)
 	if err != nil {
+		_ = cli.ShowAppHelp(c)
 		return cli.Exit(err, 1)
 	}
-	newUsers := make([]clusters.PgUserOpts, 0)
-	for _, user := range cluster.Users {
-		if user.Name != userName {
-			newUsers = append(newUsers, clusters.PgUserOpts{
-				Name:           user.Name,
-				RoleAttributes: user.RoleAttributes,
-			})
-		}
-	}
-	if len(newUsers) == len(cluster.Users) {
-		return cli.Exit(fmt.Sprintf("user with name '%s' does not exist in cluster '%s'",
-			userName, clusterName), 1)
+
+	result := clusters.AddDatabase(pgClient, clusterName, clusters.DatabaseOpts{
+		Name:  c.String("db-name"),
+		Owner: c.String("db-owner"),
+	})
+	return handleTaskResult(c, result, pgClient, clusterName)
+}
+
+func removeDatabaseAction(c *cli.Context) error {
+	clusterName := c.Args().Get(0)
+	databaseName := c.Args().Get(1)
+	if clusterName == "" || databaseName == "" {
+		_ = cli.ShowCommandHelp(c, "remove-database")
+		return cli.Exit("cluster_name and database_name are required", 1)
 	}
 
-	// finally, update the cluster with the new list of users
-	result := clusters.Update(pgClient, clusterName, clusters.UpdateOpts{Users: newUsers})
-	if err := handleTaskResult(c, result, pgClient, clusterName); err != nil {
-		return err
+	pgClient, err := client.NewPostgresClientV1(c)
+	if err != nil {
+		_ = cli.ShowAppHelp(c)
+		return cli.Exit(err, 1)
 	}
-	return nil
+
+	result := clusters.RemoveDatabase(pgClient, clusterName, databaseName)
+	return handleTaskResult(c, result, pgClient, clusterName)
 }
 
-func addDatabaseAction(c *cli.Context) error {
+func scaleAction(c *cli.Context) error {
 	clusterName := c.Args().First()
 	if clusterName == "" {
-		_ = cli.ShowCommandHelp(c, "add-database")
+		_ = cli.ShowCommandHelp(c, "scale")
 		return cli.Exit("cluster_name is required", 1)
 	}
 
@@ -521,54 +592,52 @@ func addDatabaseAction(c *cli.Context) error {
 		return cli.Exit(err, 1)
 	}
 
-	// first, get the existing databases
-	cluster, err := clusters.Get(pgClient, clusterName).Extract()
+	result := clusters.Scale(pgClient, clusterName, clusters.FlavorOpts{
+		CPU:       c.Int("flavor-cpu"),
+		MemoryGiB: c.Int("flavor-memory"),
+	})
+	return handleTaskResult(c, result, pgClient, clusterName)
+}
+
+func resizeStorageAction(c *cli.Context) error {
+	clusterName := c.Args().First()
+	if clusterName == "" {
+		_ = cli.ShowCommandHelp(c, "resize-storage")
+		return cli.Exit("cluster_name is required", 1)
+	}
+
+	pgClient, err := client.NewPostgresClientV1(c)
 	if err != nil {
+		_ = cli.ShowAppHelp(c)
 		return cli.Exit(err, 1)
 	}
-	newDatabases := make([]clusters.DatabaseOpts, 0)
-	for _, db := range cluster.Databases {
-		if db.Name == c.String("db-name") {
-			return cli.Exit(fmt.Sprintf("database with name '%s' already exists in cluster '%s'",
-				c.String("db-name"), clusterName), 1)
-		}
-		newDatabases = append(newDatabases, clusters.DatabaseOpts{
-			Name:  db.Name,
-			Owner: db.Owner,
-		})
-	}
 
-	// check that the owner exists
-	foundOwner := false
-	for _, user := range cluster.Users {
-		if user.Name == c.String("db-owner") {
-			foundOwner = true
-		}
-	}
-	if !foundOwner {
-		return cli.Exit(fmt.Sprintf("db-owner with name '%s' not found in cluster '%s'",
-			c.String("db-owner"), clusterName), 1)
+	result := clusters.ResizeStorage(pgClient, clusterName, c.Int("storage-size"))
+	return handleTaskResult(c, result, pgClient, clusterName)
+}
+
+func setPoolerModeAction(c *cli.Context) error {
+	clusterName := c.Args().First()
+	if clusterName == "" {
+		_ = cli.ShowCommandHelp(c, "set-pooler-mode")
+		return cli.Exit("cluster_name is required", 1)
 	}
 
-	// second, add the new database
-	newDatabases = append(newDatabases, clusters.DatabaseOpts{
-		Name:  c.String("db-name"),
-		Owner: c.String("db-owner"),
-	})
-	// finally, update the cluster with the new list of databases
-	result := clusters.Update(pgClient, clusterName, clusters.UpdateOpts{Databases: newDatabases})
-	if err := handleTaskResult(c, result, pgClient, clusterName); err != nil {
-		return err
+	pgClient, err := client.NewPostgresClientV1(c)
+	if err != nil {
+		_ = cli.ShowAppHelp(c)
+		return cli.Exit(err, 1)
 	}
-	return nil
+
+	result := clusters.SetPoolerMode(pgClient, clusterName, clusters.PoolerMode(c.String("mode")))
+	return handleTaskResult(c, result, pgClient, clusterName)
 }
 
-func removeDatabaseAction(c *cli.Context) error {
-	clusterName := c.Args().Get(0)
-	databaseName := c.Args().Get(1)
-	if clusterName == "" || databaseName == "" {
-		_ = cli.ShowCommandHelp(c, "remove-database")
-		return cli.Exit("cluster_name and database_name are required", 1)
+func connectionStringAction(c *cli.Context) error {
+	clusterName := c.Args().First()
+	if clusterName == "" {
+		_ = cli.ShowCommandHelp(c, "connection-string")
+		return cli.Exit("cluster_name is required", 1)
 	}
 
 	pgClient, err := client.NewPostgresClientV1(c)
@@ -577,33 +646,40 @@ func removeDatabaseAction(c *cli.Context) error {
 		return cli.Exit(err, 1)
 	}
 
-	// first, get the existing databases
 	cluster, err := clusters.Get(pgClient, clusterName).Extract()
 	if err != nil {
 		return cli.Exit(err, 1)
 	}
-	newDatabases := make([]clusters.DatabaseOpts, 0)
-	foundDatabase := false
-	for _, db := range cluster.Databases {
-		if db.Name != databaseName {
-			newDatabases = append(newDatabases, clusters.DatabaseOpts{
-				Name:  db.Name,
-				Owner: db.Owner,
-			})
-		} else {
-			foundDatabase = true
+	connectionString, err := clusters.ConnectionStringWithPort(cluster, c.String("db-name"), c.String("user-name"), c.Int("port"))
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	_, err = fmt.Fprintln(c.App.Writer, connectionString)
+	return err
+}
+
+func validatePGConfAction(c *cli.Context) error {
+	conf := c.String("pg-config-conf")
+	if c.IsSet("file") {
+		data, err := os.ReadFile(c.String("file"))
+		if err != nil {
+			return cli.Exit(err, 1)
 		}
+		conf = string(data)
 	}
-	if !foundDatabase {
-		return cli.Exit(fmt.Sprintf("database with name '%s' does not exist in cluster '%s'",
-			databaseName, clusterName), 1)
+	if conf == "" {
+		_ = cli.ShowCommandHelp(c, "validate-pg-conf")
+		return cli.Exit("either --file or --pg-config-conf is required", 1)
 	}
 
-	// finally, update the cluster with the new list of databases
-	result := clusters.Update(pgClient, clusterName, clusters.UpdateOpts{Databases: newDatabases})
-	if err := handleTaskResult(c, result, pgClient, clusterName); err != nil {
-		return err
+	parsed, err := clusters.ParsePGConf(conf)
+	if err == nil {
+		err = parsed.Validate()
 	}
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	utils.ShowResults(parsed, c.String("format"))
 	return nil
 }
 
@@ -822,6 +898,47 @@ func addDatabaseFlags() []cli.Flag {
 	return addFlags
 }
 
+func scaleFlags() []cli.Flag {
+	scaleFlags := []cli.Flag{
+		&cli.IntFlag{
+			Name:     "flavor-cpu",
+			Usage:    "number of CPU cores (min: 1)",
+			Required: true,
+		},
+		&cli.IntFlag{
+			Name:     "flavor-memory",
+			Usage:    "amount of RAM in GiB (min: 1)",
+			Required: true,
+		},
+	}
+	scaleFlags = append(scaleFlags, flags.WaitCommandFlags...)
+	return scaleFlags
+}
+
+func resizeStorageFlags() []cli.Flag {
+	resizeFlags := []cli.Flag{
+		&cli.IntFlag{
+			Name:     "storage-size",
+			Usage:    "new size of the storage in GB (max: 100). Can only be increased.",
+			Required: true,
+		},
+	}
+	resizeFlags = append(resizeFlags, flags.WaitCommandFlags...)
+	return resizeFlags
+}
+
+func setPoolerModeFlags() []cli.Flag {
+	poolerFlags := []cli.Flag{
+		&cli.StringFlag{
+			Name:     "mode",
+			Usage:    "mode of the connection pooler (e.g. session, statement, transaction)",
+			Required: true,
+		},
+	}
+	poolerFlags = append(poolerFlags, flags.WaitCommandFlags...)
+	return poolerFlags
+}
+
 var Commands = cli.Command{
 	Name:        "clusters",
 	Usage:       "Manage PostgreSQL clusters",
@@ -836,5 +953,10 @@ var Commands = cli.Command{
 		&clusterRemoveUserCommand,
 		&clusterAddDatabaseCommand,
 		&clusterRemoveDatabaseCommand,
+		&clusterScaleCommand,
+		&clusterResizeStorageCommand,
+		&clusterSetPoolerModeCommand,
+		&clusterConnectionStringCommand,
+		&clusterValidatePGConfCommand,
 	},
 }
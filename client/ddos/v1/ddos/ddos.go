@@ -0,0 +1,71 @@
+package ddos
+
+import (
+	"strconv"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/client/common"
+	"github.com/G-Core/gcorelabscloud-go/client/ddos/v1/client"
+	"github.com/G-Core/gcorelabscloud-go/client/utils"
+	"github.com/G-Core/gcorelabscloud-go/gcore/ddos/v1/ddos/protection"
+	"github.com/urfave/cli/v2"
+)
+
+// regionalClientFactory builds clients of other regions by overriding the region flag.
+func regionalClientFactory(c *cli.Context, name, version string) protection.ClientFactory {
+	return func(regionID int) (*gcorecloud.ServiceClient, error) {
+		if err := c.Set("region", strconv.Itoa(regionID)); err != nil {
+			return nil, err
+		}
+		return common.BuildClient(c, name, version)
+	}
+}
+
+var ddosCoverageCommand = cli.Command{
+	Name:     "coverage",
+	Usage:    "Show DDoS protection of every public IP of floating IPs, load balancers and baremetal instances",
+	Category: "ddos",
+	Flags: []cli.Flag{
+		&cli.IntSliceFlag{
+			Name:  "region-id",
+			Usage: "regions of the report. Defaults to the current region",
+		},
+		&cli.BoolFlag{
+			Name:  "unprotected",
+			Usage: "show only IPs without a profile",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		ddosClient, err := client.NewDDoSClientV1(c)
+		if err != nil {
+			_ = cli.ShowAppHelp(c)
+			return cli.NewExitError(err, 1)
+		}
+		regionIDs := c.IntSlice("region-id")
+		if len(regionIDs) == 0 {
+			regionIDs = []int{ddosClient.RegionID}
+		}
+		entries, err := protection.Coverage(protection.CoverageClients{
+			DDoS:          regionalClientFactory(c, "ddos/profiles", "v1"),
+			FloatingIPs:   regionalClientFactory(c, "floatingips", "v1"),
+			LoadBalancers: regionalClientFactory(c, "loadbalancers", "v1"),
+			Baremetal:     regionalClientFactory(c, "bminstances", "v1"),
+		}, protection.CoverageOpts{RegionIDs: regionIDs})
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if c.Bool("unprotected") {
+			entries = protection.Unprotected(entries)
+		}
+		utils.ShowResults(entries, c.String("format"))
+		return nil
+	},
+}
+
+var Commands = cli.Command{
+	Name:  "ddos",
+	Usage: "GCloud DDoS protection API",
+	Subcommands: []*cli.Command{
+		&ddosCoverageCommand,
+	},
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/G-Core/gcorelabscloud-go/client/flags"
 	"github.com/G-Core/gcorelabscloud-go/client/utils"
 	"github.com/G-Core/gcorelabscloud-go/gcore/faas/v1/faas"
+	"github.com/G-Core/gcorelabscloud-go/gcore/faas/v1/faas/deploy"
 	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
 
 	"github.com/urfave/cli/v2"
@@ -19,8 +20,9 @@ import (
 const functionNameText = "function_name is mandatory argument"
 
 var Commands = cli.Command{
-	Name:  "functions",
-	Usage: "GCloud FaaS functions API",
+	Name:    "functions",
+	Aliases: []string{"faas"},
+	Usage:   "GCloud FaaS functions API",
 	Subcommands: []*cli.Command{
 		&namespaces.Commands,
 		&keys.Commands,
@@ -30,6 +32,7 @@ var Commands = cli.Command{
 		&functionCreateCommand,
 		&functionUpdateCommand,
 		&functionSaveCommand,
+		&functionDeployCommand,
 	},
 }
 
@@ -587,3 +590,57 @@ func saveFunction(c *cli.Context) error {
 
 	return nil
 }
+
+var functionDeployCommand = cli.Command{
+	Name:      "deploy",
+	Usage:     "deploy function from a source directory with a function manifest and a single code file.",
+	ArgsUsage: "<directory>",
+	Category:  "functions",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "namespace",
+			Aliases:  []string{"ns"},
+			Usage:    "function namespace, overrides the manifest namespace",
+			Required: false,
+		},
+		&cli.IntFlag{
+			Name:     "wait-seconds",
+			Usage:    "amount of time in seconds to wait for the function to become active",
+			Value:    deploy.DefaultWaitSeconds,
+			Required: false,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		return deployFunction(c)
+	},
+}
+
+func deployFunction(c *cli.Context) error {
+	dir, err := flags.GetFirstStringArg(c, "directory is mandatory argument")
+	if err != nil {
+		_ = cli.ShowCommandHelp(c, "deploy")
+		return err
+	}
+
+	pkg, err := deploy.Load(dir)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	cl, err := client.NewFaaSClientV1(c)
+	if err != nil {
+		_ = cli.ShowAppHelp(c)
+		return cli.Exit(err, 1)
+	}
+
+	result, err := deploy.Deploy(cl, pkg, deploy.Opts{
+		Namespace:   c.String("namespace"),
+		WaitSeconds: c.Int("wait-seconds"),
+	})
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	utils.ShowResults(result, c.String("format"))
+	return nil
+}
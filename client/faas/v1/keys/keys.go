@@ -1,6 +1,7 @@
 package keys
 
 import (
+	"context"
 	"strings"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/G-Core/gcorelabscloud-go/client/flags"
 	"github.com/G-Core/gcorelabscloud-go/client/utils"
 	"github.com/G-Core/gcorelabscloud-go/gcore/faas/v1/faas"
+	"github.com/G-Core/gcorelabscloud-go/gcore/faas/v1/faas/apikeys"
 )
 
 const keyNameText = "key_name is mandatory argument"
@@ -24,6 +26,8 @@ var Commands = cli.Command{
 		&keyCreateCommand,
 		&keyUpdateCommand,
 		&keyDeleteCommand,
+		&keyRotateCommand,
+		&keyAuditCommand,
 	},
 }
 
@@ -328,3 +332,138 @@ func deleteKey(c *cli.Context) error {
 		return cli.Exit(err, 1)
 	}
 }
+
+var keyRotateCommand = cli.Command{
+	Name:      "rotate",
+	Usage:     "Replace API key with a new key of the same function scope.",
+	Category:  "api keys",
+	ArgsUsage: "<key_name>",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "new-name",
+			Usage:    "replacement key name. Defaults to the key name with a timestamp",
+			Required: false,
+		},
+		&cli.StringFlag{
+			Name:     "expire",
+			Usage:    "when replacement key will expire. Format 2023-07-31T00:00:00Z. Defaults to the lifetime of the old key",
+			Required: false,
+		},
+		&cli.DurationFlag{
+			Name:     "grace-period",
+			Usage:    "how long the old key keeps working after the replacement key is created",
+			Required: false,
+		},
+		&cli.BoolFlag{
+			Name:     "keep-old",
+			Usage:    "do not detach and delete the old key",
+			Required: false,
+		},
+		&cli.PathFlag{
+			Name:     "file",
+			Usage:    "where to put replacement API key secret",
+			Required: false,
+		},
+		&cli.IntFlag{
+			Name:     "wait-seconds",
+			Usage:    "amount of time in seconds to wait for every function update",
+			Value:    apikeys.DefaultWaitSeconds,
+			Required: false,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		return rotateKey(c)
+	},
+}
+
+func rotateKey(c *cli.Context) error {
+	name, err := flags.GetFirstStringArg(c, keyNameText)
+	if err != nil {
+		_ = cli.ShowCommandHelp(c, "rotate")
+		return err
+	}
+
+	clients, err := keyClients(c)
+	if err != nil {
+		_ = cli.ShowAppHelp(c)
+		return cli.Exit(err, 1)
+	}
+
+	opts := apikeys.RotateOpts{
+		Name:        name,
+		NewName:     c.String("new-name"),
+		GracePeriod: c.Duration("grace-period"),
+		KeepOldKey:  c.Bool("keep-old"),
+		WaitSeconds: c.Int("wait-seconds"),
+	}
+
+	if c.IsSet("expire") {
+		t, err := time.Parse(gcorecloud.RFC3339ZZ, c.String("expire"))
+		if err != nil {
+			_ = cli.ShowCommandHelp(c, "rotate")
+			return cli.Exit("Invalid format for expire", 1)
+		}
+		opts.Expire = &t
+	}
+
+	if c.IsSet("file") {
+		opts.Handoff = func(key faas.Key) error {
+			return utils.WriteToFile(c.Path("file"), []byte(key.Secret))
+		}
+	}
+
+	result, err := apikeys.Rotate(context.Background(), clients, opts)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	utils.ShowResults(result, c.String("format"))
+	return nil
+}
+
+var keyAuditCommand = cli.Command{
+	Name:     "audit",
+	Usage:    "Report API keys near expiry or without functions and which keys every function is reachable with.",
+	Category: "api keys",
+	Flags: []cli.Flag{
+		&cli.IntFlag{
+			Name:     "days",
+			Usage:    "report keys expiring within the number of days",
+			Value:    30,
+			Required: false,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		return auditKeys(c)
+	},
+}
+
+func auditKeys(c *cli.Context) error {
+	clients, err := keyClients(c)
+	if err != nil {
+		_ = cli.ShowAppHelp(c)
+		return cli.Exit(err, 1)
+	}
+
+	report, err := apikeys.Audit(clients, apikeys.AuditOpts{
+		Within: time.Duration(c.Int("days")) * 24 * time.Hour,
+	})
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	utils.ShowResults(report, c.String("format"))
+	return nil
+}
+
+func keyClients(c *cli.Context) (apikeys.Clients, error) {
+	keysClient, err := client.NewFaaSKeysClientV1(c)
+	if err != nil {
+		return apikeys.Clients{}, err
+	}
+	functionsClient, err := client.NewFaaSClientV1(c)
+	if err != nil {
+		return apikeys.Clients{}, err
+	}
+	return apikeys.Clients{Keys: keysClient, Functions: functionsClient}, nil
+}
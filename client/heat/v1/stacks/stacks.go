@@ -3,10 +3,12 @@ package stacks
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/G-Core/gcorelabscloud-go/client/flags"
 	"github.com/G-Core/gcorelabscloud-go/client/heat/v1/client"
 	"github.com/G-Core/gcorelabscloud-go/client/utils"
+	"github.com/G-Core/gcorelabscloud-go/gcore/heat/v1/stack/events"
 	"github.com/G-Core/gcorelabscloud-go/gcore/heat/v1/stack/stacks"
 	"github.com/G-Core/gcorelabscloud-go/gcore/heat/v1/stack/stacks/types"
 
@@ -233,6 +235,195 @@ var stackUpdateSubCommand = cli.Command{
 	},
 }
 
+var templateCheckFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:     "template",
+		Usage:    "stack template yaml file",
+		Required: true,
+	},
+	&cli.StringFlag{
+		Name:     "environment",
+		Usage:    "stack environment yaml file",
+		Required: false,
+	},
+	&cli.StringSliceFlag{
+		Name:     "parameter",
+		Usage:    "stack parameters. Example: --parameter one=two --parameter three=four",
+		Required: false,
+	},
+}
+
+// templateCheckOpts builds stack options of the template, environment and parameter flags.
+func templateCheckOpts(c *cli.Context) (stacks.CreateOpts, error) {
+	opts := stacks.CreateOpts{Name: c.String("name")}
+	content, err := utils.CheckYamlFile(c.String("template"))
+	if err != nil {
+		return opts, err
+	}
+	opts.TemplateOpts = &stacks.Template{TE: stacks.TE{Bin: content}}
+
+	if environmentFile := c.String("environment"); environmentFile != "" {
+		content, err := utils.CheckYamlFile(environmentFile)
+		if err != nil {
+			return opts, err
+		}
+		opts.EnvironmentOpts = &stacks.Environment{TE: stacks.TE{Bin: content}}
+	}
+
+	opts.Parameters, err = utils.StringSliceToMapInterface(c.StringSlice("parameter"))
+	return opts, err
+}
+
+var stackValidateSubCommand = cli.Command{
+	Name:     "validate",
+	Usage:    "Validate heat template locally",
+	Category: "stack",
+	Flags:    templateCheckFlags,
+	Action: func(c *cli.Context) error {
+		opts, err := templateCheckOpts(c)
+		if err != nil {
+			_ = cli.ShowCommandHelp(c, "validate")
+			return cli.NewExitError(err, 1)
+		}
+		if err := stacks.ValidateTemplate(opts); err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		fmt.Fprintln(c.App.Writer, "template is valid")
+		return nil
+	},
+}
+
+var stackPreviewSubCommand = cli.Command{
+	Name:     "preview",
+	Usage:    "Render heat template with parameters locally and show resources",
+	Category: "stack",
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:     "name",
+			Usage:    "stack name",
+			Required: false,
+		},
+		&cli.BoolFlag{
+			Name:     "graph",
+			Usage:    "show only resources with their dependencies",
+			Required: false,
+		},
+	}, templateCheckFlags...),
+	Action: func(c *cli.Context) error {
+		opts, err := templateCheckOpts(c)
+		if err != nil {
+			_ = cli.ShowCommandHelp(c, "preview")
+			return cli.NewExitError(err, 1)
+		}
+		preview, err := stacks.Preview(opts)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if c.Bool("graph") {
+			fmt.Fprint(c.App.Writer, preview.Graph())
+			return nil
+		}
+		utils.ShowResults(preview, c.String("format"))
+		return nil
+	},
+}
+
+var stackEventsSubCommand = cli.Command{
+	Name:      "events",
+	Usage:     "Heat stack events",
+	ArgsUsage: "<stack_id>",
+	Category:  "stack",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "resource-name",
+			Usage:    "show events of the resource",
+			Required: false,
+		},
+		&cli.StringFlag{
+			Name:     "status",
+			Usage:    "event status. Example: CREATE_FAILED",
+			Required: false,
+		},
+		&cli.IntFlag{
+			Name:     "nested-depth",
+			Usage:    "include events of nested stacks down to the depth",
+			Required: false,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		stackID, err := flags.GetFirstStringArg(c, stackIDText)
+		if err != nil {
+			_ = cli.ShowCommandHelp(c, "events")
+			return err
+		}
+		client, err := client.NewHeatClientV1(c)
+		if err != nil {
+			_ = cli.ShowAppHelp(c)
+			return cli.NewExitError(err, 1)
+		}
+		opts := events.ListOpts{
+			ResourceName:   c.String("resource-name"),
+			ResourceStatus: c.String("status"),
+			NestedDepth:    c.Int("nested-depth"),
+			SortDir:        types.SortAsc,
+		}
+		results, err := events.ListAll(client, stackID, opts)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		utils.ShowResults(results, c.String("format"))
+		return nil
+	},
+}
+
+var stackWatchSubCommand = cli.Command{
+	Name:      "watch",
+	Usage:     "Show heat stack events until the stack reaches a status",
+	ArgsUsage: "<stack_id>",
+	Category:  "stack",
+	Flags: []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:     "status",
+			Usage:    "awaited stack status",
+			Value:    cli.NewStringSlice("CREATE_COMPLETE", "UPDATE_COMPLETE"),
+			Required: false,
+		},
+		&cli.IntFlag{
+			Name:     "timeout",
+			Usage:    "seconds to wait, negative waits forever",
+			Value:    -1,
+			Required: false,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		stackID, err := flags.GetFirstStringArg(c, stackIDText)
+		if err != nil {
+			_ = cli.ShowCommandHelp(c, "watch")
+			return err
+		}
+		client, err := client.NewHeatClientV1(c)
+		if err != nil {
+			_ = cli.ShowAppHelp(c)
+			return cli.NewExitError(err, 1)
+		}
+		stack, err := stacks.Get(client, stackID).Extract()
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		result, err := stacks.Wait(client, stack.StackName, stackID, c.StringSlice("status"), func(event events.Event) {
+			fmt.Fprintf(c.App.Writer, "%s %s %s %s\n", event.EventTime.Format(time.RFC3339),
+				event.LogicalResourceID, event.ResourceStatus, event.ResourceStatusReason)
+		}, c.Int("timeout"))
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if result != nil {
+			fmt.Fprintf(c.App.Writer, "stack %s is %s\n", result.StackName, result.StackStatus)
+		}
+		return nil
+	},
+}
+
 var StackCommands = cli.Command{
 	Name:  "stack",
 	Usage: "Heat stacks commands",
@@ -240,5 +431,9 @@ var StackCommands = cli.Command{
 		&stackGetSubCommand,
 		&stackListSubCommand,
 		&stackUpdateSubCommand,
+		&stackValidateSubCommand,
+		&stackPreviewSubCommand,
+		&stackEventsSubCommand,
+		&stackWatchSubCommand,
 	},
 }
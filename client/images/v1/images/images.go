@@ -2,11 +2,18 @@ package images
 
 import (
 	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
 	"strings"
 
 	cmeta "github.com/G-Core/gcorelabscloud-go/client/utils/metadata"
 
 	"github.com/G-Core/gcorelabscloud-go/client/images/v1/client"
+	instanceclient "github.com/G-Core/gcorelabscloud-go/client/instances/v1/client"
+	instanceclientV2 "github.com/G-Core/gcorelabscloud-go/client/instances/v2/client"
+	regionclient "github.com/G-Core/gcorelabscloud-go/client/regions/v1/client"
+	volumeclient "github.com/G-Core/gcorelabscloud-go/client/volumes/v1/client"
 
 	gcorecloud "github.com/G-Core/gcorelabscloud-go"
 	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
@@ -16,7 +23,9 @@ import (
 	"github.com/G-Core/gcorelabscloud-go/client/flags"
 	"github.com/G-Core/gcorelabscloud-go/client/utils"
 	"github.com/G-Core/gcorelabscloud-go/gcore/image/v1/images"
+	"github.com/G-Core/gcorelabscloud-go/gcore/image/v1/images/transfer"
 	"github.com/G-Core/gcorelabscloud-go/gcore/image/v1/images/types"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
 )
 
 var (
@@ -429,6 +438,210 @@ var imageUploadCommand = cli.Command{
 	},
 }
 
+var imageCopyCommand = cli.Command{
+	Name:      "copy",
+	Usage:     "Copy project image to other regions",
+	Category:  "image",
+	ArgsUsage: "<image_id>",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "url",
+			Usage:    "URL the image file is downloaded from, images cannot be downloaded from the API",
+			Required: true,
+		},
+		&cli.IntSliceFlag{
+			Name:  "target-region",
+			Usage: "target region ID. Defaults to every active region except the source one",
+		},
+		&cli.StringFlag{
+			Name:  "name",
+			Usage: "name of the copies. Defaults to the source image name",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		imageID, err := flags.GetFirstStringArg(c, imageIDText)
+		if err != nil {
+			_ = cli.ShowCommandHelp(c, "copy")
+			return err
+		}
+		sourceClient, err := client.NewImageClientV1(c)
+		if err != nil {
+			_ = cli.ShowAppHelp(c)
+			return cli.NewExitError(err, 1)
+		}
+		sourceRegionID, err := regionFromClient(sourceClient)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		regionClient, err := regionclient.NewRegionClientV1(c)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+
+		results, err := transfer.Copy(transfer.Clients{
+			Images:  regionalClientFactory(c, client.NewImageClientV1),
+			Upload:  regionalClientFactory(c, client.NewDownloadImageClientV1),
+			Regions: regionClient,
+		}, transfer.CopyOpts{
+			ImageID:        imageID,
+			SourceRegionID: sourceRegionID,
+			URL:            c.String("url"),
+			RegionIDs:      c.IntSlice("target-region"),
+			Name:           c.String("name"),
+		})
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		utils.ShowResults(results, c.String("format"))
+		return nil
+	},
+}
+
+// regionFromClient returns the region ID of a regional client URL.
+func regionFromClient(cl *gcorecloud.ServiceClient) (int, error) {
+	parts := strings.Split(strings.Trim(cl.ResourceBaseURL(), "/"), "/")
+	regionID, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return 0, fmt.Errorf("cannot get region from client URL %s", cl.ResourceBaseURL())
+	}
+	return regionID, nil
+}
+
+// regionalClientFactory builds clients of other regions by overriding the region flag.
+func regionalClientFactory(c *cli.Context, build func(*cli.Context) (*gcorecloud.ServiceClient, error)) transfer.ClientFactory {
+	return func(regionID int) (*gcorecloud.ServiceClient, error) {
+		if err := c.Set("region", strconv.Itoa(regionID)); err != nil {
+			return nil, err
+		}
+		return build(c)
+	}
+}
+
+var imageBakeCommand = cli.Command{
+	Name:      "bake",
+	Usage:     "Create a new image version from the boot volume of an instance",
+	Category:  "image",
+	ArgsUsage: "<instance_id>",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "family",
+			Usage: "image family the versions are grouped by. Defaults to the instance name",
+		},
+		&cli.StringFlag{
+			Name:  "name-template",
+			Usage: "image name template with .Family, .Version, .Date and .Instance fields",
+			Value: images.DefaultBakeNameTemplate,
+		},
+		&cli.IntFlag{
+			Name:  "retain",
+			Usage: "number of family versions kept, including the new one. 0 keeps all",
+		},
+		&cli.StringFlag{
+			Name:  "cleanup-command",
+			Usage: "shell command run before the instance is stopped, the cleanup script is passed on stdin. Example: 'ssh ubuntu@203.0.113.10 sudo sh -s'",
+		},
+		&cli.StringFlag{
+			Name:  "cleanup-script",
+			Usage: "file with the cleanup script. Defaults to a script removing machine ID, SSH host keys, cloud-init state and shell history",
+		},
+		&cli.StringFlag{
+			Name:  "hw-firmware-type",
+			Usage: "Available values are 'bios', 'uefi'. Defaults to the source image of the boot volume",
+		},
+		&cli.StringFlag{
+			Name:  "hw-machine-type",
+			Usage: "Available values are 'i440', 'q35'. Defaults to the source image of the boot volume",
+		},
+		&cli.StringFlag{
+			Name:  "ssh-key",
+			Usage: "Available values are 'allow', 'deny', 'required'. Defaults to the source image of the boot volume",
+		},
+		&cli.StringFlag{
+			Name:  "os-type",
+			Usage: "Available values are 'windows', 'linux'. Defaults to the source image of the boot volume",
+		},
+		&cli.StringFlag{
+			Name:  "architecture",
+			Usage: "Available values are 'x86_64', 'aarch64'. Defaults to the source image of the boot volume",
+		},
+		&cli.StringSliceFlag{
+			Name:  "metadata",
+			Usage: "Image metadata added to the instance metadata. Example: --metadata one=two --metadata three=four",
+		},
+		&cli.BoolFlag{
+			Name:  "keep-stopped",
+			Usage: "do not start the instance after the image is created",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		instanceID, err := flags.GetFirstStringArg(c, "instance_id is mandatory argument")
+		if err != nil {
+			_ = cli.ShowCommandHelp(c, "bake")
+			return err
+		}
+		metadata, err := StringSliceToMetadata(c.StringSlice("metadata"))
+		if err != nil {
+			_ = cli.ShowCommandHelp(c, "bake")
+			return cli.NewExitError(err, 1)
+		}
+		var clients images.BakeClients
+		if clients.Images, err = client.NewImageClientV1(c); err != nil {
+			_ = cli.ShowAppHelp(c)
+			return cli.NewExitError(err, 1)
+		}
+		if clients.Instances, err = instanceclient.NewInstanceClientV1(c); err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if clients.InstanceActions, err = instanceclientV2.NewInstanceClientV2(c); err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if clients.Volumes, err = volumeclient.NewVolumeClientV1(c); err != nil {
+			return cli.NewExitError(err, 1)
+		}
+
+		opts := images.BakeOpts{
+			InstanceID:     instanceID,
+			Family:         c.String("family"),
+			NameTemplate:   c.String("name-template"),
+			Retain:         c.Int("retain"),
+			Metadata:       metadata,
+			OSType:         types.OSType(c.String("os-type")),
+			SshKey:         types.SshKeyType(c.String("ssh-key")),
+			HwFirmwareType: types.HwFirmwareType(c.String("hw-firmware-type")),
+			HwMachineType:  types.HwMachineType(c.String("hw-machine-type")),
+			Architecture:   types.ImageArchitectureType(c.String("architecture")),
+			KeepStopped:    c.Bool("keep-stopped"),
+		}
+		if command := c.String("cleanup-command"); command != "" {
+			script := images.CleanupScript
+			if path := c.String("cleanup-script"); path != "" {
+				content, err := os.ReadFile(path)
+				if err != nil {
+					return cli.NewExitError(err, 1)
+				}
+				script = string(content)
+			}
+			opts.Cleanup = func(instance *instances.Instance) error {
+				cmd := exec.Command("sh", "-c", command)
+				cmd.Env = append(os.Environ(), "INSTANCE_ID="+instance.ID, "INSTANCE_NAME="+instance.Name)
+				cmd.Stdin = strings.NewReader(script)
+				cmd.Stdout, cmd.Stderr = os.Stderr, os.Stderr
+				return cmd.Run()
+			}
+		}
+
+		result, err := images.BuildFromInstance(clients, opts)
+		if err != nil {
+			if result != nil {
+				utils.ShowResults(result, c.String("format"))
+			}
+			return cli.NewExitError(err, 1)
+		}
+		utils.ShowResults(result, c.String("format"))
+		return nil
+	},
+}
+
 var imageDeleteCommand = cli.Command{
 	Name:      "delete",
 	Usage:     "Delete image",
@@ -476,6 +689,8 @@ var Commands = cli.Command{
 		&imageCreateCommand,
 		&imageUpdateCommand,
 		&imageUploadCommand,
+		&imageCopyCommand,
+		&imageBakeCommand,
 		{
 			Name:  "project",
 			Usage: "GCloud project images API",
@@ -0,0 +1,13 @@
+package client
+
+import (
+	"github.com/urfave/cli/v2"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/client/common"
+)
+
+// NewInferenceClientV3 creates a new inference deployments client
+func NewInferenceClientV3(c *cli.Context) (*gcorecloud.ServiceClient, error) {
+	return common.BuildClient(c, "inferences", "v3")
+}
@@ -0,0 +1,244 @@
+package inferences
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/G-Core/gcorelabscloud-go/client/flags"
+	"github.com/G-Core/gcorelabscloud-go/client/inference/v3/client"
+	regionclient "github.com/G-Core/gcorelabscloud-go/client/regions/v1/client"
+	"github.com/G-Core/gcorelabscloud-go/client/utils"
+	"github.com/G-Core/gcorelabscloud-go/gcore/inference/v3/inferences"
+	"github.com/G-Core/gcorelabscloud-go/gcore/inference/v3/inferences/spec"
+
+	"github.com/urfave/cli/v2"
+)
+
+const deploymentNameText = "deployment_name is mandatory argument"
+
+var Commands = cli.Command{
+	Name:  "inference",
+	Usage: "GCloud inference deployments API",
+	Subcommands: []*cli.Command{
+		&validateCommand,
+		&applyCommand,
+		&rolloutCommand,
+	},
+}
+
+var rolloutCommand = cli.Command{
+	Name:      "rollout",
+	Usage:     "update an inference deployment, wait for it to become healthy and restore the previous spec on failure.",
+	ArgsUsage: "<deployment_name>",
+	Category:  "inference",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "image",
+			Usage:    "new container image",
+			Required: false,
+		},
+		&cli.StringFlag{
+			Name:     "flavor",
+			Usage:    "new flavor name",
+			Required: false,
+		},
+		&cli.IntFlag{
+			Name:     "listening-port",
+			Usage:    "new container listening port",
+			Required: false,
+		},
+		&cli.StringFlag{
+			Name:     "command",
+			Usage:    "new container command, split on white space",
+			Required: false,
+		},
+		&cli.StringSliceFlag{
+			Name:     "envs",
+			Usage:    "environment variables replacing the current ones. Example: --envs one=two --envs three=four",
+			Required: false,
+		},
+		&cli.IntFlag{
+			Name:     "canary-region",
+			Usage:    "verify the update on a temporary single container deployment in the region first",
+			Required: false,
+		},
+		&cli.DurationFlag{
+			Name:     "timeout",
+			Usage:    "deadline for the deployment to become healthy",
+			Value:    inferences.DefaultRolloutTimeout,
+			Required: false,
+		},
+		&cli.DurationFlag{
+			Name:     "poll-interval",
+			Usage:    "interval between deployment status checks",
+			Value:    inferences.DefaultRolloutPollInterval,
+			Required: false,
+		},
+		&cli.IntFlag{
+			Name:     "healthy-checks",
+			Usage:    "number of consecutive healthy checks finishing the rollout",
+			Value:    inferences.DefaultHealthyChecks,
+			Required: false,
+		},
+		&cli.BoolFlag{
+			Name:     "no-rollback",
+			Usage:    "keep the new spec applied when the deployment does not become healthy",
+			Required: false,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		return rolloutDeployment(c)
+	},
+}
+
+func rolloutDeployment(c *cli.Context) error {
+	name, err := flags.GetFirstStringArg(c, deploymentNameText)
+	if err != nil {
+		_ = cli.ShowCommandHelp(c, "rollout")
+		return err
+	}
+
+	update := inferences.UpdateInferenceDeploymentOpts{}
+	if c.IsSet("image") {
+		image := c.String("image")
+		update.Image = &image
+	}
+	if c.IsSet("flavor") {
+		flavor := c.String("flavor")
+		update.FlavorName = &flavor
+	}
+	if c.IsSet("listening-port") {
+		port := c.Int("listening-port")
+		update.ListeningPort = &port
+	}
+	if c.IsSet("command") {
+		update.Command = strings.Fields(c.String("command"))
+	}
+	if c.IsSet("envs") {
+		update.Envs = map[string]string{}
+		for _, env := range c.StringSlice("envs") {
+			parts := strings.SplitN(env, "=", 2)
+			if len(parts) != 2 {
+				return cli.Exit(fmt.Errorf("wrong env format: %s", env), 1)
+			}
+			update.Envs[parts[0]] = parts[1]
+		}
+	}
+
+	opts := inferences.RolloutOpts{
+		Update:          update,
+		Timeout:         c.Duration("timeout"),
+		PollInterval:    c.Duration("poll-interval"),
+		HealthyChecks:   c.Int("healthy-checks"),
+		DisableRollback: c.Bool("no-rollback"),
+	}
+	if c.IsSet("canary-region") {
+		opts.Canary = &inferences.CanaryOpts{RegionID: c.Int("canary-region")}
+	}
+
+	cl, err := client.NewInferenceClientV3(c)
+	if err != nil {
+		_ = cli.ShowAppHelp(c)
+		return cli.Exit(err, 1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	result, err := inferences.Rollout(ctx, cl, name, opts)
+	if result != nil {
+		utils.ShowResults(result, c.String("format"))
+	}
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	return nil
+}
+
+var specFileFlag = &cli.StringFlag{
+	Name:     "file",
+	Aliases:  []string{"f"},
+	Usage:    "inference deployment spec file in YAML or JSON",
+	Required: true,
+}
+
+var validateCommand = cli.Command{
+	Name:     "validate",
+	Usage:    "validate an inference deployment spec file against the project regions, flavors, registry credentials and secrets.",
+	Category: "inference",
+	Flags: []cli.Flag{
+		specFileFlag,
+		&cli.BoolFlag{
+			Name:     "local",
+			Usage:    "validate the spec file only, without checking the references",
+			Required: false,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		s, err := loadSpec(c, !c.Bool("local"))
+		if err != nil {
+			return cli.Exit(err, 1)
+		}
+		fmt.Printf("%s is valid\n", s.Name)
+		return nil
+	},
+}
+
+var applyCommand = cli.Command{
+	Name:     "apply",
+	Usage:    "create an inference deployment from a spec file or update the fields which differ from it.",
+	Category: "inference",
+	Flags: []cli.Flag{
+		specFileFlag,
+		&cli.BoolFlag{
+			Name:     "dry-run",
+			Usage:    "show the changes without applying them",
+			Required: false,
+		},
+		&cli.BoolFlag{
+			Name:     "skip-references",
+			Usage:    "do not check the regions, flavor, registry credentials and secrets the spec references",
+			Required: false,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		s, err := loadSpec(c, !c.Bool("skip-references"))
+		if err != nil {
+			return cli.Exit(err, 1)
+		}
+		cl, err := client.NewInferenceClientV3(c)
+		if err != nil {
+			_ = cli.ShowAppHelp(c)
+			return cli.Exit(err, 1)
+		}
+		result, err := spec.Apply(cl, s, spec.ApplyOpts{DryRun: c.Bool("dry-run")})
+		if err != nil {
+			return cli.Exit(err, 1)
+		}
+		utils.ShowResults(result, c.String("format"))
+		return nil
+	},
+}
+
+// loadSpec reads the spec file and optionally checks its references with the project catalog.
+func loadSpec(c *cli.Context, references bool) (*spec.Spec, error) {
+	s, err := spec.Load(c.String("file"))
+	if err != nil || !references {
+		return s, err
+	}
+	cl, err := client.NewInferenceClientV3(c)
+	if err != nil {
+		return nil, err
+	}
+	regions, err := regionclient.NewRegionClientV1(c)
+	if err != nil {
+		return nil, err
+	}
+	catalog, err := spec.LoadCatalog(spec.Clients{Inference: cl, Regions: regions})
+	if err != nil {
+		return nil, err
+	}
+	return s, catalog.Validate(s)
+}
@@ -0,0 +1,13 @@
+package client
+
+import (
+	"github.com/urfave/cli/v2"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/client/common"
+)
+
+// NewLaaSClientV1 creates a new LaaS client
+func NewLaaSClientV1(c *cli.Context) (*gcorecloud.ServiceClient, error) {
+	return common.BuildClient(c, "laas", "v1")
+}
@@ -0,0 +1,236 @@
+package laas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/client/laas/v1/client"
+	"github.com/G-Core/gcorelabscloud-go/client/utils"
+	"github.com/G-Core/gcorelabscloud-go/gcore/laas/v1/laas"
+	"github.com/G-Core/gcorelabscloud-go/gcore/laas/v1/laas/consumer"
+	"github.com/urfave/cli/v2"
+)
+
+var credentialFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    "username",
+		Usage:   "LaaS username",
+		EnvVars: []string{"LAAS_USERNAME"},
+	},
+	&cli.StringFlag{
+		Name:    "password",
+		Usage:   "LaaS password",
+		EnvVars: []string{"LAAS_PASSWORD"},
+	},
+	&cli.BoolFlag{
+		Name:  "regenerate-user",
+		Usage: "regenerate LaaS credentials instead of using username and password. Invalidates the previous password",
+	},
+}
+
+// laasUser returns the user of the credential flags, nil means the user is regenerated.
+func laasUser(c *cli.Context) (*laas.User, error) {
+	if c.Bool("regenerate-user") {
+		return nil, nil
+	}
+	if c.String("username") == "" || c.String("password") == "" {
+		return nil, fmt.Errorf("username and password are required unless --regenerate-user is set")
+	}
+	return &laas.User{Username: c.String("username"), Password: c.String("password")}, nil
+}
+
+// parseSince accepts a RFC3339 time or a duration before now.
+func parseSince(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected RFC3339 time or duration", value)
+	}
+	return t, nil
+}
+
+var logsCommand = cli.Command{
+	Name:      "logs",
+	Usage:     "Read messages of a LaaS topic",
+	ArgsUsage: " ",
+	Category:  "laas",
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:     "topic",
+			Usage:    "topic name",
+			Required: true,
+		},
+		&cli.BoolFlag{
+			Name:  "follow",
+			Usage: "wait for new messages",
+		},
+		&cli.GenericFlag{
+			Name: "from",
+			Value: &utils.EnumValue{
+				Enum:    []string{"oldest", "newest"},
+				Default: "newest",
+			},
+			Usage: "start from the oldest or newest messages. Ignored with --last or --since",
+		},
+		&cli.IntFlag{
+			Name:  "last",
+			Usage: "start from the last messages of every partition",
+		},
+		&cli.StringFlag{
+			Name:  "since",
+			Usage: "start from messages after RFC3339 time or duration, e.g. 15m",
+		},
+		&cli.BoolFlag{
+			Name:  "raw",
+			Usage: "show only message values",
+		},
+	}, credentialFlags...),
+	Action: func(c *cli.Context) error {
+		laasClient, err := client.NewLaaSClientV1(c)
+		if err != nil {
+			_ = cli.ShowAppHelp(c)
+			return cli.NewExitError(err, 1)
+		}
+		user, err := laasUser(c)
+		if err != nil {
+			_ = cli.ShowCommandHelp(c, "logs")
+			return cli.NewExitError(err, 1)
+		}
+		since, err := parseSince(c.String("since"))
+		if err != nil {
+			_ = cli.ShowCommandHelp(c, "logs")
+			return cli.NewExitError(err, 1)
+		}
+		opts := consumer.TailOpts{
+			Topic:  c.String("topic"),
+			Offset: consumer.OffsetNewest,
+			Since:  since,
+			Last:   c.Int("last"),
+			Follow: c.Bool("follow"),
+		}
+		if c.String("from") == "oldest" {
+			opts.Offset = consumer.OffsetOldest
+		}
+
+		logs, err := consumer.NewLaaS(laasClient, consumer.LaaSOpts{User: user})
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		defer logs.Close()
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		err = logs.Tail(ctx, opts, func(m consumer.Message) error {
+			if c.Bool("raw") {
+				_, err := fmt.Fprintln(c.App.Writer, string(m.Value))
+				return err
+			}
+			_, err := fmt.Fprintf(c.App.Writer, "%s %d/%d %s\n", m.Timestamp.Format(time.RFC3339Nano), m.Partition, m.Offset, m.Value)
+			return err
+		})
+		if err != nil && !errors.Is(err, context.Canceled) {
+			return cli.NewExitError(err, 1)
+		}
+		return nil
+	},
+}
+
+var searchCommand = cli.Command{
+	Name:      "search",
+	Usage:     "Search LaaS logs in OpenSearch",
+	ArgsUsage: " ",
+	Category:  "laas",
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:     "index",
+			Usage:    "index name or pattern",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:  "query",
+			Usage: "query string, e.g. level:error",
+		},
+		&cli.StringFlag{
+			Name:  "since",
+			Usage: "RFC3339 time or duration, e.g. 15m",
+		},
+		&cli.StringFlag{
+			Name:  "until",
+			Usage: "RFC3339 time or duration, e.g. 5m",
+		},
+		&cli.IntFlag{
+			Name:  "size",
+			Usage: "number of hits",
+			Value: consumer.DefaultSearchSize,
+		},
+		&cli.BoolFlag{
+			Name:  "ascending",
+			Usage: "show the oldest hits first",
+		},
+	}, credentialFlags...),
+	Action: func(c *cli.Context) error {
+		laasClient, err := client.NewLaaSClientV1(c)
+		if err != nil {
+			_ = cli.ShowAppHelp(c)
+			return cli.NewExitError(err, 1)
+		}
+		user, err := laasUser(c)
+		if err != nil {
+			_ = cli.ShowCommandHelp(c, "search")
+			return cli.NewExitError(err, 1)
+		}
+		since, err := parseSince(c.String("since"))
+		if err != nil {
+			_ = cli.ShowCommandHelp(c, "search")
+			return cli.NewExitError(err, 1)
+		}
+		until, err := parseSince(c.String("until"))
+		if err != nil {
+			_ = cli.ShowCommandHelp(c, "search")
+			return cli.NewExitError(err, 1)
+		}
+		opts := consumer.SearchOpts{
+			Index:     c.String("index"),
+			Query:     c.String("query"),
+			Since:     since,
+			Until:     until,
+			Size:      c.Int("size"),
+			Ascending: c.Bool("ascending"),
+		}
+		if err := gcorecloud.ValidateStruct(opts); err != nil {
+			_ = cli.ShowCommandHelp(c, "search")
+			return cli.NewExitError(err, 1)
+		}
+
+		search, err := consumer.NewSearchClient(laasClient, user)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		result, err := search.Search(c.Context, opts)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		utils.ShowResults(result, c.String("format"))
+		return nil
+	},
+}
+
+var Commands = cli.Command{
+	Name:  "laas",
+	Usage: "GCloud LaaS API",
+	Subcommands: []*cli.Command{
+		&logsCommand,
+		&searchCommand,
+	},
+}
@@ -4,12 +4,17 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 	"unicode/utf8"
 
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
 	"github.com/G-Core/gcorelabscloud-go/client/flags"
 	"github.com/G-Core/gcorelabscloud-go/client/lifecyclepolicy/v1/client"
 	"github.com/G-Core/gcorelabscloud-go/client/utils"
+	volumesclient "github.com/G-Core/gcorelabscloud-go/client/volumes/v1/client"
 	"github.com/G-Core/gcorelabscloud-go/gcore/lifecyclepolicy/v1/lifecyclepolicy"
+	"github.com/G-Core/gcorelabscloud-go/gcore/lifecyclepolicy/v1/lifecyclepolicy/assignment"
+	"github.com/G-Core/gcorelabscloud-go/gcore/volume/v1/volumes"
 	"github.com/urfave/cli/v2"
 )
 
@@ -34,6 +39,8 @@ var Commands = cli.Command{
 		&updateSubCommand,
 		&volumeSubCommands,
 		&scheduleSubCommands,
+		&simulateSubCommand,
+		&reconcileSubCommand,
 	},
 }
 
@@ -553,3 +560,175 @@ var removeScheduleSubCommand = cli.Command{
 		return nil
 	},
 }
+
+func extractPeriod(c *cli.Context) (time.Duration, error) {
+	s := c.String("period")
+	n, suffix, err := splitArg(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value of period flag (%s)", s)
+	}
+	switch suffix {
+	case 'W':
+		return lifecyclepolicy.RetentionTimer{Weeks: n}.Duration(), nil
+	case 'D':
+		return lifecyclepolicy.RetentionTimer{Days: n}.Duration(), nil
+	case 'H':
+		return lifecyclepolicy.RetentionTimer{Hours: n}.Duration(), nil
+	case 'M':
+		return lifecyclepolicy.RetentionTimer{Minutes: n}.Duration(), nil
+	}
+	return 0, fmt.Errorf("invalid value of period flag (%s)", s)
+}
+
+// extractVolumeSizes reads sizes of the volume_size flag and gets the sizes of other volumes of the policy.
+func extractVolumeSizes(c *cli.Context, policy *lifecyclepolicy.LifecyclePolicy) (map[string]int, error) {
+	sizes := map[string]int{}
+	for _, s := range c.StringSlice("volume_size") {
+		id, size, found := strings.Cut(s, "=")
+		n, err := strconv.Atoi(size)
+		if !found || err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid value of volume_size flag (%s)", s)
+		}
+		sizes[id] = n
+	}
+	var volumesClient *gcorecloud.ServiceClient
+	for _, v := range policy.Volumes {
+		if _, ok := sizes[v.ID]; ok {
+			continue
+		}
+		if volumesClient == nil {
+			var err error
+			if volumesClient, err = volumesclient.NewVolumeClientV1(c); err != nil {
+				return nil, err
+			}
+		}
+		volume, err := volumes.Get(volumesClient, v.ID).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("cannot get size of volume %s: %w", v.ID, err)
+		}
+		sizes[v.ID] = volume.Size
+	}
+	return sizes, nil
+}
+
+var simulateSubCommand = cli.Command{
+	Name:      "simulate",
+	Usage:     "simulate snapshots created and deleted by lifecycle policy",
+	ArgsUsage: argsUsagePolicyID,
+	Category:  category,
+	Flags: []cli.Flag{
+		&cli.TimestampFlag{
+			Name:     "start",
+			Usage:    "Start of the simulation in RFC3339 format. Current time by default",
+			Layout:   time.RFC3339,
+			Required: false,
+		},
+		&cli.StringFlag{
+			Name:     "period",
+			Usage:    "Simulated period. Should be in format nX, where n is a positive integer and X is one of W, D, H, M (weeks, days, hours, minutes respectively)",
+			Value:    "30D",
+			Required: false,
+		},
+		&cli.StringSliceFlag{
+			Name:     "volume_size",
+			Usage:    "Volume size in GiB as volume_id=size. Sizes of other volumes of the policy are requested. Volumes which are not in the policy are simulated as well",
+			Required: false,
+		},
+		&cli.BoolFlag{
+			Name:     "timeline",
+			Usage:    "Show snapshot creations and deletions instead of the summary",
+			Required: false,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		lifecyclePolicyID, err := flags.GetFirstIntArg(c, idErrorText)
+		if err != nil {
+			_ = cli.ShowCommandHelp(c, "simulate")
+			return err
+		}
+		period, err := extractPeriod(c)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		client, err := client.NewLifecyclePolicyClientV1(c)
+		if err != nil {
+			_ = cli.ShowAppHelp(c)
+			return cli.NewExitError(err, 1)
+		}
+		policy, err := lifecyclepolicy.Get(client, lifecyclePolicyID, lifecyclepolicy.GetOpts{NeedVolumes: true}).Extract()
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		sizes, err := extractVolumeSizes(c, policy)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		start := time.Now().UTC().Truncate(time.Minute)
+		if t := c.Timestamp("start"); t != nil {
+			start = *t
+		}
+		opts := lifecyclepolicy.SimulateOpts{Start: start, End: start.Add(period), VolumeSizes: sizes}
+		result, err := lifecyclepolicy.Simulate(*policy, opts)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if c.Bool("timeline") {
+			utils.ShowResults(result.Events, c.String("format"))
+			return nil
+		}
+		result.Events = nil
+		utils.ShowResults(result, c.String("format"))
+		return nil
+	},
+}
+
+var reconcileSubCommand = cli.Command{
+	Name:     "reconcile",
+	Usage:    "assign volumes to lifecycle policies by metadata selectors, volumes matching no rule of their policy are removed",
+	Category: category,
+	Flags: []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:     "rule",
+			Usage:    "Volumes matching the metadata are assigned to the policy. Should be in format policy_id:key=value[,key=value...], e.g. 12:backup=daily",
+			Required: true,
+		},
+		&cli.BoolFlag{
+			Name:     "dry-run",
+			Usage:    "Only show the drift, policies are not changed",
+			Required: false,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		opts := assignment.Opts{DryRun: c.Bool("dry-run")}
+		for _, s := range c.StringSlice("rule") {
+			rule, err := assignment.ParseRule(s)
+			if err != nil {
+				_ = cli.ShowCommandHelp(c, "reconcile")
+				return cli.NewExitError(err, 1)
+			}
+			opts.Rules = append(opts.Rules, rule)
+		}
+		policyClient, err := client.NewLifecyclePolicyClientV1(c)
+		if err != nil {
+			_ = cli.ShowAppHelp(c)
+			return cli.NewExitError(err, 1)
+		}
+		volumeClient, err := volumesclient.NewVolumeClientV1(c)
+		if err != nil {
+			_ = cli.ShowAppHelp(c)
+			return cli.NewExitError(err, 1)
+		}
+		reconciler, err := assignment.New(assignment.Clients{LifecyclePolicy: policyClient, Volumes: volumeClient}, opts)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		drifts, err := reconciler.Reconcile()
+		if drifts != nil {
+			utils.ShowResults(drifts, c.String("format"))
+		}
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		return nil
+	},
+}
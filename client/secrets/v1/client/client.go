@@ -10,3 +10,7 @@ import (
 func NewSecretClientV1(c *cli.Context) (*gcorecloud.ServiceClient, error) {
 	return common.BuildClient(c, "secrets", "v1")
 }
+
+func NewSecretClientV2(c *cli.Context) (*gcorecloud.ServiceClient, error) {
+	return common.BuildClient(c, "secrets", "v2")
+}
@@ -6,9 +6,11 @@ import (
 
 	gcorecloud "github.com/G-Core/gcorelabscloud-go"
 	"github.com/G-Core/gcorelabscloud-go/client/flags"
+	lbclient "github.com/G-Core/gcorelabscloud-go/client/loadbalancers/v1/client"
 	"github.com/G-Core/gcorelabscloud-go/client/secrets/v1/client"
 	"github.com/G-Core/gcorelabscloud-go/client/utils"
 	"github.com/G-Core/gcorelabscloud-go/gcore/secret/v1/secrets"
+	"github.com/G-Core/gcorelabscloud-go/gcore/secret/v2/certs"
 	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
 	"github.com/urfave/cli/v2"
 )
@@ -23,6 +25,8 @@ var Commands = cli.Command{
 		&secretGetCommand,
 		&secretDeleteCommand,
 		&secretCreateCommand,
+		&secretExpiringCommand,
+		&secretRotateCommand,
 	},
 }
 
@@ -215,3 +219,151 @@ var secretCreateCommand = cli.Command{
 		})
 	},
 }
+
+var secretExpiringCommand = cli.Command{
+	Name:  "expiring",
+	Usage: "List secrets expiring within the given number of days",
+	Flags: []cli.Flag{
+		&cli.IntFlag{
+			Name:     "days",
+			Aliases:  []string{"d"},
+			Usage:    "expiration period in days",
+			Value:    30,
+			Required: false,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		client, err := client.NewSecretClientV1(c)
+		if err != nil {
+			_ = cli.ShowAppHelp(c)
+			return cli.NewExitError(err, 1)
+		}
+		results, err := certs.ListExpiring(client, time.Duration(c.Int("days"))*24*time.Hour)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		utils.ShowResults(results, c.String("format"))
+		return nil
+	},
+}
+
+var secretRotateCommand = cli.Command{
+	Name:      "rotate",
+	Usage:     "Upload a new certificate and replace the listener secret with it",
+	ArgsUsage: "<listener_id>",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "name",
+			Aliases:  []string{"n"},
+			Usage:    "new secret name",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     "certificate",
+			Usage:    "PEM certificate file",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     "certificate-chain",
+			Usage:    "PEM certificate chain file",
+			Required: false,
+		},
+		&cli.StringFlag{
+			Name:     "private-key",
+			Usage:    "PEM private key file",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     "secret-id",
+			Usage:    "secret to replace. Defaults to the listener secret",
+			Required: false,
+		},
+		&cli.StringSliceFlag{
+			Name:     "dns-name",
+			Usage:    "DNS name the certificate must cover",
+			Required: false,
+		},
+		&cli.IntFlag{
+			Name:     "min-validity-days",
+			Usage:    "minimum remaining validity of the certificate in days",
+			Required: false,
+		},
+		&cli.BoolFlag{
+			Name:     "keep-old",
+			Usage:    "do not delete the replaced secret",
+			Required: false,
+		},
+		&cli.IntFlag{
+			Name:     "wait-seconds",
+			Usage:    "Required amount of time in seconds to wait for each task",
+			Value:    certs.DefaultWaitSeconds,
+			Required: false,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		listenerID, err := flags.GetFirstStringArg(c, "listener_id is mandatory argument")
+		if err != nil {
+			_ = cli.ShowCommandHelp(c, "rotate")
+			return err
+		}
+		certificate, err := utils.ReadFile(c.String("certificate"))
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		var chain []byte
+		if c.String("certificate-chain") != "" {
+			chain, err = utils.ReadFile(c.String("certificate-chain"))
+			if err != nil {
+				return cli.NewExitError(err, 1)
+			}
+		}
+		privateKey, err := utils.ReadFile(c.String("private-key"))
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		bundle, err := certs.ParseBundle(string(certificate), string(chain), string(privateKey))
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+
+		secretClient, err := client.NewSecretClientV1(c)
+		if err != nil {
+			_ = cli.ShowAppHelp(c)
+			return cli.NewExitError(err, 1)
+		}
+		secretClientV2, err := client.NewSecretClientV2(c)
+		if err != nil {
+			_ = cli.ShowAppHelp(c)
+			return cli.NewExitError(err, 1)
+		}
+		listenerClient, err := lbclient.NewLBListenerClientV2(c)
+		if err != nil {
+			_ = cli.ShowAppHelp(c)
+			return cli.NewExitError(err, 1)
+		}
+
+		result, err := certs.RotateListener(certs.Clients{
+			Listeners: listenerClient,
+			Secrets:   secretClient,
+			SecretsV2: secretClientV2,
+		}, certs.RotateOpts{
+			ListenerID:    listenerID,
+			Name:          c.String("name"),
+			Bundle:        bundle,
+			SecretID:      c.String("secret-id"),
+			KeepOldSecret: c.Bool("keep-old"),
+			Validate: certs.ValidateOpts{
+				MinValidity: time.Duration(c.Int("min-validity-days")) * 24 * time.Hour,
+				DNSNames:    c.StringSlice("dns-name"),
+			},
+			WaitSeconds: c.Int("wait-seconds"),
+		})
+		if result != nil {
+			utils.ShowResults(result, c.String("format"))
+		}
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		return nil
+	},
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	instanceclient "github.com/G-Core/gcorelabscloud-go/client/instances/v1/client"
 	"github.com/G-Core/gcorelabscloud-go/client/securitygroups/v1/client"
 
 	"github.com/G-Core/gcorelabscloud-go/client/securitygroups/v1/securitygrouprules"
@@ -13,6 +14,7 @@ import (
 	"github.com/G-Core/gcorelabscloud-go/client/flags"
 	"github.com/G-Core/gcorelabscloud-go/client/utils"
 	"github.com/G-Core/gcorelabscloud-go/gcore/securitygroup/v1/securitygroups"
+	"github.com/G-Core/gcorelabscloud-go/gcore/securitygroup/v1/securitygroups/analyze"
 	"github.com/urfave/cli/v2"
 )
 
@@ -391,6 +393,102 @@ var securityGroupDeepCopySubCommand = cli.Command{
 	},
 }
 
+var securityGroupAuditSubCommand = cli.Command{
+	Name:     "audit",
+	Usage:    "Audit effective access of security groups attached to instances",
+	Category: "securitygroup",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "remote",
+			Usage:    "remote ip address or cidr to check access for",
+			Required: false,
+		},
+		&cli.StringFlag{
+			Name:     "instance-id",
+			Aliases:  []string{"i"},
+			Usage:    "instance to check access to",
+			Required: false,
+		},
+		&cli.GenericFlag{
+			Name:    "protocol",
+			Aliases: []string{"p"},
+			Value: &utils.EnumValue{
+				Enum:    protocolTypeList,
+				Default: types.ProtocolTCP.String(),
+			},
+			Usage:    fmt.Sprintf("output in %s", strings.Join(protocolTypeList, ", ")),
+			Required: false,
+		},
+		&cli.IntFlag{
+			Name:     "port",
+			Usage:    "port or icmp type",
+			Required: false,
+		},
+		&cli.GenericFlag{
+			Name:    "direction",
+			Aliases: []string{"dr"},
+			Value: &utils.EnumValue{
+				Enum:    directionTypeList,
+				Default: types.RuleDirectionIngress.String(),
+			},
+			Usage:    fmt.Sprintf("output in %s", strings.Join(directionTypeList, ", ")),
+			Required: false,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		remote := c.String("remote")
+		instanceID := c.String("instance-id")
+		if (remote == "") != (instanceID == "") {
+			_ = cli.ShowCommandHelp(c, "audit")
+			return cli.NewExitError(fmt.Errorf("remote and instance-id parameters should be set together"), 1)
+		}
+		sgClient, err := client.NewSecurityGroupClientV1(c)
+		if err != nil {
+			_ = cli.ShowAppHelp(c)
+			return cli.NewExitError(err, 1)
+		}
+		instanceClient, err := instanceclient.NewInstanceClientV1(c)
+		if err != nil {
+			_ = cli.ShowAppHelp(c)
+			return cli.NewExitError(err, 1)
+		}
+		snapshot, err := analyze.Load(sgClient, instanceClient)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+
+		if instanceID != "" {
+			verdict, err := snapshot.CanReach(analyze.Query{
+				Remote:     remote,
+				InstanceID: instanceID,
+				Protocol:   types.Protocol(c.String("protocol")),
+				Port:       c.Int("port"),
+				Direction:  types.RuleDirection(c.String("direction")),
+			})
+			if err != nil {
+				return cli.NewExitError(err, 1)
+			}
+			utils.ShowResults(verdict, c.String("format"))
+			return nil
+		}
+
+		unused := snapshot.UnusedGroups()
+		unusedIDs := make([]string, 0, len(unused))
+		for _, sg := range unused {
+			unusedIDs = append(unusedIDs, sg.ID)
+		}
+		report := struct {
+			OpenToWorld  []analyze.Finding `json:"open_to_world"`
+			UnusedGroups []string          `json:"unused_groups"`
+		}{
+			OpenToWorld:  snapshot.OpenToWorld(),
+			UnusedGroups: unusedIDs,
+		}
+		utils.ShowResults(report, c.String("format"))
+		return nil
+	},
+}
+
 var Commands = cli.Command{
 	Name:  "securitygroup",
 	Usage: "GCloud security groups API",
@@ -401,6 +499,7 @@ var Commands = cli.Command{
 		&securityGroupDeleteSubCommand,
 		&securityGroupCreateSubCommand,
 		&securityGroupDeepCopySubCommand,
+		&securityGroupAuditSubCommand,
 		{
 			Name:  "instance",
 			Usage: "Security group instances",
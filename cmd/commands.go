@@ -9,6 +9,7 @@ import (
 	"github.com/G-Core/gcorelabscloud-go/client/apitokens/v1/apitokens"
 	"github.com/G-Core/gcorelabscloud-go/client/apptemplates/v1/apptemplates"
 	"github.com/G-Core/gcorelabscloud-go/client/dbaas/postgres/v1"
+	"github.com/G-Core/gcorelabscloud-go/client/ddos/v1/ddos"
 	"github.com/G-Core/gcorelabscloud-go/client/faas/v1/functions"
 	"github.com/G-Core/gcorelabscloud-go/client/file_shares/v1/file_shares"
 	"github.com/G-Core/gcorelabscloud-go/client/flags"
@@ -17,11 +18,13 @@ import (
 	"github.com/G-Core/gcorelabscloud-go/client/gpu/v3"
 	"github.com/G-Core/gcorelabscloud-go/client/heat"
 	"github.com/G-Core/gcorelabscloud-go/client/images/v1/images"
+	"github.com/G-Core/gcorelabscloud-go/client/inference/v3/inferences"
 	"github.com/G-Core/gcorelabscloud-go/client/instances/v1/instances"
 	"github.com/G-Core/gcorelabscloud-go/client/k8s/v2/k8s"
 	"github.com/G-Core/gcorelabscloud-go/client/keypairs/v2/keypairs"
 	"github.com/G-Core/gcorelabscloud-go/client/keystones/v1/keystones"
 	"github.com/G-Core/gcorelabscloud-go/client/l7policies/v1/l7policies"
+	"github.com/G-Core/gcorelabscloud-go/client/laas/v1/laas"
 	"github.com/G-Core/gcorelabscloud-go/client/lifecyclepolicy/v1/lifecyclepolicy"
 	"github.com/G-Core/gcorelabscloud-go/client/limits/v2/limits"
 	"github.com/G-Core/gcorelabscloud-go/client/loadbalancers/v1/loadbalancers"
@@ -82,7 +85,10 @@ var commands = []*cli.Command{
 	&ais.Commands,
 	&functions.Commands,
 	&gpu.Commands,
+	&inferences.Commands,
 	&postgres.Commands,
+	&ddos.Commands,
+	&laas.Commands,
 }
 
 type clientCommands struct {
@@ -0,0 +1,76 @@
+package clusters
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+)
+
+// DefaultPort is used when the API returns no connection string of the cluster network.
+const DefaultPort = 5432
+
+// endpoint returns the host and port of the connection string returned by the API for the cluster.
+func (c PostgresSQLCluster) endpoint() (string, int, error) {
+	host, port := c.Network.Host, DefaultPort
+	if c.Network.ConnectionString != "" {
+		u, err := url.Parse(c.Network.ConnectionString)
+		if err != nil {
+			return "", 0, fmt.Errorf("invalid connection string of cluster %s: %w", c.ClusterName, err)
+		}
+		if u.Hostname() != "" {
+			host = u.Hostname()
+		}
+		if u.Port() != "" {
+			if port, err = strconv.Atoi(u.Port()); err != nil {
+				return "", 0, fmt.Errorf("invalid connection string of cluster %s: %w", c.ClusterName, err)
+			}
+		}
+	}
+	if host == "" {
+		return "", 0, fmt.Errorf("cluster %s has no host yet", c.ClusterName)
+	}
+	return host, port, nil
+}
+
+// ConnectionString builds a postgresql:// URI for the database and user of the cluster. The password is not included.
+// It uses the endpoint of the connection string returned by the API as is, also for clusters with a pooler,
+// so whether connections go through the pooler depends on that endpoint. The network host and DefaultPort
+// are used when the API returns no connection string.
+func ConnectionString(cluster *PostgresSQLCluster, db, user string) (string, error) {
+	return ConnectionStringWithPort(cluster, db, user, 0)
+}
+
+// ConnectionStringWithPort builds a postgresql:// URI like ConnectionString with another port of the cluster host,
+// e.g. a server or pooler port known for the cluster. The port of the API endpoint is used when port is zero.
+func ConnectionStringWithPort(cluster *PostgresSQLCluster, db, user string, port int) (string, error) {
+	if cluster.Database(db) == nil {
+		return "", fmt.Errorf("database %s not found in cluster %s", db, cluster.ClusterName)
+	}
+	u := cluster.User(user)
+	if u == nil {
+		return "", fmt.Errorf("user %s not found in cluster %s", user, cluster.ClusterName)
+	}
+	if !slices.Contains(u.RoleAttributes, RoleAttributeLogin) || slices.Contains(u.RoleAttributes, RoleAttributeNoLogin) {
+		return "", fmt.Errorf("user %s of cluster %s cannot log in", user, cluster.ClusterName)
+	}
+	host, endpointPort, err := cluster.endpoint()
+	if err != nil {
+		return "", err
+	}
+	if port < 0 || port > 65535 {
+		return "", fmt.Errorf("invalid port %d", port)
+	}
+	if port == 0 {
+		port = endpointPort
+	}
+	result := url.URL{
+		Scheme:   "postgresql",
+		User:     url.User(user),
+		Host:     net.JoinHostPort(host, strconv.Itoa(port)),
+		Path:     "/" + db,
+		RawQuery: url.Values{"sslmode": {"require"}}.Encode(),
+	}
+	return result.String(), nil
+}
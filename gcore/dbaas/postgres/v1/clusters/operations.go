@@ -0,0 +1,210 @@
+package clusters
+
+import (
+	"fmt"
+	"slices"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+)
+
+// DatabaseOpts returns the databases of the cluster in the form accepted by UpdateOpts.
+func (c PostgresSQLCluster) DatabaseOpts() []DatabaseOpts {
+	databases := make([]DatabaseOpts, 0, len(c.Databases))
+	for _, db := range c.Databases {
+		databases = append(databases, DatabaseOpts{Name: db.Name, Owner: db.Owner})
+	}
+	return databases
+}
+
+// UserOpts returns the users of the cluster in the form accepted by UpdateOpts.
+func (c PostgresSQLCluster) UserOpts() []PgUserOpts {
+	users := make([]PgUserOpts, 0, len(c.Users))
+	for _, user := range c.Users {
+		users = append(users, PgUserOpts{Name: user.Name, RoleAttributes: user.RoleAttributes})
+	}
+	return users
+}
+
+// Database returns the database with the name, nil if the cluster has no such database.
+func (c PostgresSQLCluster) Database(name string) *DatabaseOverview {
+	for i := range c.Databases {
+		if c.Databases[i].Name == name {
+			return &c.Databases[i]
+		}
+	}
+	return nil
+}
+
+// User returns the user with the name, nil if the cluster has no such user.
+func (c PostgresSQLCluster) User(name string) *PgUserOverview {
+	for i := range c.Users {
+		if c.Users[i].Name == name {
+			return &c.Users[i]
+		}
+	}
+	return nil
+}
+
+// modify gets the cluster, applies the change to it and sends the resulting update.
+func modify(client *gcorecloud.ServiceClient, clusterName string, change func(cluster *PostgresSQLCluster) (UpdateOpts, error)) (r tasks.Result) {
+	cluster, err := Get(client, clusterName).Extract()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	opts, err := change(cluster)
+	if err != nil {
+		r.Err = err
+		return
+	}
+	return Update(client, clusterName, opts)
+}
+
+// AddDatabase adds a database to the cluster. The owner must be an existing user of the cluster.
+func AddDatabase(client *gcorecloud.ServiceClient, clusterName string, opts DatabaseOpts) (r tasks.Result) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		r.Err = err
+		return
+	}
+	return modify(client, clusterName, func(cluster *PostgresSQLCluster) (UpdateOpts, error) {
+		if cluster.Database(opts.Name) != nil {
+			return UpdateOpts{}, fmt.Errorf("database %s already exists in cluster %s", opts.Name, clusterName)
+		}
+		if cluster.User(opts.Owner) == nil {
+			return UpdateOpts{}, fmt.Errorf("owner %s of database %s not found in cluster %s", opts.Owner, opts.Name, clusterName)
+		}
+		return UpdateOpts{Databases: append(cluster.DatabaseOpts(), opts)}, nil
+	})
+}
+
+// RemoveDatabase removes a database from the cluster.
+func RemoveDatabase(client *gcorecloud.ServiceClient, clusterName, name string) (r tasks.Result) {
+	return modify(client, clusterName, func(cluster *PostgresSQLCluster) (UpdateOpts, error) {
+		if cluster.Database(name) == nil {
+			return UpdateOpts{}, fmt.Errorf("database %s not found in cluster %s", name, clusterName)
+		}
+		if len(cluster.Databases) == 1 {
+			return UpdateOpts{}, fmt.Errorf("cannot remove the last database %s of cluster %s", name, clusterName)
+		}
+		databases := slices.DeleteFunc(cluster.DatabaseOpts(), func(db DatabaseOpts) bool { return db.Name == name })
+		return UpdateOpts{Databases: databases}, nil
+	})
+}
+
+// SetDatabaseOwner changes the owner of a database of the cluster.
+func SetDatabaseOwner(client *gcorecloud.ServiceClient, clusterName, name, owner string) (r tasks.Result) {
+	return modify(client, clusterName, func(cluster *PostgresSQLCluster) (UpdateOpts, error) {
+		db := cluster.Database(name)
+		if db == nil {
+			return UpdateOpts{}, fmt.Errorf("database %s not found in cluster %s", name, clusterName)
+		}
+		if cluster.User(owner) == nil {
+			return UpdateOpts{}, fmt.Errorf("owner %s of database %s not found in cluster %s", owner, name, clusterName)
+		}
+		if db.Owner == owner {
+			return UpdateOpts{}, fmt.Errorf("database %s of cluster %s is already owned by %s", name, clusterName, owner)
+		}
+		databases := cluster.DatabaseOpts()
+		for i := range databases {
+			if databases[i].Name == name {
+				databases[i].Owner = owner
+			}
+		}
+		return UpdateOpts{Databases: databases}, nil
+	})
+}
+
+// AddUser adds a user to the cluster.
+func AddUser(client *gcorecloud.ServiceClient, clusterName string, opts PgUserOpts) (r tasks.Result) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		r.Err = err
+		return
+	}
+	return modify(client, clusterName, func(cluster *PostgresSQLCluster) (UpdateOpts, error) {
+		if cluster.User(opts.Name) != nil {
+			return UpdateOpts{}, fmt.Errorf("user %s already exists in cluster %s", opts.Name, clusterName)
+		}
+		return UpdateOpts{Users: append(cluster.UserOpts(), opts)}, nil
+	})
+}
+
+// UpdateUser replaces the role attributes of a user of the cluster.
+func UpdateUser(client *gcorecloud.ServiceClient, clusterName string, opts PgUserOpts) (r tasks.Result) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		r.Err = err
+		return
+	}
+	return modify(client, clusterName, func(cluster *PostgresSQLCluster) (UpdateOpts, error) {
+		if cluster.User(opts.Name) == nil {
+			return UpdateOpts{}, fmt.Errorf("user %s not found in cluster %s", opts.Name, clusterName)
+		}
+		users := cluster.UserOpts()
+		for i := range users {
+			if users[i].Name == opts.Name {
+				users[i] = opts
+			}
+		}
+		return UpdateOpts{Users: users}, nil
+	})
+}
+
+// RemoveUser removes a user from the cluster. Users owning a database cannot be removed.
+func RemoveUser(client *gcorecloud.ServiceClient, clusterName, name string) (r tasks.Result) {
+	return modify(client, clusterName, func(cluster *PostgresSQLCluster) (UpdateOpts, error) {
+		if cluster.User(name) == nil {
+			return UpdateOpts{}, fmt.Errorf("user %s not found in cluster %s", name, clusterName)
+		}
+		for _, db := range cluster.Databases {
+			if db.Owner == name {
+				return UpdateOpts{}, fmt.Errorf("user %s owns database %s of cluster %s", name, db.Name, clusterName)
+			}
+		}
+		users := slices.DeleteFunc(cluster.UserOpts(), func(user PgUserOpts) bool { return user.Name == name })
+		return UpdateOpts{Users: users}, nil
+	})
+}
+
+// Scale changes the flavor of the cluster.
+func Scale(client *gcorecloud.ServiceClient, clusterName string, opts FlavorOpts) (r tasks.Result) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		r.Err = err
+		return
+	}
+	return modify(client, clusterName, func(cluster *PostgresSQLCluster) (UpdateOpts, error) {
+		if cluster.Flavor.CPU == opts.CPU && cluster.Flavor.MemoryGiB == opts.MemoryGiB {
+			return UpdateOpts{}, fmt.Errorf("cluster %s already has %d CPU and %d GiB of memory", clusterName, opts.CPU, opts.MemoryGiB)
+		}
+		return UpdateOpts{Flavor: &opts}, nil
+	})
+}
+
+// ResizeStorage grows the storage of the cluster. Storage cannot be shrunk.
+func ResizeStorage(client *gcorecloud.ServiceClient, clusterName string, sizeGiB int) (r tasks.Result) {
+	opts := PGStorageConfigurationUpdateOpts{SizeGiB: sizeGiB}
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		r.Err = err
+		return
+	}
+	return modify(client, clusterName, func(cluster *PostgresSQLCluster) (UpdateOpts, error) {
+		if sizeGiB <= cluster.Storage.SizeGiB {
+			return UpdateOpts{}, fmt.Errorf("storage of cluster %s can only grow from %d GiB, got %d GiB", clusterName, cluster.Storage.SizeGiB, sizeGiB)
+		}
+		return UpdateOpts{Storage: &opts}, nil
+	})
+}
+
+// SetPoolerMode switches the pgbouncer pooler of the cluster to the mode, enabling the pooler when the cluster has none.
+func SetPoolerMode(client *gcorecloud.ServiceClient, clusterName string, mode PoolerMode) (r tasks.Result) {
+	pooler := PoolerOpts{Mode: mode, Type: PoolerTypePgBouncer}
+	if err := gcorecloud.ValidateStruct(pooler); err != nil {
+		r.Err = err
+		return
+	}
+	return modify(client, clusterName, func(cluster *PostgresSQLCluster) (UpdateOpts, error) {
+		if current := cluster.PGServerConfiguration.Pooler; current != nil && current.Mode == mode {
+			return UpdateOpts{}, fmt.Errorf("pooler of cluster %s is already in %s mode", clusterName, mode)
+		}
+		return UpdateOpts{PGServerConfiguration: &PGServerConfigurationUpdateOpts{Pooler: &pooler}}, nil
+	})
+}
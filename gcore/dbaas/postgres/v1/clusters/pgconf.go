@@ -0,0 +1,278 @@
+package clusters
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PGConfSetting is a parameter assignment of a pg_conf.
+type PGConfSetting struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	Line  int    `json:"line"`
+}
+
+// PGConf is a parsed pg_conf in the postgresql.conf format.
+type PGConf []PGConfSetting
+
+// ParsePGConf parses pg_conf settings. Every non-empty line is a "name = value" assignment, the equal sign is optional
+// and # starts a comment. Values may be single quoted, a quote inside is written twice or escaped with a backslash.
+func ParsePGConf(conf string) (PGConf, error) {
+	var result PGConf
+	var errs []error
+	for i, line := range strings.Split(conf, "\n") {
+		setting, err := parsePGConfLine(line)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", i+1, err))
+			continue
+		}
+		if setting != nil {
+			setting.Line = i + 1
+			result = append(result, *setting)
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+func parsePGConfLine(line string) (*PGConfSetting, error) {
+	line = strings.TrimSpace(line)
+	if line == "" || line[0] == '#' {
+		return nil, nil
+	}
+	end := strings.IndexFunc(line, func(r rune) bool { return !isPGConfNameRune(r) })
+	if end == -1 {
+		end = len(line)
+	}
+	if end == 0 {
+		return nil, fmt.Errorf("expected parameter name in %q", line)
+	}
+	name := strings.ToLower(line[:end])
+	rest := strings.TrimSpace(line[end:])
+	rest = strings.TrimSpace(strings.TrimPrefix(rest, "="))
+	if rest == "" || rest[0] == '#' {
+		return nil, fmt.Errorf("%s: missing value", name)
+	}
+
+	var value string
+	if rest[0] == '\'' {
+		var b strings.Builder
+		closed := false
+		i := 1
+		for i < len(rest) {
+			c := rest[i]
+			switch {
+			case c == '\'' && i+1 < len(rest) && rest[i+1] == '\'':
+				b.WriteByte('\'')
+				i += 2
+				continue
+			case c == '\\' && i+1 < len(rest):
+				b.WriteByte(rest[i+1])
+				i += 2
+				continue
+			case c == '\'':
+				closed = true
+			default:
+				b.WriteByte(c)
+			}
+			i++
+			if closed {
+				break
+			}
+		}
+		if !closed {
+			return nil, fmt.Errorf("%s: unterminated quoted value", name)
+		}
+		value, rest = b.String(), rest[i:]
+	} else {
+		end := strings.IndexAny(rest, " \t#")
+		if end == -1 {
+			end = len(rest)
+		}
+		value, rest = rest[:end], rest[end:]
+	}
+	if rest = strings.TrimSpace(rest); rest != "" && rest[0] != '#' {
+		return nil, fmt.Errorf("%s: unexpected %q after value", name, rest)
+	}
+	return &PGConfSetting{Name: name, Value: value}, nil
+}
+
+func isPGConfNameRune(r rune) bool {
+	return r == '_' || r == '.' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'
+}
+
+// Get returns the value of the last assignment of the parameter.
+func (conf PGConf) Get(name string) (string, bool) {
+	name = strings.ToLower(name)
+	for i := len(conf) - 1; i >= 0; i-- {
+		if conf[i].Name == name {
+			return conf[i].Value, true
+		}
+	}
+	return "", false
+}
+
+// Set returns the settings with the parameter assigned to the value, replacing earlier assignments.
+func (conf PGConf) Set(name, value string) PGConf {
+	name = strings.ToLower(name)
+	result := slices.DeleteFunc(slices.Clone(conf), func(s PGConfSetting) bool { return s.Name == name })
+	return append(result, PGConfSetting{Name: name, Value: value})
+}
+
+// String formats the settings as pg_conf, one "name=value" per line.
+func (conf PGConf) String() string {
+	var b strings.Builder
+	for _, s := range conf {
+		b.WriteString(s.Name)
+		b.WriteByte('=')
+		if s.Value != "" && strings.IndexFunc(s.Value, func(r rune) bool { return !isPGConfNameRune(r) && r != '-' }) == -1 {
+			b.WriteString(s.Value)
+		} else {
+			b.WriteString("'" + strings.ReplaceAll(s.Value, "'", "''") + "'")
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Validate checks the values of known parameters and rejects duplicated parameters.
+// Parameters unknown to the SDK are accepted as is.
+func (conf PGConf) Validate() error {
+	var errs []error
+	seen := map[string]int{}
+	for _, s := range conf {
+		if line, ok := seen[s.Name]; ok {
+			errs = append(errs, fmt.Errorf("line %d: %s is already set on line %d", s.Line, s.Name, line))
+			continue
+		}
+		seen[s.Name] = s.Line
+		if p, ok := pgParameters[s.Name]; ok {
+			if err := p.validate(s.Value); err != nil {
+				errs = append(errs, fmt.Errorf("line %d: %s: %w", s.Line, s.Name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidatePGConf parses and validates pg_conf settings.
+func ValidatePGConf(conf string) error {
+	parsed, err := ParsePGConf(conf)
+	if err != nil {
+		return err
+	}
+	return parsed.Validate()
+}
+
+type pgParameterKind int
+
+const (
+	pgBool pgParameterKind = iota
+	pgInteger
+	pgReal
+	pgMemory
+	pgTime
+	pgEnum
+)
+
+// pgParameter describes the accepted values of a parameter. Memory bounds are in kB, time bounds in milliseconds.
+type pgParameter struct {
+	kind pgParameterKind
+	min  float64
+	max  float64
+	// unit is the size in kB or milliseconds of a value without unit.
+	unit float64
+	enum []string
+}
+
+var pgMemoryUnits = map[string]float64{"B": 1.0 / 1024, "kB": 1, "MB": 1024, "GB": 1024 * 1024, "TB": 1024 * 1024 * 1024}
+
+var pgTimeUnits = map[string]float64{"us": 0.001, "ms": 1, "s": 1000, "min": 60 * 1000, "h": 60 * 60 * 1000, "d": 24 * 60 * 60 * 1000}
+
+var pgBoolValues = []string{"on", "off", "true", "false", "yes", "no", "1", "0"}
+
+const pgMaxInt = 2147483647
+
+var pgParameters = map[string]pgParameter{
+	"max_connections":                     {kind: pgInteger, min: 1, max: 262143},
+	"shared_buffers":                      {kind: pgMemory, min: 128, max: pgMaxInt * 8, unit: 8},
+	"effective_cache_size":                {kind: pgMemory, min: 8, max: pgMaxInt * 8, unit: 8},
+	"maintenance_work_mem":                {kind: pgMemory, min: 1024, max: pgMaxInt, unit: 1},
+	"work_mem":                            {kind: pgMemory, min: 64, max: pgMaxInt, unit: 1},
+	"temp_buffers":                        {kind: pgMemory, min: 800, max: pgMaxInt * 8, unit: 8},
+	"wal_buffers":                         {kind: pgMemory, min: -8, max: 262143 * 8, unit: 8},
+	"min_wal_size":                        {kind: pgMemory, min: 2 * 1024, max: pgMaxInt * 1024, unit: 1024},
+	"max_wal_size":                        {kind: pgMemory, min: 2 * 1024, max: pgMaxInt * 1024, unit: 1024},
+	"checkpoint_completion_target":        {kind: pgReal, min: 0, max: 1},
+	"checkpoint_timeout":                  {kind: pgTime, min: 30 * 1000, max: 86400 * 1000, unit: 1000},
+	"random_page_cost":                    {kind: pgReal, min: 0, max: 1.79769e+308},
+	"seq_page_cost":                       {kind: pgReal, min: 0, max: 1.79769e+308},
+	"effective_io_concurrency":            {kind: pgInteger, min: 0, max: 1000},
+	"default_statistics_target":           {kind: pgInteger, min: 1, max: 10000},
+	"max_worker_processes":                {kind: pgInteger, min: 0, max: 262143},
+	"max_parallel_workers":                {kind: pgInteger, min: 0, max: 1024},
+	"max_parallel_workers_per_gather":     {kind: pgInteger, min: 0, max: 1024},
+	"max_parallel_maintenance_workers":    {kind: pgInteger, min: 0, max: 1024},
+	"statement_timeout":                   {kind: pgTime, min: 0, max: pgMaxInt, unit: 1},
+	"lock_timeout":                        {kind: pgTime, min: 0, max: pgMaxInt, unit: 1},
+	"idle_in_transaction_session_timeout": {kind: pgTime, min: 0, max: pgMaxInt, unit: 1},
+	"log_min_duration_statement":          {kind: pgTime, min: -1, max: pgMaxInt, unit: 1},
+	"autovacuum":                          {kind: pgBool},
+	"jit":                                 {kind: pgBool},
+	"huge_pages":                          {kind: pgEnum, enum: []string{"on", "off", "try"}},
+	"synchronous_commit":                  {kind: pgEnum, enum: []string{"on", "off", "local", "remote_write", "remote_apply"}},
+}
+
+func (p pgParameter) validate(value string) error {
+	switch p.kind {
+	case pgBool:
+		if !slices.Contains(pgBoolValues, strings.ToLower(value)) {
+			return fmt.Errorf("invalid boolean %q", value)
+		}
+		return nil
+	case pgEnum:
+		if !slices.Contains(p.enum, strings.ToLower(value)) {
+			return fmt.Errorf("invalid value %q, expected one of %s", value, strings.Join(p.enum, ", "))
+		}
+		return nil
+	case pgInteger, pgReal:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil || p.kind == pgInteger && v != float64(int64(v)) {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		return p.checkRange(v, value)
+	}
+
+	units := pgMemoryUnits
+	if p.kind == pgTime {
+		units = pgTimeUnits
+	}
+	number := strings.TrimRightFunc(value, func(r rune) bool { return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' })
+	unit := strings.TrimSpace(value[len(number):])
+	v, err := strconv.ParseInt(strings.TrimSpace(number), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid value %q", value)
+	}
+	scale := p.unit
+	if unit != "" {
+		var ok bool
+		if scale, ok = units[unit]; !ok {
+			names := make([]string, 0, len(units))
+			for name := range units {
+				names = append(names, name)
+			}
+			slices.Sort(names)
+			return fmt.Errorf("invalid unit %q, expected one of %s", unit, strings.Join(names, ", "))
+		}
+	}
+	return p.checkRange(float64(v)*scale, value)
+}
+
+func (p pgParameter) checkRange(v float64, value string) error {
+	if v < p.min || v > p.max {
+		return fmt.Errorf("%s is out of range", value)
+	}
+	return nil
+}
@@ -233,3 +233,68 @@ const DeleteResponse = `
   "tasks": ["79dc7c30-44d2-4c5c-b5c1-e9a46f6bbf54"]
 }
 `
+
+const AddDatabaseRequest = `
+{
+  "databases": [
+    {"name": "testdb", "owner": "testuser"},
+    {"name": "reports", "owner": "testuser"}
+  ]
+}
+`
+
+const AddUserRequest = `
+{
+  "users": [
+    {"name": "testuser", "role_attributes": ["LOGIN", "CREATEDB"]},
+    {"name": "reader", "role_attributes": ["LOGIN"]}
+  ]
+}
+`
+
+const UpdateUserRequest = `
+{
+  "users": [
+    {"name": "testuser", "role_attributes": ["LOGIN", "CREATEROLE"]}
+  ]
+}
+`
+
+const ScaleRequest = `
+{
+  "flavor": {
+    "cpu": 4,
+    "memory_gib": 8
+  }
+}
+`
+
+const ResizeStorageRequest = `
+{
+  "storage": {
+    "size_gib": 60
+  }
+}
+`
+
+const SetPoolerModeRequest = `
+{
+  "pg_server_configuration": {
+    "pooler": {
+      "mode": "transaction",
+      "type": "pgbouncer"
+    }
+  }
+}
+`
+
+const PGConf = `
+# memory
+shared_buffers = 256MB
+work_mem='4MB'   # per sort
+wal_buffers=-1
+checkpoint_completion_target 0.9
+huge_pages = off
+search_path = '"$user", public'
+application_name = 'it''s'
+`
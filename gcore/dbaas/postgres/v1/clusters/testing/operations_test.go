@@ -0,0 +1,138 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/dbaas/postgres/v1/clusters"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+	"github.com/stretchr/testify/require"
+)
+
+// handleCluster serves the first cluster and expects an update with the request body, none when it is empty.
+func handleCluster(t *testing.T, request string) {
+	th.Mux.HandleFunc(prepareClusterTestURL(FirstClusterShort.ClusterName), func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		w.Header().Add("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			w.WriteHeader(http.StatusOK)
+			_, _ = fmt.Fprint(w, GetResponse)
+		case http.MethodPatch:
+			if request == "" {
+				t.Errorf("unexpected update")
+			} else {
+				th.TestJSONRequest(t, r, request)
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = fmt.Fprint(w, UpdateResponse)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+}
+
+func checkTask(t *testing.T, r tasks.Result) {
+	require.NoError(t, r.Err)
+	task, err := r.Extract()
+	require.NoError(t, err)
+	require.Equal(t, &ExpectedTaskResults, task)
+}
+
+func TestOperations(t *testing.T) {
+	name := FirstClusterShort.ClusterName
+	cases := []struct {
+		name    string
+		request string
+		run     func(client *gcorecloud.ServiceClient) tasks.Result
+	}{
+		{"AddDatabase", AddDatabaseRequest, func(client *gcorecloud.ServiceClient) tasks.Result {
+			return clusters.AddDatabase(client, name, clusters.DatabaseOpts{Name: "reports", Owner: "testuser"})
+		}},
+		{"AddUser", AddUserRequest, func(client *gcorecloud.ServiceClient) tasks.Result {
+			return clusters.AddUser(client, name, clusters.PgUserOpts{Name: "reader", RoleAttributes: []clusters.RoleAttribute{clusters.RoleAttributeLogin}})
+		}},
+		{"UpdateUser", UpdateUserRequest, func(client *gcorecloud.ServiceClient) tasks.Result {
+			return clusters.UpdateUser(client, name, clusters.PgUserOpts{Name: "testuser",
+				RoleAttributes: []clusters.RoleAttribute{clusters.RoleAttributeLogin, clusters.RoleAttributeCreateRole}})
+		}},
+		{"Scale", ScaleRequest, func(client *gcorecloud.ServiceClient) tasks.Result {
+			return clusters.Scale(client, name, clusters.FlavorOpts{CPU: 4, MemoryGiB: 8})
+		}},
+		{"ResizeStorage", ResizeStorageRequest, func(client *gcorecloud.ServiceClient) tasks.Result {
+			return clusters.ResizeStorage(client, name, 60)
+		}},
+		{"SetPoolerMode", SetPoolerModeRequest, func(client *gcorecloud.ServiceClient) tasks.Result {
+			return clusters.SetPoolerMode(client, name, clusters.PoolerModeTransaction)
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			th.SetupHTTP()
+			defer th.TeardownHTTP()
+			handleCluster(t, tc.request)
+			checkTask(t, tc.run(fake.ServiceTokenClient("dbaas/postgres/clusters", "v1")))
+		})
+	}
+}
+
+func TestOperationErrors(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	handleCluster(t, "")
+	client := fake.ServiceTokenClient("dbaas/postgres/clusters", "v1")
+	name := FirstClusterShort.ClusterName
+
+	require.EqualError(t, clusters.AddDatabase(client, name, clusters.DatabaseOpts{Name: "testdb", Owner: "testuser"}).Err,
+		"database testdb already exists in cluster test-cluster-1")
+	require.EqualError(t, clusters.AddDatabase(client, name, clusters.DatabaseOpts{Name: "reports", Owner: "reader"}).Err,
+		"owner reader of database reports not found in cluster test-cluster-1")
+	require.EqualError(t, clusters.RemoveDatabase(client, name, "testdb").Err,
+		"cannot remove the last database testdb of cluster test-cluster-1")
+	require.EqualError(t, clusters.SetDatabaseOwner(client, name, "testdb", "testuser").Err,
+		"database testdb of cluster test-cluster-1 is already owned by testuser")
+	require.EqualError(t, clusters.RemoveUser(client, name, "testuser").Err,
+		"user testuser owns database testdb of cluster test-cluster-1")
+	require.EqualError(t, clusters.RemoveUser(client, name, "reader").Err,
+		"user reader not found in cluster test-cluster-1")
+	require.EqualError(t, clusters.Scale(client, name, clusters.FlavorOpts{CPU: 2, MemoryGiB: 4}).Err,
+		"cluster test-cluster-1 already has 2 CPU and 4 GiB of memory")
+	require.EqualError(t, clusters.ResizeStorage(client, name, 40).Err,
+		"storage of cluster test-cluster-1 can only grow from 50 GiB, got 40 GiB")
+	require.EqualError(t, clusters.SetPoolerMode(client, name, clusters.PoolerModeSession).Err,
+		"pooler of cluster test-cluster-1 is already in session mode")
+	require.Error(t, clusters.SetPoolerMode(client, name, "batch").Err)
+	require.Error(t, clusters.ResizeStorage(client, name, 200).Err)
+}
+
+func TestConnectionString(t *testing.T) {
+	cluster := FirstClusterDetail
+	cs, err := clusters.ConnectionString(&cluster, "testdb", "testuser")
+	require.NoError(t, err)
+	require.Equal(t, "postgresql://testuser@test-cluster-1.example.com:5432/testdb?sslmode=require", cs)
+
+	cs, err = clusters.ConnectionStringWithPort(&cluster, "testdb", "testuser", 6432)
+	require.NoError(t, err)
+	require.Equal(t, "postgresql://testuser@test-cluster-1.example.com:6432/testdb?sslmode=require", cs)
+
+	cluster.Network.ConnectionString = "postgres://pooler.test-cluster-1.example.com:6543"
+	cs, err = clusters.ConnectionString(&cluster, "testdb", "testuser")
+	require.NoError(t, err)
+	require.Equal(t, "postgresql://testuser@pooler.test-cluster-1.example.com:6543/testdb?sslmode=require", cs)
+
+	cluster.PGServerConfiguration.Pooler = nil
+	cluster.Network.ConnectionString = ""
+	cs, err = clusters.ConnectionString(&cluster, "testdb", "testuser")
+	require.NoError(t, err)
+	require.Equal(t, "postgresql://testuser@test-cluster-1.example.com:5432/testdb?sslmode=require", cs)
+
+	_, err = clusters.ConnectionString(&cluster, "missing", "testuser")
+	require.EqualError(t, err, "database missing not found in cluster test-cluster-1")
+	cluster.Users = []clusters.PgUserOverview{{Name: "testuser", RoleAttributes: []clusters.RoleAttribute{clusters.RoleAttributeNoLogin}}}
+	_, err = clusters.ConnectionString(&cluster, "testdb", "testuser")
+	require.EqualError(t, err, "user testuser of cluster test-cluster-1 cannot log in")
+}
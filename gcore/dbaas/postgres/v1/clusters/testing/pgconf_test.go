@@ -0,0 +1,60 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/dbaas/postgres/v1/clusters"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePGConf(t *testing.T) {
+	conf, err := clusters.ParsePGConf(PGConf)
+	require.NoError(t, err)
+	require.Equal(t, clusters.PGConf{
+		{Name: "shared_buffers", Value: "256MB", Line: 3},
+		{Name: "work_mem", Value: "4MB", Line: 4},
+		{Name: "wal_buffers", Value: "-1", Line: 5},
+		{Name: "checkpoint_completion_target", Value: "0.9", Line: 6},
+		{Name: "huge_pages", Value: "off", Line: 7},
+		{Name: "search_path", Value: `"$user", public`, Line: 8},
+		{Name: "application_name", Value: "it's", Line: 9},
+	}, conf)
+	require.NoError(t, conf.Validate())
+
+	value, ok := conf.Get("WORK_MEM")
+	require.True(t, ok)
+	require.Equal(t, "4MB", value)
+
+	conf = conf.Set("work_mem", "8MB")
+	require.Equal(t, "8MB", conf[len(conf)-1].Value)
+	require.Contains(t, conf.String(), "work_mem=8MB\n")
+	require.Contains(t, conf.String(), "search_path='\"$user\", public'\n")
+	require.Contains(t, conf.String(), "application_name='it''s'\n")
+
+	reparsed, err := clusters.ParsePGConf(conf.String())
+	require.NoError(t, err)
+	require.Len(t, reparsed, len(conf))
+}
+
+func TestValidatePGConf(t *testing.T) {
+	require.NoError(t, clusters.ValidatePGConf("max_connections=100\nstatement_timeout = 30s\nlog_min_duration_statement=-1\n"))
+
+	cases := map[string]string{
+		"work_mem":                    "line 1: work_mem: missing value",
+		"application_name = 'x":       "line 1: application_name: unterminated quoted value",
+		"max_connections = 100 200":   `line 1: max_connections: unexpected "200" after value`,
+		"= 1":                         `line 1: expected parameter name in "= 1"`,
+		"max_connections = 0":         "line 1: max_connections: 0 is out of range",
+		"max_connections = 1.5":       `line 1: max_connections: invalid number "1.5"`,
+		"shared_buffers = 64kB":       "line 1: shared_buffers: 64kB is out of range",
+		"work_mem = 4XB":              `line 1: work_mem: invalid unit "XB", expected one of B, GB, MB, TB, kB`,
+		"checkpoint_timeout = 10s":    "line 1: checkpoint_timeout: 10s is out of range",
+		"huge_pages = maybe":          `line 1: huge_pages: invalid value "maybe", expected one of on, off, try`,
+		"autovacuum = sure":           `line 1: autovacuum: invalid boolean "sure"`,
+		"jit = on\nJIT = off":         "line 2: jit is already set on line 1",
+		"statement_timeout = 5 weeks": `line 1: statement_timeout: unexpected "weeks" after value`,
+	}
+	for conf, expected := range cases {
+		require.EqualError(t, clusters.ValidatePGConf(conf), expected, conf)
+	}
+}
@@ -18,6 +18,8 @@ const (
 	PoolerModeStatement   PoolerMode = "statement"
 	PoolerModeTransaction PoolerMode = "transaction"
 
+	PoolerTypePgBouncer = "pgbouncer"
+
 	ClusterStatusDeleting  ClusterStatus = "DELETING"
 	ClusterStatusFailed    ClusterStatus = "FAILED"
 	ClusterStatusPreparing ClusterStatus = "PREPARING"
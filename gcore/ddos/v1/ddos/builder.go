@@ -0,0 +1,202 @@
+package ddos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ProfileBuilder builds the fields of a protection profile from a profile template, checking values locally
+// instead of relying on API errors.
+type ProfileBuilder struct {
+	template ProfileTemplate
+	values   map[string]interface{}
+}
+
+// NewProfileBuilder returns a builder of profiles of the template.
+func NewProfileBuilder(template ProfileTemplate) *ProfileBuilder {
+	return &ProfileBuilder{template: template, values: make(map[string]interface{})}
+}
+
+// Template returns the profile template of the builder.
+func (b *ProfileBuilder) Template() ProfileTemplate {
+	return b.template
+}
+
+// Set sets the value of a template field by name. Values of str, int and bool fields may be given as strings.
+// Values of other fields, e.g. lists, are sent as JSON.
+func (b *ProfileBuilder) Set(name string, value interface{}) *ProfileBuilder {
+	b.values[name] = value
+	return b
+}
+
+// SetFields sets values of template fields by name.
+func (b *ProfileBuilder) SetFields(values map[string]interface{}) *ProfileBuilder {
+	for name, value := range values {
+		b.values[name] = value
+	}
+	return b
+}
+
+func (b *ProfileBuilder) field(name string) *TemplateField {
+	for i := range b.template.Fields {
+		if b.template.Fields[i].Name == name {
+			return &b.template.Fields[i]
+		}
+	}
+	return nil
+}
+
+// Fields fills defaults and checks every field value against its type and validation schema.
+// Every invalid field is reported in the returned error.
+func (b *ProfileBuilder) Fields() ([]ProfileField, error) {
+	var errs []error
+	for name := range b.values {
+		if b.field(name) == nil {
+			errs = append(errs, fmt.Errorf("field %s does not exist in profile template %s", name, b.template.Name))
+		}
+	}
+
+	fields := make([]ProfileField, 0, len(b.template.Fields))
+	for _, tf := range b.template.Fields {
+		value, ok := b.values[tf.Name]
+		if !ok && tf.Default != "" {
+			value, ok = tf.Default, true
+		}
+		if !ok || value == nil {
+			if tf.Required {
+				errs = append(errs, fmt.Errorf("field %s is required", tf.Name))
+			}
+			continue
+		}
+		field, err := buildField(tf, value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("field %s: %w", tf.Name, err))
+			continue
+		}
+		fields = append(fields, field)
+	}
+	if len(errs) != 0 {
+		return nil, errors.Join(errs...)
+	}
+	return fields, nil
+}
+
+func buildField(tf TemplateField, value interface{}) (ProfileField, error) {
+	field := ProfileField{BaseField: tf.ID}
+	var typed interface{}
+	switch tf.FieldType {
+	case StringField:
+		s, ok := value.(string)
+		if !ok {
+			return field, fmt.Errorf("should be a string, got %T", value)
+		}
+		typed, field.Value = s, s
+	case IntField:
+		i, err := toInt(value)
+		if err != nil {
+			return field, err
+		}
+		typed, field.Value = i, strconv.FormatInt(i, 10)
+	case BoolField:
+		v, err := toBool(value)
+		if err != nil {
+			return field, err
+		}
+		typed, field.Value = v, strconv.FormatBool(v)
+	default:
+		if s, ok := value.(string); ok && json.Valid([]byte(s)) {
+			if err := json.Unmarshal([]byte(s), &typed); err != nil {
+				return field, err
+			}
+		} else {
+			typed = value
+		}
+		raw, err := json.Marshal(typed)
+		if err != nil {
+			return field, err
+		}
+		field.FieldValue = raw
+	}
+	if err := ValidateSchema(tf.ValidationSchema, typed); err != nil {
+		return field, err
+	}
+	return field, nil
+}
+
+func toInt(value interface{}) (int64, error) {
+	if s, ok := value.(string); ok {
+		i, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("should be an integer, got %q", s)
+		}
+		return i, nil
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return int64(v.Uint()), nil
+	}
+	return 0, fmt.Errorf("should be an integer, got %T", value)
+}
+
+func toBool(value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return false, fmt.Errorf("should be a boolean, got %q", v)
+		}
+		return b, nil
+	}
+	return false, fmt.Errorf("should be a boolean, got %T", value)
+}
+
+// CreateOpts builds options creating a profile of the template for the IP address of the resource.
+func (b *ProfileBuilder) CreateOpts(resourceType ResourceType, resourceID, ipAddress string) (*CreateProfileOpts, error) {
+	fields, err := b.Fields()
+	if err != nil {
+		return nil, err
+	}
+	return &CreateProfileOpts{
+		ProfileTemplate:     b.template.ID,
+		ProfileTemplateName: b.template.Name,
+		ResourceType:        resourceType,
+		ResourceID:          resourceID,
+		IPAddress:           ipAddress,
+		Fields:              fields,
+	}, nil
+}
+
+// UpdateOpts builds options updating a profile to the template and fields of the builder.
+func (b *ProfileBuilder) UpdateOpts(resourceType ResourceType, resourceID, ipAddress string) (*UpdateProfileOpts, error) {
+	fields, err := b.Fields()
+	if err != nil {
+		return nil, err
+	}
+	return &UpdateProfileOpts{
+		ProfileTemplate:     b.template.ID,
+		ProfileTemplateName: b.template.Name,
+		ResourceType:        resourceType,
+		ResourceID:          resourceID,
+		IPAddress:           ipAddress,
+		Fields:              fields,
+	}, nil
+}
+
+// FindProfileTemplate returns the profile template with the name or ID.
+func FindProfileTemplate(templates []ProfileTemplate, nameOrID string) (*ProfileTemplate, error) {
+	for i := range templates {
+		if templates[i].Name == nameOrID || strconv.Itoa(templates[i].ID) == nameOrID {
+			return &templates[i], nil
+		}
+	}
+	return nil, fmt.Errorf("profile template %s not found", nameOrID)
+}
@@ -0,0 +1,193 @@
+package protection
+
+import (
+	"fmt"
+	"sort"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/baremetal/v1/bminstances"
+	"github.com/G-Core/gcorelabscloud-go/gcore/ddos/v1/ddos"
+	"github.com/G-Core/gcorelabscloud-go/gcore/floatingip/v1/floatingips"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/loadbalancers"
+)
+
+// Owners of public IP addresses in a coverage report.
+const (
+	OwnerBaremetal    = "baremetal"
+	OwnerInstance     = "instance"
+	OwnerLoadBalancer = "loadbalancer"
+	// OwnerFloatingIP marks floating IPs not attached to an instance or a load balancer.
+	OwnerFloatingIP = "floatingip"
+)
+
+// ClientFactory returns a service client of a region.
+type ClientFactory func(regionID int) (*gcorecloud.ServiceClient, error)
+
+// CoverageClients groups service clients of the regions in a coverage report. Resources of a missing client are not reported.
+type CoverageClients struct {
+	// DDoS returns ddos/profiles v1 clients.
+	DDoS ClientFactory `validate:"required"`
+	// FloatingIPs returns floatingips v1 clients.
+	FloatingIPs ClientFactory
+	// LoadBalancers returns loadbalancers v1 clients.
+	LoadBalancers ClientFactory
+	// Baremetal returns bminstances v1 clients.
+	Baremetal ClientFactory
+}
+
+// CoverageOpts represents options of a coverage report.
+type CoverageOpts struct {
+	RegionIDs []int `validate:"required,min=1,dive,gt=0"`
+}
+
+// CoverageEntry describes the protection of a public IP address.
+type CoverageEntry struct {
+	RegionID     int    `json:"region_id"`
+	IPAddress    string `json:"ip_address"`
+	Owner        string `json:"owner"`
+	ResourceID   string `json:"resource_id,omitempty"`
+	ResourceName string `json:"resource_name,omitempty"`
+	// RegionCovered is set when the region supports advanced DDoS protection.
+	RegionCovered bool `json:"region_covered"`
+	Protected     bool `json:"protected"`
+	ProfileID     int  `json:"profile_id,omitempty"`
+	// ProfileTemplate is the template name of the profile.
+	ProfileTemplate string `json:"profile_template,omitempty"`
+	Active          bool   `json:"active"`
+	BGP             bool   `json:"bgp"`
+	ProfileStatus   string `json:"profile_status,omitempty"`
+}
+
+func (e *CoverageEntry) attach(profile *ddos.Profile) {
+	if profile == nil {
+		return
+	}
+	e.Protected = true
+	e.ProfileID = profile.ID
+	e.ProfileTemplate = profile.ProfileTemplate.Name
+	e.Active = profile.Options.Active
+	e.BGP = profile.Options.BGP
+	e.ProfileStatus = profile.Status.Status
+}
+
+// Unprotected returns entries without a profile.
+func Unprotected(entries []CoverageEntry) []CoverageEntry {
+	var result []CoverageEntry
+	for _, e := range entries {
+		if !e.Protected {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// Coverage lists every public IP address of floating IPs, load balancers and baremetal instances in the regions
+// together with the resource owning it, the DDoS coverage of its region and its protection profile.
+// Entries are sorted by region and IP address.
+func Coverage(clients CoverageClients, opts CoverageOpts) ([]CoverageEntry, error) {
+	if err := gcorecloud.ValidateStruct(clients); err != nil {
+		return nil, err
+	}
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	var entries []CoverageEntry
+	for _, regionID := range opts.RegionIDs {
+		regional, err := regionCoverage(clients, regionID)
+		if err != nil {
+			return nil, fmt.Errorf("region %d: %w", regionID, err)
+		}
+		entries = append(entries, regional...)
+	}
+	return entries, nil
+}
+
+func regionCoverage(clients CoverageClients, regionID int) ([]CoverageEntry, error) {
+	c, err := clients.DDoS(regionID)
+	if err != nil {
+		return nil, err
+	}
+	covered, err := ddos.CheckRegionCoverage(c).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("cannot check region coverage: %w", err)
+	}
+	profiles, err := ddos.ListAllProfiles(c)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list profiles: %w", err)
+	}
+	byIP := make(map[string]*ddos.Profile, len(profiles))
+	for i := range profiles {
+		byIP[profiles[i].IPAddress] = &profiles[i]
+	}
+
+	found := make(map[string]*CoverageEntry)
+	add := func(ip, owner, id, name string) {
+		if _, ok := found[ip]; ok {
+			return
+		}
+		found[ip] = &CoverageEntry{
+			RegionID: regionID, IPAddress: ip, Owner: owner, ResourceID: id, ResourceName: name,
+			RegionCovered: covered.IsCovered,
+		}
+	}
+
+	// Load balancers go first, their floating IPs are attached to VIP ports and have no instance.
+	if clients.LoadBalancers != nil {
+		lc, err := clients.LoadBalancers(regionID)
+		if err != nil {
+			return nil, err
+		}
+		lbs, err := loadbalancers.ListAll(lc, nil)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list load balancers: %w", err)
+		}
+		for i := range lbs {
+			for _, ip := range LoadBalancerIPs(&lbs[i]) {
+				add(ip, OwnerLoadBalancer, lbs[i].ID, lbs[i].Name)
+			}
+		}
+	}
+	if clients.Baremetal != nil {
+		bc, err := clients.Baremetal(regionID)
+		if err != nil {
+			return nil, err
+		}
+		servers, err := bminstances.ListAll(bc, nil)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list baremetal instances: %w", err)
+		}
+		for i := range servers {
+			for _, ip := range InstanceIPs(&servers[i]) {
+				add(ip, OwnerBaremetal, servers[i].ID, servers[i].Name)
+			}
+		}
+	}
+	if clients.FloatingIPs != nil {
+		fc, err := clients.FloatingIPs(regionID)
+		if err != nil {
+			return nil, err
+		}
+		fips, err := floatingips.ListAll(fc, nil)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list floating IPs: %w", err)
+		}
+		for _, fip := range fips {
+			if !IsPublic(fip.FloatingIPAddress) {
+				continue
+			}
+			if fip.Instance.ID != "" {
+				add(fip.FloatingIPAddress.String(), OwnerInstance, fip.Instance.ID, fip.Instance.Name)
+			} else {
+				add(fip.FloatingIPAddress.String(), OwnerFloatingIP, fip.ID, "")
+			}
+		}
+	}
+
+	entries := make([]CoverageEntry, 0, len(found))
+	for ip, e := range found {
+		e.attach(byIP[ip])
+		entries = append(entries, *e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].IPAddress < entries[j].IPAddress })
+	return entries, nil
+}
@@ -0,0 +1,46 @@
+/*
+Package protection applies DDoS protection profiles to every public IP address of an instance or a load balancer
+and reports which public IP addresses of a project lack a profile
+
+It lives outside of the ddos package because instances and load balancers embed ddos types.
+
+Example to protect every public IP of a load balancer
+
+	templates, err := ddos.ListAllProfileTemplates(ddosClient)
+	if err != nil {
+		panic(err)
+	}
+	template, err := ddos.FindProfileTemplate(templates, "Advanced")
+	if err != nil {
+		panic(err)
+	}
+	builder := ddos.NewProfileBuilder(*template).Set("ARRAY_IP_WHITELIST", []string{"203.0.113.10"})
+
+	results, err := protection.Protect(protection.Clients{
+		DDoS:          ddosClient,
+		LoadBalancers: loadbalancerClient,
+	}, protection.ProtectOpts{
+		ResourceType: ddos.ResourceTypeLoadBalancer,
+		ResourceID:   "79943b8e-7c2c-4d7a-8a9f-4b7d1c1e5b8f",
+		Builder:      builder,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+Example to list unprotected public IPs of two regions
+
+	entries, err := protection.Coverage(protection.CoverageClients{
+		DDoS:          ddosClients,
+		FloatingIPs:   floatingIPClients,
+		LoadBalancers: loadbalancerClients,
+		Baremetal:     baremetalClients,
+	}, protection.CoverageOpts{RegionIDs: []int{76, 80}})
+	if err != nil {
+		panic(err)
+	}
+	for _, entry := range protection.Unprotected(entries) {
+		fmt.Println(entry.IPAddress, entry.Owner, entry.ResourceID)
+	}
+*/
+package protection
@@ -0,0 +1,191 @@
+package protection
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/ddos/v1/ddos"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/loadbalancers"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+)
+
+// DefaultWaitSeconds is how long a profile creation is waited for.
+const DefaultWaitSeconds = 300
+
+// Clients groups service clients of a region used to protect resources.
+type Clients struct {
+	// DDoS is a ddos/profiles v1 client.
+	DDoS *gcorecloud.ServiceClient `validate:"required"`
+	// Instances is an instances v1 client. It is required to protect instances.
+	Instances *gcorecloud.ServiceClient
+	// LoadBalancers is a loadbalancers v1 client. It is required to protect load balancers.
+	LoadBalancers *gcorecloud.ServiceClient
+}
+
+// ProtectOpts represents options used to protect every public IP of a resource.
+type ProtectOpts struct {
+	ResourceType ddos.ResourceType `validate:"required,oneof=instance loadbalancer"`
+	ResourceID   string            `validate:"required"`
+	// Builder builds the profile applied to every IP.
+	Builder *ddos.ProfileBuilder `validate:"required"`
+	// NoWait returns after the profiles creation is started.
+	NoWait      bool
+	WaitSeconds int `validate:"omitempty,gt=0"`
+}
+
+// ProtectResult describes the protection of an IP address.
+type ProtectResult struct {
+	IPAddress string `json:"ip_address"`
+	ProfileID int    `json:"profile_id,omitempty"`
+	TaskID    string `json:"task_id,omitempty"`
+	// Skipped is set when the IP already has a profile.
+	Skipped bool   `json:"skipped"`
+	Error   string `json:"error,omitempty"`
+}
+
+// IsPublic returns whether the address is a public IPv4 address, the only addresses profiles are created for.
+func IsPublic(ip net.IP) bool {
+	ip = ip.To4()
+	return ip != nil && ip.IsGlobalUnicast() && !ip.IsPrivate()
+}
+
+// InstanceIPs returns public IPv4 addresses of the instance, sorted.
+func InstanceIPs(instance *instances.Instance) []string {
+	var ips []net.IP
+	for _, addresses := range instance.Addresses {
+		for _, address := range addresses {
+			ips = append(ips, address.Address)
+		}
+	}
+	return publicIPs(ips)
+}
+
+// LoadBalancerIPs returns public IPv4 addresses of the load balancer VIP and floating IPs, sorted.
+func LoadBalancerIPs(lb *loadbalancers.LoadBalancer) []string {
+	ips := []net.IP{lb.VipAddress}
+	for _, fip := range lb.FloatingIPs {
+		ips = append(ips, fip.FloatingIPAddress)
+	}
+	return publicIPs(ips)
+}
+
+func publicIPs(ips []net.IP) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, ip := range ips {
+		if IsPublic(ip) && !seen[ip.String()] {
+			seen[ip.String()] = true
+			result = append(result, ip.String())
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// ResourceIPs returns public IPv4 addresses of an instance or a load balancer.
+func ResourceIPs(clients Clients, resourceType ddos.ResourceType, resourceID string) ([]string, error) {
+	switch resourceType {
+	case ddos.ResourceTypeInstance:
+		if clients.Instances == nil {
+			return nil, fmt.Errorf("instances client is required to protect instances")
+		}
+		instance, err := instances.Get(clients.Instances, resourceID).Extract()
+		if err != nil {
+			return nil, err
+		}
+		return InstanceIPs(instance), nil
+	case ddos.ResourceTypeLoadBalancer:
+		if clients.LoadBalancers == nil {
+			return nil, fmt.Errorf("loadbalancers client is required to protect load balancers")
+		}
+		lb, err := loadbalancers.Get(clients.LoadBalancers, resourceID, nil).Extract()
+		if err != nil {
+			return nil, err
+		}
+		return LoadBalancerIPs(lb), nil
+	}
+	return nil, fmt.Errorf("unknown resource type %s", resourceType)
+}
+
+// Protect creates a profile of the builder for every public IP of the resource. IPs with a profile are skipped.
+// The fields are checked once before anything is created, a failed IP is reported in its result and does not stop the others.
+func Protect(clients Clients, opts ProtectOpts) ([]ProtectResult, error) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	if err := gcorecloud.ValidateStruct(clients); err != nil {
+		return nil, err
+	}
+	if _, err := opts.Builder.Fields(); err != nil {
+		return nil, err
+	}
+	waitSeconds := opts.WaitSeconds
+	if waitSeconds == 0 {
+		waitSeconds = DefaultWaitSeconds
+	}
+
+	ips, err := ResourceIPs(clients, opts.ResourceType, opts.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("%s %s has no public IP addresses", opts.ResourceType, opts.ResourceID)
+	}
+	profiles, err := ddos.ListAllProfiles(clients.DDoS)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list profiles: %w", err)
+	}
+	existing := make(map[string]int, len(profiles))
+	for _, profile := range profiles {
+		existing[profile.IPAddress] = profile.ID
+	}
+
+	results := make([]ProtectResult, 0, len(ips))
+	for _, ip := range ips {
+		result := ProtectResult{IPAddress: ip}
+		if id, ok := existing[ip]; ok {
+			result.ProfileID, result.Skipped = id, true
+			results = append(results, result)
+			continue
+		}
+		if err := protectIP(clients.DDoS, opts, ip, waitSeconds, &result); err != nil {
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func protectIP(c *gcorecloud.ServiceClient, opts ProtectOpts, ip string, waitSeconds int, result *ProtectResult) error {
+	createOpts, err := opts.Builder.CreateOpts(opts.ResourceType, opts.ResourceID, ip)
+	if err != nil {
+		return err
+	}
+	results, err := ddos.CreateProfile(c, createOpts).Extract()
+	if err != nil {
+		return err
+	}
+	if len(results.Tasks) == 0 {
+		return fmt.Errorf("no task creating the profile")
+	}
+	result.TaskID = string(results.Tasks[0])
+	if opts.NoWait {
+		return nil
+	}
+	profileID, err := tasks.WaitTaskAndReturnResult(c, results.Tasks[0], true, waitSeconds, func(task tasks.TaskID) (interface{}, error) {
+		taskInfo, err := tasks.Get(c, string(task)).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
+		}
+		return ddos.ExtractProfileIDFromTask(taskInfo)
+	})
+	if err != nil {
+		return err
+	}
+	result.ProfileID, err = strconv.Atoi(profileID.(string))
+	return err
+}
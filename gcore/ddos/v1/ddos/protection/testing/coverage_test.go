@@ -0,0 +1,74 @@
+package testing
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore"
+	"github.com/G-Core/gcorelabscloud-go/gcore/ddos/v1/ddos/protection"
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+)
+
+func regionalClient(name string) protection.ClientFactory {
+	provider := fake.ServiceTokenClient(name, "v1").ProviderClient
+	return func(regionID int) (*gcorecloud.ServiceClient, error) {
+		return gcore.ClientServiceFromProvider(provider, gcorecloud.EndpointOpts{
+			Name:    name,
+			Region:  regionID,
+			Project: fake.ProjectID,
+			Version: "v1",
+		})
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	for url, body := range map[string]string{
+		fmt.Sprintf("/v1/ddos/region_coverage/%d", fake.RegionID):             RegionCoverageResponse,
+		fmt.Sprintf("/v1/ddos/profiles/%d/%d", fake.ProjectID, fake.RegionID): CoverageProfilesResponse,
+		fmt.Sprintf("/v1/loadbalancers/%d/%d", fake.ProjectID, fake.RegionID): LoadBalancersResponse,
+		fmt.Sprintf("/v1/bminstances/%d/%d", fake.ProjectID, fake.RegionID):   BaremetalResponse,
+		fmt.Sprintf("/v1/floatingips/%d/%d", fake.ProjectID, fake.RegionID):   FloatingIPsResponse,
+	} {
+		th.Mux.HandleFunc(url, respondJSON(t, "GET", body))
+	}
+
+	entries, err := protection.Coverage(protection.CoverageClients{
+		DDoS:          regionalClient("ddos/profiles"),
+		FloatingIPs:   regionalClient("floatingips"),
+		LoadBalancers: regionalClient("loadbalancers"),
+		Baremetal:     regionalClient("bminstances"),
+	}, protection.CoverageOpts{RegionIDs: []int{fake.RegionID}})
+	require.NoError(t, err)
+	require.Equal(t, []protection.CoverageEntry{
+		{
+			RegionID: 1, IPAddress: "5.188.1.20", Owner: protection.OwnerBaremetal,
+			ResourceID: "a7e7e8d6-0bf7-4ac9-8170-831b47ee2ba9", ResourceName: "db", RegionCovered: true,
+		},
+		{
+			RegionID: 1, IPAddress: "92.38.1.2", Owner: protection.OwnerLoadBalancer,
+			ResourceID: "79943b8e-7c2c-4d7a-8a9f-4b7d1c1e5b8f", ResourceName: "web-lb", RegionCovered: true,
+			Protected: true, ProfileID: 3, ProfileTemplate: "advanced", Active: true, BGP: true, ProfileStatus: "ACTIVE",
+		},
+		{
+			RegionID: 1, IPAddress: "92.38.1.3", Owner: protection.OwnerInstance,
+			ResourceID: "9f310fe7-baa2-47a3-b6a6-63c5d78becc2", ResourceName: "web", RegionCovered: true,
+		},
+		{
+			RegionID: 1, IPAddress: "92.38.1.4", Owner: protection.OwnerFloatingIP,
+			ResourceID: "e1c9d0c7-3a1b-4c4e-9f7e-1d2c3b4a5f6e", RegionCovered: true,
+		},
+	}, entries)
+	require.Len(t, protection.Unprotected(entries), 3)
+}
+
+func TestCoverageRequiresRegions(t *testing.T) {
+	_, err := protection.Coverage(protection.CoverageClients{DDoS: regionalClient("ddos/profiles")}, protection.CoverageOpts{})
+	require.Error(t, err)
+}
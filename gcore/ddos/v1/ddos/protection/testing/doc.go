@@ -0,0 +1,2 @@
+// protection unit tests
+package testing
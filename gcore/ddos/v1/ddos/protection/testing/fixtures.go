@@ -0,0 +1,133 @@
+package testing
+
+import "fmt"
+
+const (
+	InstanceID = "9f310fe7-baa2-47a3-b6a6-63c5d78becc2"
+	TaskID     = "d478ae29-dedc-4869-82f0-96104425f565"
+)
+
+const InstanceResponse = `
+{
+  "instance_id": "9f310fe7-baa2-47a3-b6a6-63c5d78becc2",
+  "instance_name": "web",
+  "status": "ACTIVE",
+  "addresses": {
+    "private": [
+      {"type": "fixed", "addr": "10.0.0.5"}
+    ],
+    "public": [
+      {"type": "fixed", "addr": "5.188.1.10"},
+      {"type": "fixed", "addr": "2a03:90c0:5f1::10"}
+    ],
+    "floating": [
+      {"type": "floating", "addr": "92.38.1.2"}
+    ]
+  }
+}
+`
+
+const ProfilesResponse = `
+{
+  "count": 1,
+  "results": [
+    {"id": 3, "ip_address": "92.38.1.2", "options": {"active": true, "bgp": false}, "fields": [], "protocols": []}
+  ]
+}
+`
+
+const Template = `
+{
+  "id": 2,
+  "name": "advanced",
+  "fields": [
+    {"id": 11, "name": "threshold", "field_type": "int", "required": true, "default": "100"}
+  ]
+}
+`
+
+const CreateRequest = `
+{
+  "profile_template": 2,
+  "profile_template_name": "advanced",
+  "resource_id": "9f310fe7-baa2-47a3-b6a6-63c5d78becc2",
+  "resource_type": "instance",
+  "ip_address": "5.188.1.10",
+  "fields": [{"base_field": 11, "value": "100"}]
+}
+`
+
+var TasksResponse = fmt.Sprintf(`{"tasks": ["%s"]}`, TaskID)
+
+var TaskResponse = fmt.Sprintf(`
+{
+  "id": "%s",
+  "task_type": "create_ddos_profile",
+  "state": "FINISHED",
+  "created_on": "2025-06-25T08:42:42",
+  "created_resources": {
+    "ddos_profiles": [7]
+  }
+}
+`, TaskID)
+
+const RegionCoverageResponse = `{"is_covered": true}`
+
+const CoverageProfilesResponse = `
+{
+  "count": 1,
+  "results": [
+    {
+      "id": 3,
+      "ip_address": "92.38.1.2",
+      "profile_template": {"id": 2, "name": "advanced", "fields": []},
+      "options": {"active": true, "bgp": true},
+      "status": {"status": "ACTIVE"},
+      "fields": [],
+      "protocols": []
+    }
+  ]
+}
+`
+
+const LoadBalancersResponse = `
+{
+  "count": 1,
+  "results": [
+    {
+      "id": "79943b8e-7c2c-4d7a-8a9f-4b7d1c1e5b8f",
+      "name": "web-lb",
+      "vip_address": "10.0.0.3",
+      "floating_ips": [{"floating_ip_address": "92.38.1.2", "id": "c64e5db1-5f1f-43ec-a8d9-5090df85b82d"}]
+    }
+  ]
+}
+`
+
+const BaremetalResponse = `
+{
+  "count": 1,
+  "results": [
+    {
+      "instance_id": "a7e7e8d6-0bf7-4ac9-8170-831b47ee2ba9",
+      "instance_name": "db",
+      "addresses": {"public": [{"type": "fixed", "addr": "5.188.1.20"}]}
+    }
+  ]
+}
+`
+
+const FloatingIPsResponse = `
+{
+  "count": 3,
+  "results": [
+    {"id": "c64e5db1-5f1f-43ec-a8d9-5090df85b82d", "floating_ip_address": "92.38.1.2", "instance": null},
+    {
+      "id": "2f2a2b35-6d8e-4f7c-b2c1-5f4b3f9b1a0e",
+      "floating_ip_address": "92.38.1.3",
+      "instance": {"instance_id": "9f310fe7-baa2-47a3-b6a6-63c5d78becc2", "instance_name": "web"}
+    },
+    {"id": "e1c9d0c7-3a1b-4c4e-9f7e-1d2c3b4a5f6e", "floating_ip_address": "92.38.1.4", "instance": null}
+  ]
+}
+`
@@ -0,0 +1,90 @@
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/ddos/v1/ddos"
+	"github.com/G-Core/gcorelabscloud-go/gcore/ddos/v1/ddos/protection"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/loadbalancers"
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+)
+
+func respondJSON(t *testing.T, method, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, method)
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, body)
+	}
+}
+
+func builder(t *testing.T) *ddos.ProfileBuilder {
+	var template ddos.ProfileTemplate
+	require.NoError(t, json.Unmarshal([]byte(Template), &template))
+	return ddos.NewProfileBuilder(template)
+}
+
+func clients() protection.Clients {
+	return protection.Clients{
+		DDoS:      fake.ServiceTokenClient("ddos/profiles", "v1"),
+		Instances: fake.ServiceTokenClient("instances", "v1"),
+	}
+}
+
+func TestProtect(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(fmt.Sprintf("/v1/instances/%d/%d/%s", fake.ProjectID, fake.RegionID, InstanceID),
+		respondJSON(t, "GET", InstanceResponse))
+	th.Mux.HandleFunc(fmt.Sprintf("/v1/ddos/profiles/%d/%d", fake.ProjectID, fake.RegionID), func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			th.TestJSONRequest(t, r, CreateRequest)
+			respondJSON(t, "POST", TasksResponse)(w, r)
+			return
+		}
+		respondJSON(t, "GET", ProfilesResponse)(w, r)
+	})
+	th.Mux.HandleFunc(fmt.Sprintf("/v1/tasks/%s", TaskID), respondJSON(t, "GET", TaskResponse))
+
+	results, err := protection.Protect(clients(), protection.ProtectOpts{
+		ResourceType: ddos.ResourceTypeInstance,
+		ResourceID:   InstanceID,
+		Builder:      builder(t),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []protection.ProtectResult{
+		{IPAddress: "5.188.1.10", ProfileID: 7, TaskID: TaskID},
+		{IPAddress: "92.38.1.2", ProfileID: 3, Skipped: true},
+	}, results)
+}
+
+func TestProtectInvalidFields(t *testing.T) {
+	_, err := protection.Protect(clients(), protection.ProtectOpts{
+		ResourceType: ddos.ResourceTypeInstance,
+		ResourceID:   InstanceID,
+		Builder:      builder(t).Set("threshold", "many"),
+	})
+	require.EqualError(t, err, `field threshold: should be an integer, got "many"`)
+}
+
+func TestPublicIPs(t *testing.T) {
+	require.Equal(t, []string{"5.188.1.10"}, protection.InstanceIPs(&instances.Instance{
+		Addresses: map[string][]instances.InstanceAddress{
+			"net": {{Address: net.ParseIP("10.0.0.5")}, {Address: net.ParseIP("5.188.1.10")}, {Address: net.ParseIP("5.188.1.10")}},
+		},
+	}))
+	require.Equal(t, []string{"92.38.1.2"}, protection.LoadBalancerIPs(&loadbalancers.LoadBalancer{
+		VipAddress:  net.ParseIP("192.168.0.7"),
+		FloatingIPs: []instances.FloatingIP{{FloatingIPAddress: net.ParseIP("92.38.1.2")}},
+	}))
+}
@@ -0,0 +1,287 @@
+package ddos
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ValidateSchema validates a JSON value against the validation schema of a template field.
+// It supports the type, enum, const, numeric bounds, string length, pattern, format (ipv4, ipv6, ip),
+// array items, object properties and anyOf, allOf and oneOf keywords. Other keywords are ignored.
+func ValidateSchema(schema json.RawMessage, value interface{}) error {
+	if len(schema) == 0 || string(schema) == "null" {
+		return nil
+	}
+	var s map[string]interface{}
+	if err := json.Unmarshal(schema, &s); err != nil {
+		return fmt.Errorf("invalid validation schema: %w", err)
+	}
+	normalized, err := normalizeJSON(value)
+	if err != nil {
+		return err
+	}
+	return validateSchema(s, normalized, "")
+}
+
+// normalizeJSON converts a Go value into the generic form produced by json.Unmarshal.
+func normalizeJSON(value interface{}) (interface{}, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	err = json.Unmarshal(b, &v)
+	return v, err
+}
+
+func schemaError(path, format string, args ...interface{}) error {
+	if path == "" {
+		path = "value"
+	}
+	return fmt.Errorf("%s %s", path, fmt.Sprintf(format, args...))
+}
+
+func jsonType(v interface{}) string {
+	switch x := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if x == math.Trunc(x) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func typeMatches(expected string, v interface{}) bool {
+	actual := jsonType(v)
+	return actual == expected || (expected == "number" && actual == "integer")
+}
+
+func validateSchema(s map[string]interface{}, v interface{}, path string) error {
+	if t, ok := s["type"]; ok {
+		var types []string
+		switch x := t.(type) {
+		case string:
+			types = []string{x}
+		case []interface{}:
+			for _, e := range x {
+				if name, ok := e.(string); ok {
+					types = append(types, name)
+				}
+			}
+		}
+		matched := len(types) == 0
+		for _, name := range types {
+			matched = matched || typeMatches(name, v)
+		}
+		if !matched {
+			return schemaError(path, "should be %s, got %s", strings.Join(types, " or "), jsonType(v))
+		}
+	}
+	if c, ok := s["const"]; ok && !reflect.DeepEqual(c, v) {
+		return schemaError(path, "should be %v", c)
+	}
+	if enum, ok := s["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			found = found || reflect.DeepEqual(e, v)
+		}
+		if !found {
+			return schemaError(path, "should be one of %v", enum)
+		}
+	}
+
+	switch x := v.(type) {
+	case float64:
+		if err := validateNumber(s, x, path); err != nil {
+			return err
+		}
+	case string:
+		if err := validateString(s, x, path); err != nil {
+			return err
+		}
+	case []interface{}:
+		if err := validateArray(s, x, path); err != nil {
+			return err
+		}
+	case map[string]interface{}:
+		if err := validateObject(s, x, path); err != nil {
+			return err
+		}
+	}
+	return validateCombinations(s, v, path)
+}
+
+func number(s map[string]interface{}, key string) (float64, bool) {
+	n, ok := s[key].(float64)
+	return n, ok
+}
+
+func validateNumber(s map[string]interface{}, x float64, path string) error {
+	if n, ok := number(s, "minimum"); ok && x < n {
+		return schemaError(path, "should be at least %v", n)
+	}
+	if n, ok := number(s, "maximum"); ok && x > n {
+		return schemaError(path, "should be at most %v", n)
+	}
+	if n, ok := number(s, "exclusiveMinimum"); ok && x <= n {
+		return schemaError(path, "should be greater than %v", n)
+	}
+	if n, ok := number(s, "exclusiveMaximum"); ok && x >= n {
+		return schemaError(path, "should be less than %v", n)
+	}
+	if n, ok := number(s, "multipleOf"); ok && n != 0 && math.Mod(x, n) != 0 {
+		return schemaError(path, "should be a multiple of %v", n)
+	}
+	return nil
+}
+
+func validateString(s map[string]interface{}, x string, path string) error {
+	length := len([]rune(x))
+	if n, ok := number(s, "minLength"); ok && float64(length) < n {
+		return schemaError(path, "should be at least %v characters long", n)
+	}
+	if n, ok := number(s, "maxLength"); ok && float64(length) > n {
+		return schemaError(path, "should be at most %v characters long", n)
+	}
+	if p, ok := s["pattern"].(string); ok {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return fmt.Errorf("invalid validation schema pattern %q: %w", p, err)
+		}
+		if !re.MatchString(x) {
+			return schemaError(path, "should match %s", p)
+		}
+	}
+	if f, ok := s["format"].(string); ok {
+		ip := net.ParseIP(x)
+		switch {
+		case f == "ipv4" && (ip == nil || ip.To4() == nil),
+			f == "ipv6" && (ip == nil || ip.To4() != nil),
+			f == "ip" && ip == nil:
+			return schemaError(path, "should be a valid %s address", f)
+		}
+	}
+	return nil
+}
+
+func validateArray(s map[string]interface{}, x []interface{}, path string) error {
+	if n, ok := number(s, "minItems"); ok && float64(len(x)) < n {
+		return schemaError(path, "should have at least %v items", n)
+	}
+	if n, ok := number(s, "maxItems"); ok && float64(len(x)) > n {
+		return schemaError(path, "should have at most %v items", n)
+	}
+	if unique, _ := s["uniqueItems"].(bool); unique {
+		for i := range x {
+			for j := i + 1; j < len(x); j++ {
+				if reflect.DeepEqual(x[i], x[j]) {
+					return schemaError(path, "should have unique items")
+				}
+			}
+		}
+	}
+	if items, ok := s["items"].(map[string]interface{}); ok {
+		for i, item := range x {
+			if err := validateSchema(items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateObject(s map[string]interface{}, x map[string]interface{}, path string) error {
+	prefix := path
+	if prefix != "" {
+		prefix += "."
+	}
+	if required, ok := s["required"].([]interface{}); ok {
+		for _, r := range required {
+			if name, ok := r.(string); ok {
+				if _, ok := x[name]; !ok {
+					return schemaError(prefix+name, "is required")
+				}
+			}
+		}
+	}
+	properties, _ := s["properties"].(map[string]interface{})
+	names := make([]string, 0, len(x))
+	for name := range x {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if p, ok := properties[name].(map[string]interface{}); ok {
+			if err := validateSchema(p, x[name], prefix+name); err != nil {
+				return err
+			}
+		} else if additional, ok := s["additionalProperties"].(bool); ok && !additional {
+			return schemaError(prefix+name, "is not allowed")
+		}
+	}
+	return nil
+}
+
+func subschemas(s map[string]interface{}, key string) []map[string]interface{} {
+	list, _ := s[key].([]interface{})
+	result := make([]map[string]interface{}, 0, len(list))
+	for _, e := range list {
+		if sub, ok := e.(map[string]interface{}); ok {
+			result = append(result, sub)
+		}
+	}
+	return result
+}
+
+func validateCombinations(s map[string]interface{}, v interface{}, path string) error {
+	for _, sub := range subschemas(s, "allOf") {
+		if err := validateSchema(sub, v, path); err != nil {
+			return err
+		}
+	}
+	if anyOf := subschemas(s, "anyOf"); len(anyOf) != 0 {
+		var first error
+		for _, sub := range anyOf {
+			err := validateSchema(sub, v, path)
+			if err == nil {
+				first = nil
+				break
+			}
+			if first == nil {
+				first = err
+			}
+		}
+		if first != nil {
+			return first
+		}
+	}
+	if oneOf := subschemas(s, "oneOf"); len(oneOf) != 0 {
+		matched := 0
+		for _, sub := range oneOf {
+			if validateSchema(sub, v, path) == nil {
+				matched++
+			}
+		}
+		if matched != 1 {
+			return schemaError(path, "should match exactly one schema, matched %d", matched)
+		}
+	}
+	return nil
+}
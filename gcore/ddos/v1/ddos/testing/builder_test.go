@@ -0,0 +1,76 @@
+package testing
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/ddos/v1/ddos"
+)
+
+func builderTemplate(t *testing.T) ddos.ProfileTemplate {
+	var template ddos.ProfileTemplate
+	require.NoError(t, json.Unmarshal([]byte(builderTemplateResponse), &template))
+	return template
+}
+
+func TestProfileBuilder(t *testing.T) {
+	builder := ddos.NewProfileBuilder(builderTemplate(t)).
+		Set("site", "example").
+		Set("geo_blocking", "true").
+		Set("ip_whitelist", []string{"203.0.113.10"})
+
+	opts, err := builder.CreateOpts(ddos.ResourceTypeInstance, "9f310fe7-baa2-47a3-b6a6-63c5d78becc2", "203.0.113.1")
+	require.NoError(t, err)
+	require.Equal(t, 2, opts.ProfileTemplate)
+	require.Equal(t, []ddos.ProfileField{
+		{BaseField: 11, Value: "100"},
+		{BaseField: 12, Value: "true"},
+		{BaseField: 13, Value: "example"},
+		{BaseField: 14, FieldValue: json.RawMessage(`["203.0.113.10"]`)},
+	}, opts.Fields)
+
+	update, err := builder.Set("threshold", 500).UpdateOpts(ddos.ResourceTypeInstance, "9f310fe7-baa2-47a3-b6a6-63c5d78becc2", "203.0.113.1")
+	require.NoError(t, err)
+	require.Equal(t, "500", update.Fields[0].Value)
+}
+
+func TestProfileBuilderErrors(t *testing.T) {
+	_, err := ddos.NewProfileBuilder(builderTemplate(t)).
+		Set("threshold", "5").
+		Set("geo_blocking", 1).
+		Set("ip_whitelist", `["203.0.113.10", "not-an-ip"]`).
+		Set("unknown", "x").
+		Fields()
+	require.Error(t, err)
+	require.Equal(t, "field unknown does not exist in profile template advanced\n"+
+		"field threshold: value should be at least 10\n"+
+		"field geo_blocking: should be a boolean, got int\n"+
+		"field site is required\n"+
+		"field ip_whitelist: [1] should be a valid ipv4 address", err.Error())
+
+	_, err = ddos.NewProfileBuilder(builderTemplate(t)).Set("site", "a-very-long-site").Fields()
+	require.EqualError(t, err, "field site: value should be at most 10 characters long")
+}
+
+func TestValidateSchema(t *testing.T) {
+	schema := json.RawMessage(`{
+		"type": "object",
+		"required": ["port"],
+		"additionalProperties": false,
+		"properties": {
+			"port": {"type": "integer", "exclusiveMinimum": 0, "maximum": 65535},
+			"protocol": {"enum": ["TCP", "UDP"]},
+			"note": {"anyOf": [{"type": "string", "pattern": "^[a-z]+$"}, {"type": "null"}]}
+		}
+	}`)
+	require.NoError(t, ddos.ValidateSchema(schema, map[string]interface{}{"port": 443, "protocol": "TCP", "note": nil}))
+	require.EqualError(t, ddos.ValidateSchema(schema, map[string]interface{}{"protocol": "TCP"}), "port is required")
+	require.EqualError(t, ddos.ValidateSchema(schema, map[string]interface{}{"port": 0}), "port should be greater than 0")
+	require.EqualError(t, ddos.ValidateSchema(schema, map[string]interface{}{"port": 1.5}), "port should be integer, got number")
+	require.EqualError(t, ddos.ValidateSchema(schema, map[string]interface{}{"port": 1, "protocol": "ICMP"}), "protocol should be one of [TCP UDP]")
+	require.EqualError(t, ddos.ValidateSchema(schema, map[string]interface{}{"port": 1, "note": "A"}), "note should match ^[a-z]+$")
+	require.EqualError(t, ddos.ValidateSchema(schema, map[string]interface{}{"port": 1, "extra": 1}), "extra is not allowed")
+	require.NoError(t, ddos.ValidateSchema(nil, "anything"))
+}
@@ -240,3 +240,41 @@ var (
 		},
 	}
 )
+
+const builderTemplateResponse = `
+{
+  "id": 2,
+  "name": "advanced",
+  "description": "advanced protection",
+  "fields": [
+    {
+      "id": 11,
+      "name": "threshold",
+      "field_type": "int",
+      "required": true,
+      "default": "100",
+      "validation_schema": {"type": "integer", "minimum": 10, "maximum": 10000}
+    },
+    {
+      "id": 12,
+      "name": "geo_blocking",
+      "field_type": "bool",
+      "required": false,
+      "default": "false"
+    },
+    {
+      "id": 13,
+      "name": "site",
+      "field_type": "str",
+      "required": true,
+      "validation_schema": {"type": "string", "maxLength": 10}
+    },
+    {
+      "id": 14,
+      "name": "ip_whitelist",
+      "required": false,
+      "validation_schema": {"type": "array", "items": {"type": "string", "format": "ipv4"}, "maxItems": 2}
+    }
+  ]
+}
+`
@@ -0,0 +1,139 @@
+package apikeys
+
+import (
+	"sort"
+	"time"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/faas/v1/faas"
+)
+
+// DefaultAuditWithin is the period keys are reported as expiring within.
+const DefaultAuditWithin = 30 * 24 * time.Hour
+
+// AuditOpts represents options used to audit keys.
+type AuditOpts struct {
+	// Within defaults to DefaultAuditWithin.
+	Within time.Duration
+	// Now defaults to the current time.
+	Now time.Time
+}
+
+// ExpiringKey is a key expiring within the audited period.
+type ExpiringKey struct {
+	Name      string        `json:"name"`
+	Expire    time.Time     `json:"expire"`
+	ExpiresIn time.Duration `json:"expires_in"`
+	Expired   bool          `json:"expired"`
+}
+
+// FunctionAccess lists the keys a function is reachable with.
+type FunctionAccess struct {
+	Namespace    string `json:"namespace"`
+	Name         string `json:"name"`
+	EnableAPIKey bool   `json:"enable_api_key"`
+	// Keys are the keys listed by the function.
+	Keys []string `json:"keys"`
+	// ScopedKeys are the keys having the function in their scope.
+	ScopedKeys []string `json:"scoped_keys"`
+	// UnknownKeys are keys listed by the function which do not exist.
+	UnknownKeys []string `json:"unknown_keys,omitempty"`
+}
+
+// Report is a result of a key audit.
+type Report struct {
+	Expiring []ExpiringKey `json:"expiring"`
+	// EmptyScope are keys without functions.
+	EmptyScope []string `json:"empty_scope"`
+	// Access lists every function and the keys it is reachable with. Functions with disabled
+	// API keys are reachable without a key.
+	Access []FunctionAccess `json:"access"`
+}
+
+// Audit lists keys and functions of all namespaces and builds a Report.
+func Audit(clients Clients, opts AuditOpts) (*Report, error) {
+	keys, err := faas.ListKeysAll(clients.Keys, nil)
+	if err != nil {
+		return nil, err
+	}
+	namespaces, err := faas.ListNamespaceALL(clients.Functions, nil)
+	if err != nil {
+		return nil, err
+	}
+	functions := map[string][]faas.Function{}
+	for _, ns := range namespaces {
+		list, err := faas.ListFunctionsALL(clients.Functions, ns.Name, nil)
+		if err != nil {
+			return nil, err
+		}
+		functions[ns.Name] = list
+	}
+	return BuildReport(keys, functions, opts), nil
+}
+
+// BuildReport builds a Report from keys and functions grouped by namespace.
+func BuildReport(keys []faas.Key, functions map[string][]faas.Function, opts AuditOpts) *Report {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	within := opts.Within
+	if within == 0 {
+		within = DefaultAuditWithin
+	}
+	deadline := now.Add(within)
+
+	report := &Report{Expiring: []ExpiringKey{}, EmptyScope: []string{}, Access: []FunctionAccess{}}
+	known := map[string]bool{}
+	scoped := map[faas.KeysFunction][]string{}
+	for _, key := range keys {
+		known[key.Name] = true
+		if len(key.Functions) == 0 {
+			report.EmptyScope = append(report.EmptyScope, key.Name)
+		}
+		for _, fn := range key.Functions {
+			scoped[fn] = append(scoped[fn], key.Name)
+		}
+		expire := key.Expire.Time
+		if expire.IsZero() || expire.After(deadline) {
+			continue
+		}
+		report.Expiring = append(report.Expiring, ExpiringKey{
+			Name:      key.Name,
+			Expire:    expire,
+			ExpiresIn: expire.Sub(now),
+			Expired:   !expire.After(now),
+		})
+	}
+	sort.Slice(report.Expiring, func(i, j int) bool {
+		return report.Expiring[i].Expire.Before(report.Expiring[j].Expire)
+	})
+	sort.Strings(report.EmptyScope)
+
+	for namespace, list := range functions {
+		for _, fn := range list {
+			access := FunctionAccess{
+				Namespace:    namespace,
+				Name:         fn.Name,
+				EnableAPIKey: fn.EnableAPIKey,
+				Keys:         append([]string{}, fn.Keys...),
+				ScopedKeys:   append([]string{}, scoped[faas.KeysFunction{Name: fn.Name, Namespace: namespace}]...),
+			}
+			for _, k := range fn.Keys {
+				if !known[k] {
+					access.UnknownKeys = append(access.UnknownKeys, k)
+				}
+			}
+			sort.Strings(access.Keys)
+			sort.Strings(access.ScopedKeys)
+			report.Access = append(report.Access, access)
+		}
+	}
+	sort.Slice(report.Access, func(i, j int) bool {
+		a, b := report.Access[i], report.Access[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return report
+}
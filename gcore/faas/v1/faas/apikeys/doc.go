@@ -0,0 +1,30 @@
+/*
+Package apikeys rotates FaaS API keys and audits which functions are reachable with which key
+
+Example to rotate a key
+
+	result, err := apikeys.Rotate(ctx, apikeys.Clients{
+		Keys:      keysClient,
+		Functions: functionsClient,
+	}, apikeys.RotateOpts{
+		Name:        "ci-key",
+		GracePeriod: time.Hour,
+		Handoff: func(key faas.Key) error {
+			return storeSecret(key.Name, key.Secret)
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+Example to audit keys expiring within 14 days
+
+	report, err := apikeys.Audit(apikeys.Clients{
+		Keys:      keysClient,
+		Functions: functionsClient,
+	}, apikeys.AuditOpts{Within: 14 * 24 * time.Hour})
+	if err != nil {
+		panic(err)
+	}
+*/
+package apikeys
@@ -0,0 +1,214 @@
+package apikeys
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/faas/v1/faas"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+)
+
+// DefaultWaitSeconds is how long rotation waits for every function update task.
+const DefaultWaitSeconds = 300
+
+// Clients groups service clients used for key rotation and audit.
+type Clients struct {
+	// Keys is a faas/keys v1 client.
+	Keys *gcorecloud.ServiceClient
+	// Functions is a faas/namespaces v1 client.
+	Functions *gcorecloud.ServiceClient
+}
+
+// RotateOpts represents options used to rotate a key.
+type RotateOpts struct {
+	// Name is the key being replaced.
+	Name string `validate:"required"`
+	// NewName is the name of the replacement key. Defaults to the old name followed by the current time.
+	NewName string
+	// Expire is the expiration of the replacement key. Defaults to the current time plus the lifetime
+	// of the old key, a key without expiration is replaced with a key without expiration.
+	Expire *time.Time
+	// Handoff receives the replacement key with its secret once it is attached to the functions.
+	// If it returns an error the replacement key is detached and deleted and the old key is kept.
+	Handoff func(key faas.Key) error
+	// GracePeriod is how long the old key keeps working after the handoff.
+	GracePeriod time.Duration `validate:"omitempty,gte=0"`
+	// KeepOldKey disables detaching and deleting the old key after the grace period.
+	KeepOldKey  bool
+	WaitSeconds int `validate:"omitempty,gt=0"`
+}
+
+// RotateResult describes a rotation.
+type RotateResult struct {
+	OldKey string `json:"old_key"`
+	// NewKey holds the secret of the replacement key.
+	NewKey faas.Key `json:"new_key"`
+	// Attached are the functions with enabled API keys the replacement key was added to.
+	Attached   []faas.KeysFunction `json:"attached"`
+	OldRetired bool                `json:"old_retired"`
+}
+
+// Rotate replaces a key with a new key of the same function scope. The new key is added to the key list
+// of every function in scope having EnableAPIKey set, then handed to the caller. After the grace period
+// the old key is removed from these functions and deleted. When ctx is done during the grace period,
+// the result is returned together with the context error and the old key can be retired later with Retire.
+func Rotate(ctx context.Context, clients Clients, opts RotateOpts) (*RotateResult, error) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	waitSeconds := opts.WaitSeconds
+	if waitSeconds == 0 {
+		waitSeconds = DefaultWaitSeconds
+	}
+	now := time.Now().UTC()
+
+	old, err := faas.GetKey(clients.Keys, opts.Name).Extract()
+	if err != nil {
+		return nil, err
+	}
+	createOpts := faas.CreateKeyOpts{
+		Name:        opts.NewName,
+		Description: old.Description,
+		Functions:   old.Functions,
+	}
+	if createOpts.Name == "" {
+		createOpts.Name = fmt.Sprintf("%s-%s", opts.Name, now.Format("20060102150405"))
+	}
+	switch {
+	case opts.Expire != nil:
+		createOpts.Expire = &gcorecloud.JSONRFC3339ZZ{Time: opts.Expire.UTC()}
+	case !old.Expire.IsZero():
+		lifetime := old.Expire.Sub(old.CreatedAt.Time)
+		if old.CreatedAt.IsZero() || lifetime <= 0 {
+			return nil, fmt.Errorf("cannot derive the lifetime of key %s, set the expiration", opts.Name)
+		}
+		createOpts.Expire = &gcorecloud.JSONRFC3339ZZ{Time: now.Add(lifetime).Truncate(time.Second)}
+	}
+
+	newKey, err := faas.CreateKey(clients.Keys, createOpts)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create key %s: %w", createOpts.Name, err)
+	}
+	result := &RotateResult{OldKey: opts.Name, NewKey: newKey}
+
+	rollback := func(err error) (*RotateResult, error) {
+		for _, fn := range result.Attached {
+			if detachErr := detachKey(clients.Functions, fn, newKey.Name, waitSeconds); detachErr != nil {
+				return nil, fmt.Errorf("%w, cannot detach key %s from %s/%s: %s", err, newKey.Name, fn.Namespace, fn.Name, detachErr)
+			}
+		}
+		if deleteErr := faas.DeleteKey(clients.Keys, newKey.Name); deleteErr != nil {
+			return nil, fmt.Errorf("%w, cannot delete key %s: %s", err, newKey.Name, deleteErr)
+		}
+		return nil, err
+	}
+
+	for _, scope := range old.Functions {
+		fn, err := faas.GetFunction(clients.Functions, scope.Namespace, scope.Name).Extract()
+		if err != nil {
+			return rollback(fmt.Errorf("cannot get function %s/%s: %w", scope.Namespace, scope.Name, err))
+		}
+		if !fn.EnableAPIKey || contains(fn.Keys, newKey.Name) {
+			continue
+		}
+		if err := updateFunctionKeys(clients.Functions, scope, replaceKey(fn.Keys, "", newKey.Name), waitSeconds); err != nil {
+			return rollback(fmt.Errorf("cannot attach key %s to %s/%s: %w", newKey.Name, scope.Namespace, scope.Name, err))
+		}
+		result.Attached = append(result.Attached, scope)
+	}
+
+	if opts.Handoff != nil {
+		if err := opts.Handoff(newKey); err != nil {
+			return rollback(fmt.Errorf("handoff failed: %w", err))
+		}
+	}
+	if opts.KeepOldKey {
+		return result, nil
+	}
+
+	if opts.GracePeriod > 0 {
+		timer := time.NewTimer(opts.GracePeriod)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if err := Retire(clients, opts.Name, waitSeconds); err != nil {
+		return result, err
+	}
+	result.OldRetired = true
+	return result, nil
+}
+
+// Retire removes a key from the key list of the functions in its scope and deletes it.
+func Retire(clients Clients, name string, waitSeconds int) error {
+	if waitSeconds == 0 {
+		waitSeconds = DefaultWaitSeconds
+	}
+	key, err := faas.GetKey(clients.Keys, name).Extract()
+	if err != nil {
+		return err
+	}
+	for _, scope := range key.Functions {
+		fn, err := faas.GetFunction(clients.Functions, scope.Namespace, scope.Name).Extract()
+		if err != nil {
+			return fmt.Errorf("cannot get function %s/%s: %w", scope.Namespace, scope.Name, err)
+		}
+		if !contains(fn.Keys, name) {
+			continue
+		}
+		if err := updateFunctionKeys(clients.Functions, scope, replaceKey(fn.Keys, name, ""), waitSeconds); err != nil {
+			return fmt.Errorf("cannot detach key %s from %s/%s: %w", name, scope.Namespace, scope.Name, err)
+		}
+	}
+	if err := faas.DeleteKey(clients.Keys, name); err != nil {
+		return fmt.Errorf("cannot delete key %s: %w", name, err)
+	}
+	return nil
+}
+
+func detachKey(c *gcorecloud.ServiceClient, scope faas.KeysFunction, name string, waitSeconds int) error {
+	fn, err := faas.GetFunction(c, scope.Namespace, scope.Name).Extract()
+	if err != nil {
+		return err
+	}
+	return updateFunctionKeys(c, scope, replaceKey(fn.Keys, name, ""), waitSeconds)
+}
+
+func updateFunctionKeys(c *gcorecloud.ServiceClient, scope faas.KeysFunction, keys []string, waitSeconds int) error {
+	results, err := faas.UpdateFunction(c, scope.Namespace, scope.Name, faas.UpdateFunctionOpts{Keys: &keys}).Extract()
+	if err != nil {
+		return err
+	}
+	if len(results.Tasks) == 0 {
+		return nil
+	}
+	return tasks.WaitForFinishedTask(c, results.Tasks[0], waitSeconds)
+}
+
+// replaceKey returns the key list without the key named remove and with the key named add.
+func replaceKey(list []string, remove, add string) []string {
+	keys := make([]string, 0, len(list)+1)
+	for _, k := range list {
+		if k != remove && k != add {
+			keys = append(keys, k)
+		}
+	}
+	if add != "" {
+		keys = append(keys, add)
+	}
+	return keys
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
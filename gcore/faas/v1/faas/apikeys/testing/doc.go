@@ -0,0 +1,2 @@
+// apikeys unit tests
+package testing
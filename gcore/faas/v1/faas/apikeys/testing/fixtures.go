@@ -0,0 +1,78 @@
+package testing
+
+const TaskID = "50f53a35-42ed-40c4-82b2-5a37fb3e00bc"
+
+const OldKeyResponse = `
+{
+  "name": "ci-key",
+  "description": "ci",
+  "status": "active",
+  "created_at": "2024-01-01T00:00:00Z",
+  "expire": "2024-04-01T00:00:00Z",
+  "functions": [
+    {"name": "hello", "namespace": "default"},
+    {"name": "public", "namespace": "default"}
+  ]
+}
+`
+
+const NewKeyResponse = `
+{
+  "name": "ci-key-2",
+  "description": "ci",
+  "status": "active",
+  "secret": "new-secret",
+  "functions": [
+    {"name": "hello", "namespace": "default"},
+    {"name": "public", "namespace": "default"}
+  ]
+}
+`
+
+const HelloFunctionResponse = `
+{
+  "name": "hello",
+  "status": "active",
+  "enable_api_key": true,
+  "keys": ["ci-key", "other-key"]
+}
+`
+
+const PublicFunctionResponse = `
+{
+  "name": "public",
+  "status": "active",
+  "enable_api_key": false,
+  "keys": []
+}
+`
+
+const AttachRequest = `
+{
+  "keys": ["ci-key", "other-key", "ci-key-2"]
+}
+`
+
+const DetachRequest = `
+{
+  "keys": ["other-key", "ci-key-2"]
+}
+`
+
+const TaskResponse = `
+{
+  "tasks": [
+    "50f53a35-42ed-40c4-82b2-5a37fb3e00bc"
+  ]
+}
+`
+
+const FinishedTaskResponse = `
+{
+  "id": "50f53a35-42ed-40c4-82b2-5a37fb3e00bc",
+  "state": "FINISHED",
+  "task_type": "update_faas_function",
+  "project_id": 1,
+  "created_on": "2019-06-25T08:42:42"
+}
+`
@@ -0,0 +1,199 @@
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/faas/v1/faas"
+	"github.com/G-Core/gcorelabscloud-go/gcore/faas/v1/faas/apikeys"
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func respond(w http.ResponseWriter, status int, body string) {
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, err := fmt.Fprint(w, body)
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+func keyURL(name string) string {
+	return fmt.Sprintf("/v1/faas/keys/%d/%d/%s", fake.ProjectID, fake.RegionID, name)
+}
+
+func functionURL(name string) string {
+	return fmt.Sprintf("/v1/faas/namespaces/%d/%d/default/functions/%s", fake.ProjectID, fake.RegionID, name)
+}
+
+func clients() apikeys.Clients {
+	return apikeys.Clients{
+		Keys:      fake.ServiceTokenClient("faas/keys", "v1"),
+		Functions: fake.ServiceTokenClient("faas/namespaces", "v1"),
+	}
+}
+
+type recorder struct {
+	calls []string
+}
+
+func (r *recorder) setup(t *testing.T, handoffKeys *[]string) {
+	th.Mux.HandleFunc(keyURL("ci-key"), func(w http.ResponseWriter, req *http.Request) {
+		r.calls = append(r.calls, req.Method+" ci-key")
+		switch req.Method {
+		case http.MethodGet:
+			respond(w, http.StatusOK, OldKeyResponse)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	th.Mux.HandleFunc(keyURL("ci-key-2"), func(w http.ResponseWriter, req *http.Request) {
+		th.TestMethod(t, req, "DELETE")
+		r.calls = append(r.calls, "DELETE ci-key-2")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	th.Mux.HandleFunc(fmt.Sprintf("/v1/faas/keys/%d/%d", fake.ProjectID, fake.RegionID), func(w http.ResponseWriter, req *http.Request) {
+		th.TestMethod(t, req, "POST")
+		r.calls = append(r.calls, "POST key")
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		var request map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &request))
+		require.Equal(t, "ci-key-2", request["name"])
+		require.Equal(t, "ci", request["description"])
+		require.Len(t, request["functions"], 2)
+		expire, err := time.Parse(gcorecloud.RFC3339ZZ, request["expire"].(string))
+		require.NoError(t, err)
+		require.WithinDuration(t, time.Now().Add(91*24*time.Hour), expire, time.Minute)
+		respond(w, http.StatusOK, NewKeyResponse)
+	})
+	hello := HelloFunctionResponse
+	th.Mux.HandleFunc(functionURL("hello"), func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			respond(w, http.StatusOK, hello)
+		case http.MethodPatch:
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			var request struct {
+				Keys []string `json:"keys"`
+			}
+			require.NoError(t, json.Unmarshal(body, &request))
+			r.calls = append(r.calls, fmt.Sprintf("PATCH hello %v", request.Keys))
+			*handoffKeys = request.Keys
+			fn, _ := json.Marshal(map[string]interface{}{"name": "hello", "enable_api_key": true, "keys": request.Keys})
+			hello = string(fn)
+			respond(w, http.StatusOK, TaskResponse)
+		}
+	})
+	th.Mux.HandleFunc(functionURL("public"), func(w http.ResponseWriter, req *http.Request) {
+		th.TestMethod(t, req, "GET")
+		respond(w, http.StatusOK, PublicFunctionResponse)
+	})
+	th.Mux.HandleFunc(fmt.Sprintf("/v1/tasks/%s", TaskID), func(w http.ResponseWriter, req *http.Request) {
+		respond(w, http.StatusOK, FinishedTaskResponse)
+	})
+}
+
+func TestRotate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var rec recorder
+	var functionKeys []string
+	rec.setup(t, &functionKeys)
+
+	var handedOff faas.Key
+	result, err := apikeys.Rotate(context.Background(), clients(), apikeys.RotateOpts{
+		Name:    "ci-key",
+		NewName: "ci-key-2",
+		Handoff: func(key faas.Key) error {
+			handedOff = key
+			require.Equal(t, []string{"ci-key", "other-key", "ci-key-2"}, functionKeys)
+			return nil
+		},
+		GracePeriod: 10 * time.Millisecond,
+		WaitSeconds: 5,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "new-secret", handedOff.Secret)
+	require.Equal(t, "new-secret", result.NewKey.Secret)
+	require.Equal(t, []faas.KeysFunction{{Name: "hello", Namespace: "default"}}, result.Attached)
+	require.True(t, result.OldRetired)
+	require.Equal(t, []string{
+		"GET ci-key",
+		"POST key",
+		"PATCH hello [ci-key other-key ci-key-2]",
+		"GET ci-key",
+		"PATCH hello [other-key ci-key-2]",
+		"DELETE ci-key",
+	}, rec.calls)
+}
+
+func TestRotateHandoffFails(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var rec recorder
+	var functionKeys []string
+	rec.setup(t, &functionKeys)
+
+	_, err := apikeys.Rotate(context.Background(), clients(), apikeys.RotateOpts{
+		Name:    "ci-key",
+		NewName: "ci-key-2",
+		Handoff: func(key faas.Key) error {
+			return fmt.Errorf("vault is sealed")
+		},
+		WaitSeconds: 5,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "vault is sealed")
+	require.Equal(t, []string{
+		"GET ci-key",
+		"POST key",
+		"PATCH hello [ci-key other-key ci-key-2]",
+		"PATCH hello [ci-key other-key]",
+		"DELETE ci-key-2",
+	}, rec.calls)
+}
+
+func TestBuildReport(t *testing.T) {
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	keys := []faas.Key{
+		{Name: "ci-key", Expire: gcorecloud.JSONRFC3339ZZ{Time: now.Add(10 * 24 * time.Hour)},
+			Functions: []faas.KeysFunction{{Name: "hello", Namespace: "default"}}},
+		{Name: "old-key", Expire: gcorecloud.JSONRFC3339ZZ{Time: now.Add(-time.Hour)}},
+		{Name: "forever-key", Functions: []faas.KeysFunction{{Name: "hello", Namespace: "default"}}},
+		{Name: "later-key", Expire: gcorecloud.JSONRFC3339ZZ{Time: now.Add(90 * 24 * time.Hour)}},
+	}
+	functions := map[string][]faas.Function{
+		"default": {
+			{Name: "public"},
+			{Name: "hello", EnableAPIKey: true, Keys: []string{"ci-key", "deleted-key"}},
+		},
+	}
+
+	report := apikeys.BuildReport(keys, functions, apikeys.AuditOpts{Now: now})
+	require.Len(t, report.Expiring, 2)
+	require.Equal(t, "old-key", report.Expiring[0].Name)
+	require.True(t, report.Expiring[0].Expired)
+	require.Equal(t, "ci-key", report.Expiring[1].Name)
+	require.Equal(t, 10*24*time.Hour, report.Expiring[1].ExpiresIn)
+	require.Equal(t, []string{"later-key", "old-key"}, report.EmptyScope)
+
+	require.Len(t, report.Access, 2)
+	require.Equal(t, "hello", report.Access[0].Name)
+	require.Equal(t, []string{"ci-key", "forever-key"}, report.Access[0].ScopedKeys)
+	require.Equal(t, []string{"deleted-key"}, report.Access[0].UnknownKeys)
+	require.Equal(t, "public", report.Access[1].Name)
+	require.False(t, report.Access[1].EnableAPIKey)
+}
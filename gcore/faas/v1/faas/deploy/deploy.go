@@ -0,0 +1,103 @@
+package deploy
+
+import (
+	"fmt"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/faas/v1/faas"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+)
+
+const (
+	// DefaultWaitSeconds is how long Deploy waits for the task and for the function to become active.
+	DefaultWaitSeconds = 600
+	// StatusActive is the status of a deployed function.
+	StatusActive = "active"
+)
+
+// failedStatuses are function and build statuses ending a deployment.
+var failedStatuses = map[string]bool{"error": true, "failed": true}
+
+// Opts represents options used to deploy a Package.
+type Opts struct {
+	// Namespace defaults to the manifest namespace.
+	Namespace   string
+	WaitSeconds int `validate:"omitempty,gt=0"`
+}
+
+// Result describes a finished deployment.
+type Result struct {
+	Namespace string         `json:"namespace"`
+	Created   bool           `json:"created"`
+	Function  *faas.Function `json:"function"`
+}
+
+// Deploy creates the function of the package, or updates it when it already exists,
+// then waits for the function to become active. The client must be a faas/namespaces v1 client.
+func Deploy(c *gcorecloud.ServiceClient, pkg *Package, opts Opts) (*Result, error) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	namespace := opts.Namespace
+	if namespace == "" {
+		namespace = pkg.Manifest.Namespace
+	}
+	if namespace == "" {
+		return nil, fmt.Errorf("namespace is not set")
+	}
+	waitSeconds := opts.WaitSeconds
+	if waitSeconds == 0 {
+		waitSeconds = DefaultWaitSeconds
+	}
+	name := pkg.Manifest.Name
+
+	result := &Result{Namespace: namespace}
+	var r tasks.Result
+	_, err := faas.GetFunction(c, namespace, name).Extract()
+	switch err.(type) {
+	case nil:
+		r = faas.UpdateFunction(c, namespace, name, pkg.ToUpdateOpts())
+	case gcorecloud.ErrDefault404:
+		r = faas.CreateFunction(c, namespace, pkg.ToCreateOpts())
+		result.Created = true
+	default:
+		return nil, err
+	}
+	results, err := r.Extract()
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Tasks) == 0 {
+		return nil, fmt.Errorf("wrong task response")
+	}
+	if err := tasks.WaitForFinishedTask(c, results.Tasks[0], waitSeconds); err != nil {
+		return nil, err
+	}
+
+	result.Function, err = WaitActive(c, namespace, name, waitSeconds)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// WaitActive waits for a function to become active. A failed build or function is returned as an error with the build message.
+func WaitActive(c *gcorecloud.ServiceClient, namespace, name string, waitSeconds int) (*faas.Function, error) {
+	var fn *faas.Function
+	err := gcorecloud.WaitFor(waitSeconds, func() (bool, error) {
+		var err error
+		fn, err = faas.GetFunction(c, namespace, name).Extract()
+		if err != nil {
+			return false, err
+		}
+		if failedStatuses[fn.Status] || failedStatuses[fn.BuildStatus] {
+			return false, fmt.Errorf("function %s/%s failed: status %s, build status %s: %s",
+				namespace, name, fn.Status, fn.BuildStatus, fn.BuildMessage)
+		}
+		return fn.Status == StatusActive, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fn, nil
+}
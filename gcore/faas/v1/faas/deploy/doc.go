@@ -0,0 +1,34 @@
+/*
+Package deploy packages a FaaS function from a local source directory and deploys it
+
+The directory holds a function manifest (function.yaml, function.yml or function.json),
+the code file and an optional dependency file. The function code is a single file: Load fails
+when the directory holds other source files of the runtime, e.g. a second .py module.
+
+	name: hello
+	namespace: default
+	runtime: python3.7.12
+	main_method: main
+	flavor: 64mCPU-64MB
+	timeout: 5
+	code: main.py
+	dependencies: requirements.txt
+	envs:
+	  LOG_LEVEL: info
+	autoscaling:
+	  min_instances: 0
+	  max_instances: 2
+
+Example to deploy a function
+
+	pkg, err := deploy.Load("./hello")
+	if err != nil {
+		panic(err)
+	}
+
+	result, err := deploy.Deploy(client, pkg, deploy.Opts{})
+	if err != nil {
+		panic(err)
+	}
+*/
+package deploy
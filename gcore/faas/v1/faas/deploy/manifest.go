@@ -0,0 +1,249 @@
+package deploy
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/faas/v1/faas"
+	"gopkg.in/yaml.v2"
+)
+
+// ManifestFileNames are the manifest file names looked up in a source directory, in order.
+var ManifestFileNames = []string{"function.yaml", "function.yml", "function.json"}
+
+// runtimeDefaults are the code and dependency file names used when the manifest omits them,
+// along with the extensions of the runtime source files.
+var runtimeDefaults = []struct {
+	prefix       string
+	code         string
+	dependencies string
+	extensions   []string
+}{
+	{prefix: "python", code: "main.py", dependencies: "requirements.txt", extensions: []string{".py"}},
+	{prefix: "node", code: "index.js", dependencies: "package.json", extensions: []string{".js", ".mjs", ".cjs", ".ts"}},
+	{prefix: "go", code: "main.go", dependencies: "go.mod", extensions: []string{".go"}},
+}
+
+// skippedDirs are directories not searched for extra source files.
+var skippedDirs = map[string]struct{}{"node_modules": {}, "vendor": {}, "__pycache__": {}}
+
+// Autoscaling is the autoscaling section of a manifest.
+type Autoscaling struct {
+	MinInstances *int `yaml:"min_instances" json:"min_instances,omitempty" validate:"omitempty,gte=0"`
+	MaxInstances *int `yaml:"max_instances" json:"max_instances,omitempty" validate:"omitempty,gt=0"`
+}
+
+// Manifest describes a function deployed from a source directory.
+type Manifest struct {
+	Name string `yaml:"name" json:"name" validate:"required"`
+	// Namespace is used when no namespace is passed to Deploy.
+	Namespace   string            `yaml:"namespace" json:"namespace,omitempty"`
+	Description string            `yaml:"description" json:"description,omitempty"`
+	Runtime     string            `yaml:"runtime" json:"runtime" validate:"required"`
+	MainMethod  string            `yaml:"main_method" json:"main_method" validate:"required"`
+	Flavor      string            `yaml:"flavor" json:"flavor" validate:"required"`
+	Timeout     int               `yaml:"timeout" json:"timeout,omitempty" validate:"omitempty,gt=0"`
+	Envs        map[string]string `yaml:"envs" json:"envs,omitempty"`
+	Autoscaling Autoscaling       `yaml:"autoscaling" json:"autoscaling"`
+	// Code is the code file relative to the directory. Defaults to the runtime main file, e.g. main.py.
+	Code string `yaml:"code" json:"code,omitempty"`
+	// Dependencies is the dependency file relative to the directory. Defaults to the runtime
+	// dependency file, e.g. requirements.txt, which is skipped when it does not exist.
+	Dependencies string   `yaml:"dependencies" json:"dependencies,omitempty"`
+	EnableAPIKey *bool    `yaml:"enable_api_key" json:"enable_api_key,omitempty"`
+	Keys         []string `yaml:"keys" json:"keys,omitempty"`
+	Disabled     *bool    `yaml:"disabled" json:"disabled,omitempty"`
+}
+
+// Validate checks the manifest.
+func (m Manifest) Validate() error {
+	if err := gcorecloud.ValidateStruct(m); err != nil {
+		return err
+	}
+	a := m.Autoscaling
+	if a.MinInstances != nil && a.MaxInstances != nil && *a.MinInstances > *a.MaxInstances {
+		return fmt.Errorf("autoscaling min_instances %d is greater than max_instances %d", *a.MinInstances, *a.MaxInstances)
+	}
+	return nil
+}
+
+// Package is a function manifest with its code and dependencies read from a source directory.
+type Package struct {
+	Dir          string
+	Manifest     Manifest
+	CodeText     string
+	Dependencies string
+}
+
+// ReadManifest reads and validates the manifest of a source directory.
+func ReadManifest(dir string) (*Manifest, error) {
+	for _, name := range ManifestFileNames {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var m Manifest
+		if err := yaml.UnmarshalStrict(data, &m); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if err := m.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		return &m, nil
+	}
+	return nil, fmt.Errorf("no function manifest (%s) in %s", strings.Join(ManifestFileNames, ", "), dir)
+}
+
+// Load reads the manifest, the code and the dependency file of a source directory.
+// A function is deployed from a single code file, so a directory with other source files
+// of the runtime is rejected instead of deploying only a part of the code.
+func Load(dir string) (*Package, error) {
+	m, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	code, dependencies, optional := m.Code, m.Dependencies, false
+	var extensions []string
+	for _, d := range runtimeDefaults {
+		if !strings.HasPrefix(m.Runtime, d.prefix) {
+			continue
+		}
+		if code == "" {
+			code = d.code
+		}
+		if dependencies == "" {
+			dependencies, optional = d.dependencies, true
+		}
+		extensions = d.extensions
+		break
+	}
+	if code == "" {
+		return nil, fmt.Errorf("code file is not set and there is no default for runtime %s", m.Runtime)
+	}
+	if extensions == nil && filepath.Ext(code) != "" {
+		extensions = []string{filepath.Ext(code)}
+	}
+
+	p := &Package{Dir: dir, Manifest: *m}
+	codeText, err := readFile(dir, code)
+	if err != nil {
+		return nil, fmt.Errorf("code: %w", err)
+	}
+	p.CodeText = codeText
+	if err := checkSingleSource(dir, code, extensions); err != nil {
+		return nil, err
+	}
+	if dependencies != "" {
+		p.Dependencies, err = readFile(dir, dependencies)
+		if err != nil && !(optional && errors.Is(err, os.ErrNotExist)) {
+			return nil, fmt.Errorf("dependencies: %w", err)
+		}
+	}
+	return p, nil
+}
+
+// readFile reads a file of the directory, paths leaving it are rejected.
+func readFile(dir, name string) (string, error) {
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("%s is outside of the source directory", name)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// checkSingleSource fails when the directory holds source files other than the code file.
+func checkSingleSource(dir, code string, extensions []string) error {
+	var extra []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if _, ok := skippedDirs[d.Name()]; path != dir && (ok || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		for _, ext := range extensions {
+			if filepath.Ext(name) == ext && name != filepath.Clean(code) {
+				extra = append(extra, filepath.ToSlash(name))
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(extra) != 0 {
+		return fmt.Errorf("only the code file %s is deployed, remove or merge other source files: %s",
+			code, strings.Join(extra, ", "))
+	}
+	return nil
+}
+
+func (p *Package) autoscaling() faas.FunctionAutoscaling {
+	return faas.FunctionAutoscaling{
+		MinInstances: p.Manifest.Autoscaling.MinInstances,
+		MaxInstances: p.Manifest.Autoscaling.MaxInstances,
+	}
+}
+
+// ToCreateOpts builds options to create the function.
+func (p *Package) ToCreateOpts() faas.CreateFunctionOpts {
+	m := p.Manifest
+	return faas.CreateFunctionOpts{
+		Name:         m.Name,
+		Description:  m.Description,
+		Envs:         m.Envs,
+		Runtime:      m.Runtime,
+		Timeout:      m.Timeout,
+		Flavor:       m.Flavor,
+		Autoscaling:  p.autoscaling(),
+		CodeText:     p.CodeText,
+		EnableApiKey: m.EnableAPIKey,
+		Keys:         m.Keys,
+		Disabled:     m.Disabled,
+		MainMethod:   m.MainMethod,
+		Dependencies: p.Dependencies,
+	}
+}
+
+// ToUpdateOpts builds options to update an existing function to the package.
+// The runtime cannot be changed by an update.
+func (p *Package) ToUpdateOpts() faas.UpdateFunctionOpts {
+	m := p.Manifest
+	autoscaling := p.autoscaling()
+	opts := faas.UpdateFunctionOpts{
+		Description:  m.Description,
+		Envs:         m.Envs,
+		Timeout:      m.Timeout,
+		Flavor:       m.Flavor,
+		Autoscaling:  &autoscaling,
+		CodeText:     p.CodeText,
+		EnableApiKey: m.EnableAPIKey,
+		Disabled:     m.Disabled,
+		Dependencies: p.Dependencies,
+		MainMethod:   m.MainMethod,
+	}
+	if m.Keys != nil {
+		keys := m.Keys
+		opts.Keys = &keys
+	}
+	return opts
+}
@@ -0,0 +1,2 @@
+// deploy unit tests
+package testing
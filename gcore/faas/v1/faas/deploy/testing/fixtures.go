@@ -0,0 +1,115 @@
+package testing
+
+const (
+	Namespace = "default"
+	Name      = "hello"
+	TaskID    = "50f53a35-42ed-40c4-82b2-5a37fb3e00bc"
+)
+
+const Manifest = `
+name: hello
+namespace: default
+runtime: python3.7.12
+main_method: main
+flavor: 64mCPU-64MB
+timeout: 5
+envs:
+  LOG_LEVEL: info
+autoscaling:
+  min_instances: 0
+  max_instances: 2
+`
+
+const Code = "def main(): print('It works!')\n"
+
+const Requirements = "requests==2.31.0\n"
+
+const CreateRequest = `
+{
+  "name": "hello",
+  "description": "",
+  "envs": {
+    "LOG_LEVEL": "info"
+  },
+  "runtime": "python3.7.12",
+  "timeout": 5,
+  "flavor": "64mCPU-64MB",
+  "autoscaling": {
+    "min_instances": 0,
+    "max_instances": 2
+  },
+  "code_text": "def main(): print('It works!')\n",
+  "main_method": "main",
+  "dependencies": "requests==2.31.0\n"
+}
+`
+
+const UpdateRequest = `
+{
+  "envs": {
+    "LOG_LEVEL": "info"
+  },
+  "timeout": 5,
+  "flavor": "64mCPU-64MB",
+  "autoscaling": {
+    "min_instances": 0,
+    "max_instances": 2
+  },
+  "code_text": "def main(): print('It works!')\n",
+  "main_method": "main",
+  "dependencies": "requests==2.31.0\n"
+}
+`
+
+const TaskResponse = `
+{
+  "tasks": [
+    "50f53a35-42ed-40c4-82b2-5a37fb3e00bc"
+  ]
+}
+`
+
+const FinishedTaskResponse = `
+{
+  "id": "50f53a35-42ed-40c4-82b2-5a37fb3e00bc",
+  "state": "FINISHED",
+  "task_type": "create_faas_function",
+  "project_id": 1,
+  "created_on": "2019-06-25T08:42:42"
+}
+`
+
+const BuildingFunctionResponse = `
+{
+  "name": "hello",
+  "status": "deploying",
+  "build_status": "building",
+  "runtime": "python3.7.12",
+  "main_method": "main",
+  "flavor": "64mCPU-64MB"
+}
+`
+
+const ActiveFunctionResponse = `
+{
+  "name": "hello",
+  "status": "active",
+  "build_status": "success",
+  "runtime": "python3.7.12",
+  "main_method": "main",
+  "flavor": "64mCPU-64MB",
+  "endpoint": "https://hello.example.com"
+}
+`
+
+const FailedFunctionResponse = `
+{
+  "name": "hello",
+  "status": "error",
+  "build_status": "failed",
+  "build_message": "cannot install requirements",
+  "runtime": "python3.7.12",
+  "main_method": "main",
+  "flavor": "64mCPU-64MB"
+}
+`
@@ -0,0 +1,168 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/faas/v1/faas/deploy"
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func respond(w http.ResponseWriter, status int, body string) {
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, err := fmt.Fprint(w, body)
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+func writeSource(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	return dir
+}
+
+func functionURL() string {
+	return fmt.Sprintf("/v1/faas/namespaces/%d/%d/%s/functions/%s", fake.ProjectID, fake.RegionID, Namespace, Name)
+}
+
+func TestLoad(t *testing.T) {
+	dir := writeSource(t, map[string]string{
+		"function.yaml":    Manifest,
+		"main.py":          Code,
+		"requirements.txt": Requirements,
+	})
+	pkg, err := deploy.Load(dir)
+	require.NoError(t, err)
+	require.Equal(t, Name, pkg.Manifest.Name)
+	require.Equal(t, Code, pkg.CodeText)
+	require.Equal(t, Requirements, pkg.Dependencies)
+
+	dir = writeSource(t, map[string]string{"function.yaml": Manifest, "main.py": Code})
+	pkg, err = deploy.Load(dir)
+	require.NoError(t, err)
+	require.Empty(t, pkg.Dependencies)
+
+	dir = writeSource(t, map[string]string{"function.yaml": Manifest + "dependencies: deps.txt\n", "main.py": Code})
+	_, err = deploy.Load(dir)
+	require.Error(t, err)
+
+	dir = writeSource(t, map[string]string{"function.yaml": Manifest + "code: ../main.py\n"})
+	_, err = deploy.Load(dir)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "outside")
+
+	dir = writeSource(t, map[string]string{"function.yaml": "name: hello\nruntime: python3.7.12\n", "main.py": Code})
+	_, err = deploy.Load(dir)
+	require.Error(t, err)
+
+	dir = writeSource(t, map[string]string{"function.yaml": Manifest + "unknown: true\n", "main.py": Code})
+	_, err = deploy.Load(dir)
+	require.Error(t, err)
+
+	_, err = deploy.Load(t.TempDir())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no function manifest")
+}
+
+func TestLoadSingleSourceFile(t *testing.T) {
+	dir := writeSource(t, map[string]string{"function.yaml": Manifest, "main.py": Code, "helpers.py": Code})
+	_, err := deploy.Load(dir)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "helpers.py")
+
+	dir = writeSource(t, map[string]string{"function.yaml": Manifest, "main.py": Code, "README.md": "hello"})
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "__pycache__"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "__pycache__", "cached.py"), []byte(Code), 0o600))
+	_, err = deploy.Load(dir)
+	require.NoError(t, err)
+}
+
+func TestDeployCreate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	dir := writeSource(t, map[string]string{
+		"function.json":    `{"name": "hello", "namespace": "default", "runtime": "python3.7.12", "main_method": "main", "flavor": "64mCPU-64MB", "timeout": 5, "envs": {"LOG_LEVEL": "info"}, "autoscaling": {"min_instances": 0, "max_instances": 2}}`,
+		"main.py":          Code,
+		"requirements.txt": Requirements,
+	})
+	pkg, err := deploy.Load(dir)
+	require.NoError(t, err)
+
+	created := false
+	gets := 0
+	th.Mux.HandleFunc(functionURL(), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		switch {
+		case !created:
+			respond(w, http.StatusNotFound, `{"message": "not found"}`)
+		case gets == 0:
+			gets++
+			respond(w, http.StatusOK, BuildingFunctionResponse)
+		default:
+			respond(w, http.StatusOK, ActiveFunctionResponse)
+		}
+	})
+	th.Mux.HandleFunc(fmt.Sprintf("/v1/faas/namespaces/%d/%d/%s/functions", fake.ProjectID, fake.RegionID, Namespace), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestJSONRequest(t, r, CreateRequest)
+		created = true
+		respond(w, http.StatusOK, TaskResponse)
+	})
+	th.Mux.HandleFunc(fmt.Sprintf("/v1/tasks/%s", TaskID), func(w http.ResponseWriter, r *http.Request) {
+		respond(w, http.StatusOK, FinishedTaskResponse)
+	})
+
+	result, err := deploy.Deploy(fake.ServiceTokenClient("faas/namespaces", "v1"), pkg, deploy.Opts{WaitSeconds: 10})
+	require.NoError(t, err)
+	require.True(t, result.Created)
+	require.Equal(t, Namespace, result.Namespace)
+	require.Equal(t, "https://hello.example.com", result.Function.Endpoint)
+}
+
+func TestDeployUpdate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	dir := writeSource(t, map[string]string{
+		"function.yaml":    Manifest,
+		"main.py":          Code,
+		"requirements.txt": Requirements,
+	})
+	pkg, err := deploy.Load(dir)
+	require.NoError(t, err)
+
+	updated := false
+	th.Mux.HandleFunc(functionURL(), func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			if updated {
+				respond(w, http.StatusOK, FailedFunctionResponse)
+				return
+			}
+			respond(w, http.StatusOK, ActiveFunctionResponse)
+		case http.MethodPatch:
+			th.TestJSONRequest(t, r, UpdateRequest)
+			updated = true
+			respond(w, http.StatusOK, TaskResponse)
+		}
+	})
+	th.Mux.HandleFunc(fmt.Sprintf("/v1/tasks/%s", TaskID), func(w http.ResponseWriter, r *http.Request) {
+		respond(w, http.StatusOK, FinishedTaskResponse)
+	})
+
+	_, err = deploy.Deploy(fake.ServiceTokenClient("faas/namespaces", "v1"), pkg, deploy.Opts{WaitSeconds: 10})
+	require.Error(t, err)
+	require.True(t, updated)
+	require.Contains(t, err.Error(), "cannot install requirements")
+}
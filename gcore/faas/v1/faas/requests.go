@@ -282,12 +282,19 @@ type CreateKeyOptsBuilder interface {
 type CreateKeyOpts struct {
 	Name        string                    `json:"name"`
 	Description string                    `json:"description"`
-	Expire      *gcorecloud.JSONRFC3339ZZ `json:"expire,omitempty"`
+	Expire      *gcorecloud.JSONRFC3339ZZ `json:"-"`
 	Functions   []KeysFunction            `json:"functions,omitempty"`
 }
 
 func (opts CreateKeyOpts) ToKeyCreateMap() (map[string]any, error) {
-	return gcorecloud.BuildRequestBody(opts, "")
+	b, err := gcorecloud.BuildRequestBody(opts, "")
+	if err != nil {
+		return nil, err
+	}
+	if opts.Expire != nil {
+		b["expire"] = opts.Expire.Format(gcorecloud.RFC3339ZZ)
+	}
+	return b, nil
 }
 
 // CreateKey create FaaS key.
@@ -327,13 +334,20 @@ type UpdateKeyOptsBuilder interface {
 // UpdateKeyOpts represents options used to Update a key.
 type UpdateKeyOpts struct {
 	Description string                    `json:"description,omitempty"`
-	Expire      *gcorecloud.JSONRFC3339ZZ `json:"expire,omitempty"`
+	Expire      *gcorecloud.JSONRFC3339ZZ `json:"-"`
 	Functions   []KeysFunction            `json:"functions,omitempty"`
 }
 
 // ToKeyUpdateMap builds a request body from UpdateKeyOpts.
 func (opts UpdateKeyOpts) ToKeyUpdateMap() (map[string]interface{}, error) {
-	return gcorecloud.BuildRequestBody(opts, "")
+	b, err := gcorecloud.BuildRequestBody(opts, "")
+	if err != nil {
+		return nil, err
+	}
+	if opts.Expire != nil {
+		b["expire"] = opts.Expire.Format(gcorecloud.RFC3339ZZ)
+	}
+	return b, nil
 }
 
 // UpdateKey update FaaS key.
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/AlekSi/pointer"
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
 	"github.com/G-Core/gcorelabscloud-go/gcore/faas/v1/faas"
 	"github.com/G-Core/gcorelabscloud-go/pagination"
 	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
@@ -594,3 +596,19 @@ func TestUpdateKeys(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expectedUpdatedKey, key)
 }
+
+func TestKeyOptsExpire(t *testing.T) {
+	expire := gcorecloud.JSONRFC3339ZZ{Time: time.Date(2023, 7, 31, 0, 0, 0, 0, time.UTC)}
+
+	createMap, err := faas.CreateKeyOpts{Name: "test-key", Expire: &expire}.ToKeyCreateMap()
+	require.NoError(t, err)
+	require.Equal(t, "2023-07-31T00:00:00Z", createMap["expire"])
+
+	updateMap, err := faas.UpdateKeyOpts{Expire: &expire}.ToKeyUpdateMap()
+	require.NoError(t, err)
+	require.Equal(t, "2023-07-31T00:00:00Z", updateMap["expire"])
+
+	updateMap, err = faas.UpdateKeyOpts{Description: "long string"}.ToKeyUpdateMap()
+	require.NoError(t, err)
+	require.NotContains(t, updateMap, "expire")
+}
@@ -0,0 +1,36 @@
+/*
+Package pool manages floating IPs owned by an application and fails over VIPs between instances
+
+Unowned floating IPs are only claimed by a pool when they carry the eligible metadata key set to "true",
+see DefaultEligibleKey.
+
+Example to acquire a floating IP for a port
+
+	p, err := pool.New(floatingIPClient, availableFloatingIPClient, pool.Opts{Owner: "web-cluster"})
+	if err != nil {
+		panic(err)
+	}
+
+	fip, err := p.Acquire(primaryPortID, nil)
+	if err != nil {
+		panic(err)
+	}
+
+Example to move a floating IP to a standby instance port
+
+	fip, err = p.Move(fip.ID, standbyPortID, nil)
+	if err != nil {
+		panic(err)
+	}
+
+Example to fail over a VIP to a standby port
+
+	devices, err := pool.FailoverVIP(reservedFixedIPClient, pool.VIPFailoverOpts{
+		VIPPortID:     vipPortID,
+		ActivePortIDs: []string{standbyPortID},
+	})
+	if err != nil {
+		panic(err)
+	}
+*/
+package pool
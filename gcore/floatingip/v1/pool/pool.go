@@ -0,0 +1,256 @@
+package pool
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/floatingip/v1/availablefloatingips"
+	"github.com/G-Core/gcorelabscloud-go/gcore/floatingip/v1/floatingips"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+	"github.com/G-Core/gcorelabscloud-go/gcore/utils/metadata"
+	metadataV1 "github.com/G-Core/gcorelabscloud-go/gcore/utils/metadata/v1/metadata"
+)
+
+const (
+	// DefaultOwnerKey is the metadata key used to mark floating IPs owned by a pool.
+	DefaultOwnerKey = "gcore_pool_owner"
+	// DefaultEligibleKey is the metadata key marking unowned floating IPs a pool may claim.
+	DefaultEligibleKey = "gcore_pool_eligible"
+	// eligibleValue is the value of the eligible key of floating IPs a pool may claim.
+	eligibleValue = "true"
+	// DefaultWaitSeconds is how long the pool waits for floating IP create and delete tasks.
+	DefaultWaitSeconds = 300
+)
+
+// Opts represents options used to create a Pool.
+type Opts struct {
+	// Owner is a value stored in the owner metadata key of every floating IP of the pool.
+	Owner string `validate:"required"`
+	// OwnerKey is a metadata key holding the owner. Defaults to DefaultOwnerKey.
+	OwnerKey string
+	// EligibleKey is a metadata key which has to be set to "true" on an unowned floating IP for the pool to claim it.
+	// Defaults to DefaultEligibleKey.
+	EligibleKey string
+	WaitSeconds int `validate:"omitempty,gt=0"`
+}
+
+// Pool acquires, releases and moves floating IPs owned by a single owner.
+// Ownership is tracked with a metadata tag on the floating IP, so several pools can share a project.
+// Only floating IPs tagged with the eligible key are claimed, other unowned floating IPs of the project are left alone.
+type Pool struct {
+	client          *gcorecloud.ServiceClient
+	availableClient *gcorecloud.ServiceClient
+	owner           string
+	ownerKey        string
+	eligibleKey     string
+	waitSeconds     int
+
+	// mu serializes Acquire so the same free floating IP is not handed out twice.
+	mu sync.Mutex
+}
+
+// New creates a Pool. The client must be a floatingips v1 client and availableClient an availablefloatingips v1 client.
+func New(client, availableClient *gcorecloud.ServiceClient, opts Opts) (*Pool, error) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	p := &Pool{
+		client:          client,
+		availableClient: availableClient,
+		owner:           opts.Owner,
+		ownerKey:        opts.OwnerKey,
+		eligibleKey:     opts.EligibleKey,
+		waitSeconds:     opts.WaitSeconds,
+	}
+	if p.ownerKey == "" {
+		p.ownerKey = DefaultOwnerKey
+	}
+	if p.eligibleKey == "" {
+		p.eligibleKey = DefaultEligibleKey
+	}
+	if p.waitSeconds == 0 {
+		p.waitSeconds = DefaultWaitSeconds
+	}
+	return p, nil
+}
+
+// Owner returns the owner of the pool.
+func (p *Pool) Owner() string {
+	return p.owner
+}
+
+// OwnerKey returns the metadata key used for ownership tags.
+func (p *Pool) OwnerKey() string {
+	return p.ownerKey
+}
+
+// List returns all floating IPs owned by the pool.
+func (p *Pool) List() ([]floatingips.FloatingIPDetail, error) {
+	return floatingips.ListAll(p.client, floatingips.ListOpts{
+		MetadataKV: map[string]string{p.ownerKey: p.owner},
+	})
+}
+
+// Acquire returns a free floating IP of the pool and assigns it to the port when portID is set.
+// A free floating IP owned by the pool is preferred, then an unowned available one tagged with the eligible key
+// which gets claimed, and a new floating IP is allocated only when there is none.
+func (p *Pool) Acquire(portID string, fixedIPAddress net.IP) (*instances.FloatingIP, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	id, err := p.take()
+	if err != nil {
+		return nil, err
+	}
+	if portID == "" {
+		return floatingips.Get(p.client, id).Extract()
+	}
+	return floatingips.Assign(p.client, id, floatingips.CreateOpts{
+		PortID:         portID,
+		FixedIPAddress: fixedIPAddress,
+	}).Extract()
+}
+
+func (p *Pool) take() (string, error) {
+	owned, err := p.List()
+	if err != nil {
+		return "", err
+	}
+	for _, fip := range owned {
+		if fip.PortID == "" {
+			return fip.ID, nil
+		}
+	}
+
+	available, err := availablefloatingips.ListAll(p.availableClient)
+	if err != nil {
+		return "", err
+	}
+	for _, fip := range available {
+		if fip.PortID != "" || hasMetadataKey(fip.Metadata, p.ownerKey) || !hasMetadata(fip.Metadata, p.eligibleKey, eligibleValue) {
+			continue
+		}
+		claimed, err := p.claim(fip.ID)
+		if err != nil {
+			return "", err
+		}
+		if claimed {
+			return fip.ID, nil
+		}
+	}
+
+	return p.allocate()
+}
+
+// claim tags the floating IP with the owner and reads it back, as another pool may have claimed it concurrently.
+func (p *Pool) claim(id string) (bool, error) {
+	err := metadataV1.MetadataCreateOrUpdate(p.client, id, map[string]string{p.ownerKey: p.owner}).ExtractErr()
+	if err != nil {
+		return false, fmt.Errorf("cannot claim floating ip %s: %w", id, err)
+	}
+	fip, err := floatingips.Get(p.client, id).Extract()
+	if err != nil {
+		return false, fmt.Errorf("cannot check claim of floating ip %s: %w", id, err)
+	}
+	return fip.PortID == "" && hasMetadata(fip.Metadata, p.ownerKey, p.owner), nil
+}
+
+func (p *Pool) allocate() (string, error) {
+	results, err := floatingips.Create(p.client, floatingips.CreateOpts{
+		Metadata: map[string]string{p.ownerKey: p.owner},
+	}).Extract()
+	if err != nil {
+		return "", err
+	}
+	if len(results.Tasks) == 0 {
+		return "", fmt.Errorf("wrong task response")
+	}
+	id, err := tasks.WaitTaskAndReturnResult(p.client, results.Tasks[0], true, p.waitSeconds, func(task tasks.TaskID) (interface{}, error) {
+		taskInfo, err := tasks.Get(p.client, string(task)).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
+		}
+		return floatingips.ExtractFloatingIPIDFromTask(taskInfo)
+	})
+	if err != nil {
+		return "", err
+	}
+	return id.(string), nil
+}
+
+// Release unassigns a floating IP owned by the pool. The floating IP stays in the pool unless deleteIP is set,
+// in which case it is deleted.
+func (p *Pool) Release(floatingIPID string, deleteIP bool) error {
+	fip, err := p.get(floatingIPID)
+	if err != nil {
+		return err
+	}
+	if fip.PortID != "" {
+		if _, err := floatingips.UnAssign(p.client, floatingIPID).Extract(); err != nil {
+			return err
+		}
+	}
+	if !deleteIP {
+		return nil
+	}
+	results, err := floatingips.Delete(p.client, floatingIPID).Extract()
+	if err != nil {
+		return err
+	}
+	if len(results.Tasks) == 0 {
+		return fmt.Errorf("wrong task response")
+	}
+	return tasks.WaitForFinishedTask(p.client, results.Tasks[0], p.waitSeconds)
+}
+
+// Move reassigns a floating IP owned by the pool to another port.
+func (p *Pool) Move(floatingIPID, portID string, fixedIPAddress net.IP) (*instances.FloatingIP, error) {
+	fip, err := p.get(floatingIPID)
+	if err != nil {
+		return nil, err
+	}
+	if fip.PortID == portID && (fixedIPAddress == nil || fixedIPAddress.Equal(fip.FixedIPAddress)) {
+		return fip, nil
+	}
+	if fip.PortID != "" {
+		if _, err := floatingips.UnAssign(p.client, floatingIPID).Extract(); err != nil {
+			return nil, err
+		}
+	}
+	return floatingips.Assign(p.client, floatingIPID, floatingips.CreateOpts{
+		PortID:         portID,
+		FixedIPAddress: fixedIPAddress,
+	}).Extract()
+}
+
+// get returns a floating IP and checks it is owned by the pool.
+func (p *Pool) get(floatingIPID string) (*instances.FloatingIP, error) {
+	fip, err := floatingips.Get(p.client, floatingIPID).Extract()
+	if err != nil {
+		return nil, err
+	}
+	if !hasMetadata(fip.Metadata, p.ownerKey, p.owner) {
+		return nil, fmt.Errorf("floating ip %s is not owned by %s", floatingIPID, p.owner)
+	}
+	return fip, nil
+}
+
+func hasMetadataKey(md []metadata.Metadata, key string) bool {
+	for _, m := range md {
+		if m.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func hasMetadata(md []metadata.Metadata, key, value string) bool {
+	for _, m := range md {
+		if m.Key == key && m.Value == value {
+			return true
+		}
+	}
+	return false
+}
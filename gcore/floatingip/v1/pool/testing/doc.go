@@ -0,0 +1,2 @@
+// pool unit tests
+package testing
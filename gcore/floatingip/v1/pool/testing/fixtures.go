@@ -0,0 +1,115 @@
+package testing
+
+import "fmt"
+
+const (
+	Owner            = "web-cluster"
+	FloatingIPID     = "c64e5db1-5f1f-43ec-a8d9-5090df85b82d"
+	PrimaryPortID    = "ee2402d0-f0cd-4503-9b75-69be1d11c5f1"
+	StandbyPortID    = "0f0f46c5-3d1c-4a48-9c5b-0b8a7b9e1a30"
+	VIPPortID        = "817c8a3d-bb67-4b88-a0d1-aec980318ff1"
+	CreateTaskID     = "50f53a35-42ed-40c4-82b2-5a37fb3e00bc"
+	OwnerMetadata    = `[{"key": "gcore_pool_owner", "value": "web-cluster", "read_only": false}]`
+	ForeignMetadata  = `[{"key": "gcore_pool_owner", "value": "db-cluster", "read_only": false}]`
+	EligibleMetadata = `[{"key": "gcore_pool_eligible", "value": "true", "read_only": false}]`
+)
+
+// FloatingIPResponse renders a floating ip with the given port and metadata.
+func FloatingIPResponse(portID, metadata string) string {
+	port := "null"
+	fixedIP := "null"
+	if portID != "" {
+		port = fmt.Sprintf("%q", portID)
+		fixedIP = `"192.168.10.15"`
+	}
+	return fmt.Sprintf(`
+{
+  "floating_ip_address": "172.24.4.34",
+  "router_id": "11005a33-c5ac-4c96-ab6f-8f2827cc7da6",
+  "status": "ACTIVE",
+  "id": "%s",
+  "port_id": %s,
+  "fixed_ip_address": %s,
+  "created_at": "2019-06-13T13:58:12+0000",
+  "updated_at": "2019-06-13T13:58:12+0000",
+  "project_id": 1,
+  "region_id": 1,
+  "region": "RegionOne",
+  "metadata": %s
+}
+`, FloatingIPID, port, fixedIP, metadata)
+}
+
+// FloatingIPListResponse renders a list with a single floating ip.
+func FloatingIPListResponse(portID, metadata string) string {
+	return fmt.Sprintf(`{"count": 1, "results": [%s]}`, FloatingIPResponse(portID, metadata))
+}
+
+const EmptyListResponse = `{"count": 0, "results": []}`
+
+const ClaimRequest = `{"gcore_pool_owner": "web-cluster"}`
+
+const AllocateRequest = `{"metadata": {"gcore_pool_owner": "web-cluster"}}`
+
+var AssignRequest = fmt.Sprintf(`{"port_id": "%s"}`, StandbyPortID)
+
+var TaskResponse = fmt.Sprintf(`{"tasks": ["%s"]}`, CreateTaskID)
+
+var FinishedTaskResponse = fmt.Sprintf(`
+{
+  "id": "%s",
+  "state": "FINISHED",
+  "task_type": "create_floatingip",
+  "project_id": 1,
+  "created_on": "2019-06-25T08:42:42",
+  "created_resources": {
+    "floatingips": ["%s"]
+  }
+}
+`, CreateTaskID, FloatingIPID)
+
+// ReservedFixedIPResponse renders a reserved fixed ip with the given vip state.
+func ReservedFixedIPResponse(isVIP bool) string {
+	return fmt.Sprintf(`
+{
+  "port_id": "%s",
+  "name": "Reserved fixed ip 10.100.179.44",
+  "created_at": "2020-09-14T14:45:30+0000",
+  "updated_at": "2020-09-14T14:45:31+0000",
+  "status": "DOWN",
+  "fixed_ip_address": "10.100.179.44",
+  "subnet_id": "747db04a-2aac-4fda-9492-d9b85a798c09",
+  "creator_task_id": "30378aea-9343-4ff6-be38-9756094e05da",
+  "is_external": false,
+  "is_vip": %t,
+  "reservation": {"status": "available"},
+  "region": "ED-10",
+  "region_id": 1,
+  "project_id": 1,
+  "network_id": "eed97610-708d-43a5-a9a5-caebd2b7b4ee"
+}
+`, VIPPortID, isVIP)
+}
+
+const SwitchVIPRequest = `{"is_vip": true}`
+
+var ReplacePortsRequest = fmt.Sprintf(`{"port_ids": ["%s"]}`, StandbyPortID)
+
+var DevicesResponse = fmt.Sprintf(`
+{
+  "count": 1,
+  "results": [
+  {
+    "port_id": "%s",
+    "instance_id": "7c5c4a3c-bf3c-47b0-9b6e-3d3b8a5b4e11",
+    "instance_name": "standby",
+    "ip_assignments": [
+      {
+        "ip_address": "10.100.179.50",
+        "subnet_id": "747db04a-2aac-4fda-9492-d9b85a798c09"
+      }
+    ]
+  }
+  ]
+}
+`, StandbyPortID)
@@ -0,0 +1,277 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/floatingip/v1/pool"
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func floatingIPsURL(parts ...string) string {
+	url := fmt.Sprintf("/v1/floatingips/%d/%d", fake.ProjectID, fake.RegionID)
+	for _, p := range parts {
+		url += "/" + p
+	}
+	return url
+}
+
+func availableFloatingIPsURL() string {
+	return fmt.Sprintf("/v1/availablefloatingips/%d/%d", fake.ProjectID, fake.RegionID)
+}
+
+func reservedFixedIPsURL(parts ...string) string {
+	url := fmt.Sprintf("/v1/reserved_fixed_ips/%d/%d", fake.ProjectID, fake.RegionID)
+	for _, p := range parts {
+		url += "/" + p
+	}
+	return url
+}
+
+func respond(w http.ResponseWriter, body string) {
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, err := fmt.Fprint(w, body)
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+func newPool(t *testing.T) *pool.Pool {
+	p, err := pool.New(
+		fake.ServiceTokenClient("floatingips", "v1"),
+		fake.ServiceTokenClient("availablefloatingips", "v1"),
+		pool.Opts{Owner: Owner, WaitSeconds: 5},
+	)
+	require.NoError(t, err)
+	return p
+}
+
+func TestNewValidation(t *testing.T) {
+	_, err := pool.New(nil, nil, pool.Opts{})
+	require.Error(t, err)
+}
+
+func TestAcquireOwnedFloatingIP(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(floatingIPsURL(), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		require.Equal(t, `{"gcore_pool_owner":"web-cluster"}`, r.URL.Query().Get("metadata_kv"))
+		respond(w, FloatingIPListResponse("", OwnerMetadata))
+	})
+	th.Mux.HandleFunc(floatingIPsURL(FloatingIPID, "assign"), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestJSONRequest(t, r, AssignRequest)
+		respond(w, FloatingIPResponse(StandbyPortID, OwnerMetadata))
+	})
+
+	fip, err := newPool(t).Acquire(StandbyPortID, nil)
+	require.NoError(t, err)
+	require.Equal(t, FloatingIPID, fip.ID)
+	require.Equal(t, StandbyPortID, fip.PortID)
+}
+
+func TestAcquireClaimsAvailableFloatingIP(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	claimed := false
+	th.Mux.HandleFunc(floatingIPsURL(), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		respond(w, EmptyListResponse)
+	})
+	th.Mux.HandleFunc(availableFloatingIPsURL(), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		respond(w, FloatingIPListResponse("", EligibleMetadata))
+	})
+	th.Mux.HandleFunc(floatingIPsURL(FloatingIPID, "metadata"), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestJSONRequest(t, r, ClaimRequest)
+		claimed = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+	th.Mux.HandleFunc(floatingIPsURL(FloatingIPID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		respond(w, FloatingIPResponse("", OwnerMetadata))
+	})
+
+	fip, err := newPool(t).Acquire("", nil)
+	require.NoError(t, err)
+	require.True(t, claimed)
+	require.Equal(t, FloatingIPID, fip.ID)
+}
+
+func TestAcquireSkipsFloatingIPClaimedByAnotherPool(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	allocated := false
+	th.Mux.HandleFunc(floatingIPsURL(), func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			respond(w, EmptyListResponse)
+		case http.MethodPost:
+			allocated = true
+			respond(w, TaskResponse)
+		}
+	})
+	th.Mux.HandleFunc(availableFloatingIPsURL(), func(w http.ResponseWriter, r *http.Request) {
+		respond(w, FloatingIPListResponse("", EligibleMetadata))
+	})
+	th.Mux.HandleFunc(floatingIPsURL(FloatingIPID, "metadata"), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	th.Mux.HandleFunc("/v1/tasks/"+CreateTaskID, func(w http.ResponseWriter, r *http.Request) {
+		respond(w, FinishedTaskResponse)
+	})
+	th.Mux.HandleFunc(floatingIPsURL(FloatingIPID), func(w http.ResponseWriter, r *http.Request) {
+		if allocated {
+			respond(w, FloatingIPResponse("", OwnerMetadata))
+			return
+		}
+		respond(w, FloatingIPResponse("", ForeignMetadata))
+	})
+
+	fip, err := newPool(t).Acquire("", nil)
+	require.NoError(t, err)
+	require.True(t, allocated)
+	require.Equal(t, FloatingIPID, fip.ID)
+}
+
+func TestAcquireAllocatesFloatingIP(t *testing.T) {
+	for _, availableMetadata := range []string{ForeignMetadata, "[]"} {
+		testAcquireAllocatesFloatingIP(t, availableMetadata)
+	}
+}
+
+// testAcquireAllocatesFloatingIP checks an available floating IP with the given metadata is not claimed.
+func testAcquireAllocatesFloatingIP(t *testing.T, availableMetadata string) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(floatingIPsURL(), func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			respond(w, EmptyListResponse)
+		case http.MethodPost:
+			th.TestJSONRequest(t, r, AllocateRequest)
+			respond(w, TaskResponse)
+		}
+	})
+	th.Mux.HandleFunc(availableFloatingIPsURL(), func(w http.ResponseWriter, r *http.Request) {
+		respond(w, FloatingIPListResponse("", availableMetadata))
+	})
+	th.Mux.HandleFunc(floatingIPsURL(FloatingIPID, "metadata"), func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("floating ip with metadata %s must not be claimed", availableMetadata)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	th.Mux.HandleFunc("/v1/tasks/"+CreateTaskID, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		respond(w, FinishedTaskResponse)
+	})
+	th.Mux.HandleFunc(floatingIPsURL(FloatingIPID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		respond(w, FloatingIPResponse("", OwnerMetadata))
+	})
+
+	fip, err := newPool(t).Acquire("", nil)
+	require.NoError(t, err)
+	require.Equal(t, FloatingIPID, fip.ID)
+}
+
+func TestMove(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	unassigned := false
+	th.Mux.HandleFunc(floatingIPsURL(FloatingIPID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		respond(w, FloatingIPResponse(PrimaryPortID, OwnerMetadata))
+	})
+	th.Mux.HandleFunc(floatingIPsURL(FloatingIPID, "unassign"), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		unassigned = true
+		respond(w, FloatingIPResponse("", OwnerMetadata))
+	})
+	th.Mux.HandleFunc(floatingIPsURL(FloatingIPID, "assign"), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		require.True(t, unassigned)
+		th.TestJSONRequest(t, r, AssignRequest)
+		respond(w, FloatingIPResponse(StandbyPortID, OwnerMetadata))
+	})
+
+	fip, err := newPool(t).Move(FloatingIPID, StandbyPortID, nil)
+	require.NoError(t, err)
+	require.Equal(t, StandbyPortID, fip.PortID)
+}
+
+func TestReleaseForeignFloatingIP(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(floatingIPsURL(FloatingIPID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		respond(w, FloatingIPResponse(PrimaryPortID, ForeignMetadata))
+	})
+
+	err := newPool(t).Release(FloatingIPID, false)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is not owned by")
+}
+
+func TestReleaseKeepsFloatingIP(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	unassigned := false
+	th.Mux.HandleFunc(floatingIPsURL(FloatingIPID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		respond(w, FloatingIPResponse(PrimaryPortID, OwnerMetadata))
+	})
+	th.Mux.HandleFunc(floatingIPsURL(FloatingIPID, "unassign"), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		unassigned = true
+		respond(w, FloatingIPResponse("", OwnerMetadata))
+	})
+
+	err := newPool(t).Release(FloatingIPID, false)
+	require.NoError(t, err)
+	require.True(t, unassigned)
+}
+
+func TestFailoverVIP(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(reservedFixedIPsURL(VIPPortID), func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			respond(w, ReservedFixedIPResponse(false))
+		case http.MethodPatch:
+			th.TestJSONRequest(t, r, SwitchVIPRequest)
+			respond(w, ReservedFixedIPResponse(true))
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+	th.Mux.HandleFunc(reservedFixedIPsURL(VIPPortID, "connected_devices"), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PUT")
+		th.TestJSONRequest(t, r, ReplacePortsRequest)
+		respond(w, DevicesResponse)
+	})
+
+	client := fake.ServiceTokenClient("reserved_fixed_ips", "v1")
+	devices, err := pool.FailoverVIP(client, pool.VIPFailoverOpts{
+		VIPPortID:     VIPPortID,
+		ActivePortIDs: []string{StandbyPortID},
+	})
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+	require.Equal(t, StandbyPortID, devices[0].PortID)
+}
@@ -0,0 +1,42 @@
+package pool
+
+import (
+	"fmt"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/reservedfixedip/v1/reservedfixedips"
+)
+
+// VIPFailoverOpts represents options used to fail over a VIP to standby ports.
+type VIPFailoverOpts struct {
+	// VIPPortID is the port ID of the reserved fixed IP used as a VIP.
+	VIPPortID string `validate:"required"`
+	// ActivePortIDs are the instance ports allowed to hold the VIP after the failover.
+	ActivePortIDs []string `validate:"required,min=1,dive,required"`
+}
+
+// FailoverVIP moves a VIP to the given ports. The reserved fixed IP is switched to VIP mode
+// with SwitchVIP when needed, then the ports sharing it are replaced with the active ones,
+// so the failed primary stops holding the address. A floating IP assigned to the VIP port follows it without changes.
+// The client must be a reservedfixedips v1 client.
+func FailoverVIP(c *gcorecloud.ServiceClient, opts VIPFailoverOpts) ([]reservedfixedips.Device, error) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	vip, err := reservedfixedips.Get(c, opts.VIPPortID).Extract()
+	if err != nil {
+		return nil, err
+	}
+	if !vip.IsVip {
+		vip, err = reservedfixedips.SwitchVIP(c, opts.VIPPortID, reservedfixedips.SwitchVIPOpts{IsVip: true}).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("cannot switch %s to vip: %w", opts.VIPPortID, err)
+		}
+		if !vip.IsVip {
+			return nil, fmt.Errorf("reserved fixed ip %s is not a vip after switch", opts.VIPPortID)
+		}
+	}
+	return reservedfixedips.ReplacePortsToShareVIP(c, opts.VIPPortID, reservedfixedips.PortsToShareVIPOpts{
+		PortIDs: opts.ActivePortIDs,
+	}).Extract()
+}
@@ -0,0 +1,29 @@
+/*
+Package provision creates baremetal GPU clusters in the first of several acceptable regions and flavors with capacity for them
+
+Every candidate is checked for the flavor, the available nodes and the regional GPU server quota before the cluster
+is created. A candidate failing on capacity during creation is cleaned up and the next one is tried.
+
+Example to provision a cluster in Luxembourg or Manassas
+
+	result, err := provision.Provision(provision.Clients{
+		GPU: func(regionID int) (*gcorecloud.ServiceClient, error) {
+			return gcore.ClientServiceFromProvider(provider, gcorecloud.EndpointOpts{
+				Name: "gpu/baremetal", Region: regionID, Project: projectID, Version: "v3",
+			})
+		},
+		Tasks: taskClient,
+	}, provision.Opts{
+		Name:         "training",
+		ServersCount: 4,
+		Candidates: []provision.Candidate{
+			{RegionID: 76, Flavor: "bm3-ai-1xlarge-h100-80-8", ImageID: "3793c250-0b3b-4678-bab3-e11afbc29657"},
+			{RegionID: 80, Flavor: "bm3-ai-1xlarge-h100-80-8", ImageID: "7c8d7e4e-8ff2-4e3b-9d6e-7c5e2c5a4c11"},
+		},
+		ServersSettings: serversSettings,
+	})
+	if err != nil {
+		panic(err)
+	}
+*/
+package provision
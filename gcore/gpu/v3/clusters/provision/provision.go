@@ -0,0 +1,295 @@
+package provision
+
+import (
+	"fmt"
+	"strings"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/baremetal/v1/bmcapacity"
+	"github.com/G-Core/gcorelabscloud-go/gcore/gpu/v3/clusters"
+	"github.com/G-Core/gcorelabscloud-go/gcore/gpu/v3/flavors"
+	"github.com/G-Core/gcorelabscloud-go/gcore/quota/v2/quotas"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+)
+
+const (
+	// DefaultWaitSeconds is how long a cluster creation is waited for.
+	DefaultWaitSeconds = 3600
+	// QuotaName is the regional quota of baremetal GPU servers.
+	QuotaName = quotas.GPUServerQuotaName
+)
+
+// capacityErrors are messages of failures caused by a lack of nodes.
+var capacityErrors = []string{"capacity", "no valid host", "not enough", "insufficient", "no available"}
+
+// ClientFactory returns a service client of a region.
+type ClientFactory func(regionID int) (*gcorecloud.ServiceClient, error)
+
+// Clients groups service clients used for provisioning.
+type Clients struct {
+	// GPU returns GPU baremetal v3 clients.
+	GPU ClientFactory `validate:"required"`
+	// Capacity returns bmcapacity v1 clients. The flavor capacity is used without it.
+	Capacity ClientFactory
+	// Tasks is a tasks v1 client.
+	Tasks *gcorecloud.ServiceClient `validate:"required"`
+	// Quotas is a quotas v2 client. Quotas are not checked without it.
+	Quotas *gcorecloud.ServiceClient
+	// ClientID is the account the regional quotas are read for.
+	ClientID int `validate:"required_with=Quotas"`
+}
+
+// Candidate is an acceptable region and flavor. Images and networks are regional, so they can be set per candidate.
+type Candidate struct {
+	RegionID int    `json:"region_id" validate:"required"`
+	Flavor   string `json:"flavor" validate:"required"`
+	// ImageID defaults to Opts.ImageID.
+	ImageID string `json:"image_id,omitempty"`
+	// ServersSettings defaults to Opts.ServersSettings.
+	ServersSettings *clusters.BaremetalServerSettingsOpts `json:"-"`
+}
+
+// Opts represents options used to provision a cluster.
+type Opts struct {
+	Name            string `validate:"required"`
+	ImageID         string
+	ServersCount    int `validate:"required,gt=0"`
+	Tags            map[string]string
+	ServersSettings clusters.BaremetalServerSettingsOpts
+	// Candidates are tried in order.
+	Candidates  []Candidate `validate:"required,min=1,dive"`
+	WaitSeconds int         `validate:"omitempty,gt=0"`
+}
+
+// Attempt describes a candidate which was checked.
+type Attempt struct {
+	RegionID int    `json:"region_id"`
+	Flavor   string `json:"flavor"`
+	// Available is the number of nodes available, -1 when unknown.
+	Available int `json:"available"`
+	// Reason is why the candidate was not used.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Result describes a provisioned cluster.
+type Result struct {
+	RegionID int               `json:"region_id"`
+	Flavor   string            `json:"flavor"`
+	Cluster  *clusters.Cluster `json:"cluster"`
+	// Attempts are the candidates checked, the last one is the one used.
+	Attempts []Attempt `json:"attempts"`
+}
+
+// NoCapacityError is returned when no candidate could hold the cluster.
+type NoCapacityError struct {
+	Attempts []Attempt
+}
+
+func (e NoCapacityError) Error() string {
+	reasons := make([]string, 0, len(e.Attempts))
+	for _, a := range e.Attempts {
+		reasons = append(reasons, fmt.Sprintf("region %d flavor %s: %s", a.RegionID, a.Flavor, a.Reason))
+	}
+	return fmt.Sprintf("no capacity for the cluster: %s", strings.Join(reasons, "; "))
+}
+
+// IsCapacityError returns whether a cluster creation failed because of a lack of nodes.
+func IsCapacityError(err error) bool {
+	if err == nil {
+		return false
+	}
+	message := strings.ToLower(err.Error())
+	for _, s := range capacityErrors {
+		if strings.Contains(message, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns whether a candidate has the flavor enabled, enough available nodes and enough regional quota
+// for servers. The returned attempt holds the reason a candidate does not fit.
+func Check(clients Clients, candidate Candidate, servers int) (Attempt, error) {
+	attempt := Attempt{RegionID: candidate.RegionID, Flavor: candidate.Flavor, Available: -1}
+	gpu, err := clients.GPU(candidate.RegionID)
+	if err != nil {
+		return attempt, err
+	}
+	pages, err := flavors.ListBaremetal(gpu, nil).AllPages()
+	if err != nil {
+		return attempt, fmt.Errorf("cannot list flavors in region %d: %w", candidate.RegionID, err)
+	}
+	flavorList, err := flavors.ExtractBMFlavors(pages)
+	if err != nil {
+		return attempt, err
+	}
+	var flavor *flavors.BMFlavor
+	for i := range flavorList {
+		if flavorList[i].Name == candidate.Flavor || flavorList[i].ID == candidate.Flavor {
+			flavor = &flavorList[i]
+			break
+		}
+	}
+	switch {
+	case flavor == nil:
+		attempt.Reason = "flavor does not exist"
+		return attempt, nil
+	case flavor.Disabled:
+		attempt.Reason = "flavor is disabled"
+		return attempt, nil
+	}
+
+	attempt.Available = flavor.Capacity
+	if clients.Capacity != nil {
+		c, err := clients.Capacity(candidate.RegionID)
+		if err != nil {
+			return attempt, err
+		}
+		nodes, err := bmcapacity.GetAvailableNodes(c).Extract()
+		if err != nil {
+			return attempt, fmt.Errorf("cannot get available nodes in region %d: %w", candidate.RegionID, err)
+		}
+		attempt.Available = nodes.Capacity[flavor.Name]
+	}
+	if attempt.Available < servers {
+		attempt.Reason = fmt.Sprintf("%d nodes available, %d required", attempt.Available, servers)
+		return attempt, nil
+	}
+
+	if clients.Quotas != nil {
+		quota, err := quotas.ListRegional(clients.Quotas, clients.ClientID, candidate.RegionID).Extract()
+		if err != nil {
+			return attempt, fmt.Errorf("cannot get quotas of region %d: %w", candidate.RegionID, err)
+		}
+		for _, name := range quotaNames(flavor) {
+			limit, ok := (*quota)[name+"_limit"]
+			if !ok {
+				continue
+			}
+			if free := limit - (*quota)[name+"_usage"]; free < servers {
+				attempt.Reason = fmt.Sprintf("quota %s allows %d more servers, %d required", name, free, servers)
+				return attempt, nil
+			}
+		}
+	}
+	return attempt, nil
+}
+
+// quotaNames returns the general GPU server quota and the quota of the flavor GPU model, e.g. baremetal_gpu_h100_count.
+func quotaNames(flavor *flavors.BMFlavor) []string {
+	names := []string{QuotaName}
+	if p := flavor.HardwareProperties; p != nil && p.GPUModel != nil && *p.GPUModel != "" {
+		names = append(names, quotas.GPUModelQuotaName(*p.GPUModel))
+	}
+	return names
+}
+
+// Provision creates the cluster with the first candidate passing Check. A candidate failing on capacity during
+// the creation is deleted and the next candidate is tried. Any other failure stops the provisioning.
+func Provision(clients Clients, opts Opts) (*Result, error) {
+	if err := gcorecloud.ValidateStruct(clients); err != nil {
+		return nil, err
+	}
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	waitSeconds := opts.WaitSeconds
+	if waitSeconds == 0 {
+		waitSeconds = DefaultWaitSeconds
+	}
+
+	var attempts []Attempt
+	for _, candidate := range opts.Candidates {
+		attempt, err := Check(clients, candidate, opts.ServersCount)
+		if err != nil {
+			return nil, err
+		}
+		if attempt.Reason == "" {
+			var cluster *clusters.Cluster
+			cluster, err = create(clients, candidate, opts, waitSeconds)
+			if err == nil {
+				return &Result{
+					RegionID: candidate.RegionID,
+					Flavor:   candidate.Flavor,
+					Cluster:  cluster,
+					Attempts: append(attempts, attempt),
+				}, nil
+			}
+			if !IsCapacityError(err) {
+				return nil, err
+			}
+			attempt.Reason = err.Error()
+		}
+		attempts = append(attempts, attempt)
+	}
+	return nil, NoCapacityError{Attempts: attempts}
+}
+
+func create(clients Clients, candidate Candidate, opts Opts, waitSeconds int) (*clusters.Cluster, error) {
+	gpu, err := clients.GPU(candidate.RegionID)
+	if err != nil {
+		return nil, err
+	}
+	createOpts := clusters.CreateBaremetalClusterOpts{
+		Name:            opts.Name,
+		Flavor:          candidate.Flavor,
+		ImageID:         opts.ImageID,
+		Tags:            opts.Tags,
+		ServersCount:    opts.ServersCount,
+		ServersSettings: opts.ServersSettings,
+	}
+	if candidate.ImageID != "" {
+		createOpts.ImageID = candidate.ImageID
+	}
+	if candidate.ServersSettings != nil {
+		createOpts.ServersSettings = *candidate.ServersSettings
+	}
+	results, err := clusters.Create(gpu, createOpts).Extract()
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Tasks) == 0 {
+		return nil, fmt.Errorf("wrong task response")
+	}
+	task := results.Tasks[0]
+	clusterID, err := tasks.WaitTaskAndReturnResult(clients.Tasks, task, true, waitSeconds, func(task tasks.TaskID) (interface{}, error) {
+		return clusterIDFromTask(clients.Tasks, task)
+	})
+	if err != nil {
+		if IsCapacityError(err) {
+			if cleanupErr := deleteFailed(clients.Tasks, gpu, task, waitSeconds); cleanupErr != nil {
+				return nil, fmt.Errorf("%s, cannot delete failed cluster: %w", err, cleanupErr)
+			}
+		}
+		return nil, err
+	}
+	return clusters.Get(gpu, clusterID.(string)).Extract()
+}
+
+func clusterIDFromTask(c *gcorecloud.ServiceClient, task tasks.TaskID) (string, error) {
+	taskInfo, err := tasks.Get(c, string(task)).Extract()
+	if err != nil {
+		return "", fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
+	}
+	return clusters.ExtractGPUClusterIDFromTask(taskInfo)
+}
+
+// deleteFailed deletes the cluster a failed creation task left behind, if any.
+func deleteFailed(taskClient, gpu *gcorecloud.ServiceClient, task tasks.TaskID, waitSeconds int) error {
+	clusterID, err := clusterIDFromTask(taskClient, task)
+	if err != nil || clusterID == "" {
+		return err
+	}
+	results, err := clusters.Delete(gpu, clusterID, nil).Extract()
+	switch err.(type) {
+	case nil:
+	case gcorecloud.ErrDefault404:
+		return nil
+	default:
+		return err
+	}
+	if len(results.Tasks) == 0 {
+		return nil
+	}
+	return tasks.WaitForFinishedTask(taskClient, results.Tasks[0], waitSeconds)
+}
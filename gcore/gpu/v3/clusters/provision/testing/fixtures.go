@@ -0,0 +1,111 @@
+package testing
+
+import "fmt"
+
+const (
+	Flavor    = "bm3-ai-1xlarge-h100-80-8"
+	ClusterID = "1aaaab48-10d0-46d9-80cc-85209284ceb4"
+	TaskID    = "50f53a35-42ed-40c4-82b2-5a37fb3e00bc"
+	ImageID   = "3793c250-0b3b-4678-bab3-e11afbc29657"
+)
+
+// FlavorsResponse lists the flavor with the given capacity.
+func FlavorsResponse(capacity int) string {
+	return fmt.Sprintf(`
+{
+  "count": 1,
+  "results": [
+    {
+      "id": "%[1]s",
+      "name": "%[1]s",
+      "cpu": "2 x Intel Xeon 8480+",
+      "ram": 2097152,
+      "disk": "8 x 3.84 TB NVMe",
+      "network": "2 x 100 Gbit/s",
+      "gpu": "8 x NVIDIA H100 80GB",
+      "disabled": false,
+      "capacity": %[2]d,
+      "hardware_properties": {
+        "gpu_model": "H100",
+        "gpu_manufacturer": "NVIDIA",
+        "gpu_count": 8
+      }
+    }
+  ]
+}
+`, Flavor, capacity)
+}
+
+const QuotaExceededResponse = `
+{
+  "region_id": 2,
+  "baremetal_gpu_count_limit": 10,
+  "baremetal_gpu_count_usage": 2,
+  "baremetal_gpu_h100_count_limit": 2,
+  "baremetal_gpu_h100_count_usage": 1
+}
+`
+
+const QuotaResponse = `
+{
+  "region_id": 3,
+  "baremetal_gpu_count_limit": 10,
+  "baremetal_gpu_count_usage": 2
+}
+`
+
+const CreateRequest = `
+{
+  "name": "training",
+  "flavor": "bm3-ai-1xlarge-h100-80-8",
+  "image_id": "3793c250-0b3b-4678-bab3-e11afbc29657",
+  "servers_count": 2,
+  "servers_settings": {
+    "interfaces": [{"type": "external"}],
+    "security_groups": null
+  }
+}
+`
+
+const TasksResponse = `
+{
+  "tasks": [
+    "50f53a35-42ed-40c4-82b2-5a37fb3e00bc"
+  ]
+}
+`
+
+// TaskResponse renders a cluster creation task in the given state.
+func TaskResponse(state, taskError string) string {
+	errorValue := "null"
+	if taskError != "" {
+		errorValue = fmt.Sprintf("%q", taskError)
+	}
+	return fmt.Sprintf(`
+{
+  "id": "%s",
+  "task_type": "create_gpu_baremetal_cluster",
+  "state": "%s",
+  "error": %s,
+  "client_id": 2,
+  "project_id": 1,
+  "created_on": "2025-06-25T08:42:42",
+  "data": {
+    "cluster_id": "%s"
+  }
+}
+`, TaskID, state, errorValue, ClusterID)
+}
+
+const ClusterResponse = `
+{
+  "id": "1aaaab48-10d0-46d9-80cc-85209284ceb4",
+  "name": "training",
+  "status": "active",
+  "flavor": "bm3-ai-1xlarge-h100-80-8",
+  "image_id": "3793c250-0b3b-4678-bab3-e11afbc29657",
+  "servers_count": 2,
+  "created_at": "2025-06-25T08:42:42Z",
+  "tags": []
+}
+`
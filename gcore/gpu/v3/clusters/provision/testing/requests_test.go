@@ -0,0 +1,125 @@
+package testing
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore"
+	"github.com/G-Core/gcorelabscloud-go/gcore/gpu/v3/clusters"
+	"github.com/G-Core/gcorelabscloud-go/gcore/gpu/v3/clusters/provision"
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+)
+
+func gpuURL(regionID int, parts string) string {
+	return fmt.Sprintf("/v3/gpu/baremetal/%d/%d/%s", fake.ProjectID, regionID, parts)
+}
+
+func respond(t *testing.T, method, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, method)
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, body)
+	}
+}
+
+func testClients(quotas bool) provision.Clients {
+	taskClient := fake.ServiceTokenClient("tasks", "v1")
+	clients := provision.Clients{
+		GPU: func(regionID int) (*gcorecloud.ServiceClient, error) {
+			return gcore.ClientServiceFromProvider(taskClient.ProviderClient, gcorecloud.EndpointOpts{
+				Name:    "gpu/baremetal",
+				Region:  regionID,
+				Project: fake.ProjectID,
+				Version: "v3",
+			})
+		},
+		Tasks: taskClient,
+	}
+	if quotas {
+		clients.Quotas = fake.ServiceTokenClient("", "v2")
+		clients.ClientID = 2
+	}
+	return clients
+}
+
+func testOpts(regions ...int) provision.Opts {
+	opts := provision.Opts{
+		Name:         "training",
+		ImageID:      ImageID,
+		ServersCount: 2,
+		ServersSettings: clusters.BaremetalServerSettingsOpts{
+			Interfaces: []clusters.InterfaceOpts{clusters.ExternalInterfaceOpts{Type: "external"}},
+		},
+		WaitSeconds: 5,
+	}
+	for _, regionID := range regions {
+		opts.Candidates = append(opts.Candidates, provision.Candidate{RegionID: regionID, Flavor: Flavor})
+	}
+	return opts
+}
+
+func TestProvisionFallback(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(gpuURL(1, "flavors"), respond(t, "GET", FlavorsResponse(1)))
+	th.Mux.HandleFunc(gpuURL(2, "flavors"), respond(t, "GET", FlavorsResponse(4)))
+	th.Mux.HandleFunc(gpuURL(3, "flavors"), respond(t, "GET", FlavorsResponse(4)))
+	th.Mux.HandleFunc("/v2/regional_quotas/2/2", respond(t, "GET", QuotaExceededResponse))
+	th.Mux.HandleFunc("/v2/regional_quotas/2/3", respond(t, "GET", QuotaResponse))
+	th.Mux.HandleFunc(gpuURL(3, "clusters"), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestJSONRequest(t, r, CreateRequest)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(w, TasksResponse)
+	})
+	th.Mux.HandleFunc("/v1/tasks/"+TaskID, respond(t, "GET", TaskResponse("FINISHED", "")))
+	th.Mux.HandleFunc(gpuURL(3, "clusters/"+ClusterID), respond(t, "GET", ClusterResponse))
+
+	result, err := provision.Provision(testClients(true), testOpts(1, 2, 3))
+	require.NoError(t, err)
+	require.Equal(t, 3, result.RegionID)
+	require.Equal(t, ClusterID, result.Cluster.ID)
+	require.Len(t, result.Attempts, 3)
+	require.Equal(t, "1 nodes available, 2 required", result.Attempts[0].Reason)
+	require.Equal(t, "quota baremetal_gpu_h100_count allows 1 more servers, 2 required", result.Attempts[1].Reason)
+	require.Empty(t, result.Attempts[2].Reason)
+}
+
+func TestProvisionCapacityFailure(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	deleted := false
+	th.Mux.HandleFunc(gpuURL(1, "flavors"), respond(t, "GET", FlavorsResponse(4)))
+	th.Mux.HandleFunc(gpuURL(1, "clusters"), respond(t, "POST", TasksResponse))
+	th.Mux.HandleFunc("/v1/tasks/"+TaskID, respond(t, "GET", TaskResponse("ERROR", "No valid host was found")))
+	th.Mux.HandleFunc(gpuURL(1, "clusters/"+ClusterID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		deleted = true
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := provision.Provision(testClients(false), testOpts(1))
+	require.Error(t, err)
+	var noCapacity provision.NoCapacityError
+	require.True(t, errors.As(err, &noCapacity))
+	require.Len(t, noCapacity.Attempts, 1)
+	require.Contains(t, noCapacity.Attempts[0].Reason, "No valid host")
+	require.True(t, deleted)
+}
+
+func TestIsCapacityError(t *testing.T) {
+	require.True(t, provision.IsCapacityError(fmt.Errorf("task is in error state: ERROR. Error: Not enough baremetal nodes")))
+	require.False(t, provision.IsCapacityError(fmt.Errorf("invalid image")))
+	require.False(t, provision.IsCapacityError(nil))
+}
@@ -0,0 +1,24 @@
+/*
+Package supervisor watches the servers of a GPU cluster and repairs broken ones
+
+A server in ERROR, or not ACTIVE for longer than a grace period, is rebuilt. When the rebuild fails, or the server
+breaks again after its rebuilds, it is deleted and the cluster is resized back to its servers count.
+At most MaxConcurrentRepairs servers are repaired at a time.
+
+Example to supervise a cluster until the context is canceled
+
+	s, err := supervisor.New(supervisor.Clients{GPU: gpuClient, Tasks: taskClient}, supervisor.Opts{
+		ClusterID: "1aaaab48-10d0-46d9-80cc-85209284ceb4",
+		OnEvent: func(e supervisor.Event) {
+			log.Printf("%s %s %s: %v", e.Type, e.ServerName, e.Status, e.Err)
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	if err := s.Run(ctx); err != nil {
+		panic(err)
+	}
+*/
+package supervisor
@@ -0,0 +1,379 @@
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/gpu/v3/clusters"
+	"github.com/G-Core/gcorelabscloud-go/gcore/gpu/v3/servers"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+)
+
+const (
+	// StatusActive is the status of a healthy server.
+	StatusActive = "ACTIVE"
+	// StatusError is the status of a failed server.
+	StatusError = "ERROR"
+
+	// DefaultInterval is how often the servers are checked.
+	DefaultInterval = time.Minute
+	// DefaultNotActiveGracePeriod is how long a server may be neither ACTIVE nor ERROR before it is repaired.
+	DefaultNotActiveGracePeriod = 30 * time.Minute
+	// DefaultMaxConcurrentRepairs is how many servers are repaired at a time.
+	DefaultMaxConcurrentRepairs = 1
+	// DefaultMaxRebuilds is how many times a server is rebuilt before it is replaced.
+	DefaultMaxRebuilds = 1
+	// DefaultWaitSeconds is how long every repair task is waited for.
+	DefaultWaitSeconds = 3600
+)
+
+// DefaultIgnoreStatuses are statuses of servers stopped on purpose.
+var DefaultIgnoreStatuses = []string{"SHUTOFF", "SUSPENDED", "PAUSED"}
+
+// EventType is the kind of an Event.
+type EventType string
+
+const (
+	// EventUnhealthy is reported when a server is flagged for repair.
+	EventUnhealthy EventType = "unhealthy"
+	// EventDeferred is reported when a flagged server waits for the repair budget.
+	EventDeferred EventType = "deferred"
+	// EventRebuild is reported when a rebuild starts.
+	EventRebuild EventType = "rebuild"
+	// EventReplace is reported when a server is deleted to be replaced by a resize.
+	EventReplace EventType = "replace"
+	// EventRepaired is reported when a repair finished.
+	EventRepaired EventType = "repaired"
+	// EventFailed is reported when a repair or a check failed.
+	EventFailed EventType = "failed"
+)
+
+// Event describes something the supervisor found or did.
+type Event struct {
+	Time       time.Time `json:"time"`
+	Type       EventType `json:"type"`
+	ClusterID  string    `json:"cluster_id"`
+	ServerID   string    `json:"server_id,omitempty"`
+	ServerName string    `json:"server_name,omitempty"`
+	Status     string    `json:"status,omitempty"`
+	Err        error     `json:"-"`
+}
+
+// Clients groups service clients used by a supervisor.
+type Clients struct {
+	// GPU is a GPU baremetal or virtual v3 client of the cluster region.
+	GPU *gcorecloud.ServiceClient `validate:"required"`
+	// Tasks is a tasks v1 client.
+	Tasks *gcorecloud.ServiceClient `validate:"required"`
+}
+
+// Opts represents options of a supervisor.
+type Opts struct {
+	ClusterID string `validate:"required"`
+	// ServersCount is the count a replacing resize restores. Defaults to the cluster servers count on the first check.
+	ServersCount int `validate:"omitempty,gt=0"`
+	// Interval defaults to DefaultInterval.
+	Interval time.Duration `validate:"omitempty,gt=0"`
+	// ErrorGracePeriod is how long a server may be in ERROR before it is repaired, zero repairs it at once.
+	ErrorGracePeriod time.Duration `validate:"omitempty,gt=0"`
+	// NotActiveGracePeriod defaults to DefaultNotActiveGracePeriod.
+	NotActiveGracePeriod time.Duration `validate:"omitempty,gt=0"`
+	// IgnoreStatuses are never repaired. Defaults to DefaultIgnoreStatuses.
+	IgnoreStatuses []string
+	// MaxConcurrentRepairs defaults to DefaultMaxConcurrentRepairs.
+	MaxConcurrentRepairs int `validate:"omitempty,gt=0"`
+	// MaxRebuilds defaults to DefaultMaxRebuilds.
+	MaxRebuilds int `validate:"omitempty,gt=0"`
+	// DisableReplace only rebuilds servers, they are never deleted.
+	DisableReplace bool
+	WaitSeconds    int `validate:"omitempty,gt=0"`
+	// OnEvent is called for every event, possibly from several goroutines at once.
+	// It is never called with the supervisor state locked, so it may call the supervisor.
+	OnEvent func(Event)
+}
+
+type serverState struct {
+	unhealthySince time.Time
+	rebuilds       int
+	repairing      bool
+}
+
+// Supervisor checks the servers of a cluster and repairs the broken ones.
+type Supervisor struct {
+	clients Clients
+	opts    Opts
+	budget  chan struct{}
+	wg      sync.WaitGroup
+
+	// mu guards the servers state and opts.ServersCount, which the first check sets.
+	mu      sync.Mutex
+	servers map[string]*serverState
+
+	// resizeMu serializes replacing resizes, so they do not race on the servers count.
+	resizeMu sync.Mutex
+}
+
+type repairJob struct {
+	server  servers.Server
+	replace bool
+}
+
+// New creates a Supervisor.
+func New(clients Clients, opts Opts) (*Supervisor, error) {
+	if err := gcorecloud.ValidateStruct(clients); err != nil {
+		return nil, err
+	}
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	if opts.Interval == 0 {
+		opts.Interval = DefaultInterval
+	}
+	if opts.NotActiveGracePeriod == 0 {
+		opts.NotActiveGracePeriod = DefaultNotActiveGracePeriod
+	}
+	if opts.IgnoreStatuses == nil {
+		opts.IgnoreStatuses = DefaultIgnoreStatuses
+	}
+	if opts.MaxConcurrentRepairs == 0 {
+		opts.MaxConcurrentRepairs = DefaultMaxConcurrentRepairs
+	}
+	if opts.MaxRebuilds == 0 {
+		opts.MaxRebuilds = DefaultMaxRebuilds
+	}
+	if opts.WaitSeconds == 0 {
+		opts.WaitSeconds = DefaultWaitSeconds
+	}
+	return &Supervisor{
+		clients: clients,
+		opts:    opts,
+		budget:  make(chan struct{}, opts.MaxConcurrentRepairs),
+		servers: map[string]*serverState{},
+	}, nil
+}
+
+// Run checks the cluster every interval until the context is done, then waits for the running repairs.
+// Failed checks are reported as events and do not stop it.
+func (s *Supervisor) Run(ctx context.Context) error {
+	defer s.Wait()
+	ticker := time.NewTicker(s.opts.Interval)
+	defer ticker.Stop()
+	for {
+		if err := s.Check(ctx); err != nil {
+			s.report(Event{Type: EventFailed, Err: err})
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Wait waits for the running repairs.
+func (s *Supervisor) Wait() {
+	s.wg.Wait()
+}
+
+// Check lists the cluster servers once and starts repairs of the unhealthy ones within the budget.
+// It does not wait for the repairs.
+func (s *Supervisor) Check(ctx context.Context) error {
+	list, err := servers.ListAll(s.clients.GPU, s.opts.ClusterID, nil)
+	if err != nil {
+		return fmt.Errorf("cannot list servers of cluster %s: %w", s.opts.ClusterID, err)
+	}
+	if s.serversCount() == 0 && !s.opts.DisableReplace {
+		cluster, err := clusters.Get(s.clients.GPU, s.opts.ClusterID).Extract()
+		if err != nil {
+			return fmt.Errorf("cannot get cluster %s: %w", s.opts.ClusterID, err)
+		}
+		s.mu.Lock()
+		if s.opts.ServersCount == 0 {
+			s.opts.ServersCount = cluster.ServersCount
+		}
+		s.mu.Unlock()
+	}
+
+	events, jobs := s.plan(list, time.Now())
+	for _, event := range events {
+		s.report(event)
+	}
+	for _, job := range jobs {
+		s.wg.Add(1)
+		go s.repair(ctx, job.server, job.replace)
+	}
+	return nil
+}
+
+// plan updates the servers state and returns the events to report and the repairs to start.
+func (s *Supervisor) plan(list []servers.Server, now time.Time) ([]Event, []repairJob) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var events []Event
+	var jobs []repairJob
+	seen := make(map[string]bool, len(list))
+	for _, server := range list {
+		seen[server.ID] = true
+		state := s.servers[server.ID]
+		if state == nil {
+			state = &serverState{}
+			s.servers[server.ID] = state
+		}
+		if state.repairing {
+			continue
+		}
+		if s.healthy(server) {
+			state.unhealthySince = time.Time{}
+			continue
+		}
+		if state.unhealthySince.IsZero() {
+			state.unhealthySince = now
+			// A server already broken before the supervisor started is not given a new grace period.
+			if !server.UpdatedAt.IsZero() && server.UpdatedAt.Before(now) {
+				state.unhealthySince = server.UpdatedAt
+			}
+		}
+		grace := s.opts.NotActiveGracePeriod
+		if strings.EqualFold(server.Status, StatusError) {
+			grace = s.opts.ErrorGracePeriod
+		}
+		if now.Sub(state.unhealthySince) < grace {
+			continue
+		}
+
+		events = append(events, s.event(EventUnhealthy, server))
+		select {
+		case s.budget <- struct{}{}:
+		default:
+			events = append(events, s.event(EventDeferred, server))
+			continue
+		}
+		state.repairing = true
+		replace := state.rebuilds >= s.opts.MaxRebuilds && !s.opts.DisableReplace
+		if !replace {
+			state.rebuilds++
+		}
+		jobs = append(jobs, repairJob{server: server, replace: replace})
+	}
+	for id := range s.servers {
+		if !seen[id] {
+			delete(s.servers, id)
+		}
+	}
+	return events, jobs
+}
+
+func (s *Supervisor) healthy(server servers.Server) bool {
+	if strings.EqualFold(server.Status, StatusActive) {
+		return true
+	}
+	for _, status := range s.opts.IgnoreStatuses {
+		if strings.EqualFold(server.Status, status) {
+			return true
+		}
+	}
+	return false
+}
+
+// repair rebuilds a server, or replaces it when replace is set or the rebuild fails.
+func (s *Supervisor) repair(ctx context.Context, server servers.Server, replace bool) {
+	defer s.wg.Done()
+	defer func() { <-s.budget }()
+
+	var err error
+	if !replace {
+		s.report(s.event(EventRebuild, server))
+		err = s.wait(servers.Rebuild(s.clients.GPU, s.opts.ClusterID, server.ID))
+		if err != nil && !s.opts.DisableReplace && ctx.Err() == nil {
+			s.report(s.failed(server, fmt.Errorf("rebuild failed: %w", err)))
+			replace = true
+		}
+	}
+	if replace {
+		s.report(s.event(EventReplace, server))
+		err = s.replace(server)
+	}
+
+	s.mu.Lock()
+	if state := s.servers[server.ID]; state != nil {
+		state.repairing = false
+		state.unhealthySince = time.Time{}
+		if err == nil {
+			state.rebuilds = 0
+		}
+	}
+	s.mu.Unlock()
+
+	if err != nil {
+		s.report(s.failed(server, err))
+		return
+	}
+	s.report(s.event(EventRepaired, server))
+}
+
+// replace deletes a server and resizes the cluster back to the servers count.
+// Resizes are serialized and skipped when another replacement already restored the count.
+func (s *Supervisor) replace(server servers.Server) error {
+	err := s.wait(servers.Delete(s.clients.GPU, s.opts.ClusterID, server.ID, nil))
+	switch err.(type) {
+	case nil, gcorecloud.ErrDefault404:
+	default:
+		return fmt.Errorf("cannot delete server: %w", err)
+	}
+
+	s.resizeMu.Lock()
+	defer s.resizeMu.Unlock()
+	cluster, err := clusters.Get(s.clients.GPU, s.opts.ClusterID).Extract()
+	if err != nil {
+		return fmt.Errorf("cannot get cluster %s: %w", s.opts.ClusterID, err)
+	}
+	count := s.serversCount()
+	if cluster.ServersCount >= count {
+		return nil
+	}
+	if err := s.wait(clusters.Resize(s.clients.GPU, s.opts.ClusterID, count)); err != nil {
+		return fmt.Errorf("cannot resize cluster to %d servers: %w", count, err)
+	}
+	return nil
+}
+
+// serversCount returns the count a replacing resize restores, which the first check may set.
+func (s *Supervisor) serversCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.opts.ServersCount
+}
+
+func (s *Supervisor) wait(r tasks.Result) error {
+	results, err := r.Extract()
+	if err != nil {
+		return err
+	}
+	if len(results.Tasks) == 0 {
+		return fmt.Errorf("wrong task response")
+	}
+	return tasks.WaitForFinishedTask(s.clients.Tasks, results.Tasks[0], s.opts.WaitSeconds)
+}
+
+func (s *Supervisor) event(t EventType, server servers.Server) Event {
+	return Event{Type: t, ServerID: server.ID, ServerName: server.Name, Status: server.Status}
+}
+
+func (s *Supervisor) failed(server servers.Server, err error) Event {
+	e := s.event(EventFailed, server)
+	e.Err = err
+	return e
+}
+
+func (s *Supervisor) report(e Event) {
+	if s.opts.OnEvent == nil {
+		return
+	}
+	e.Time = time.Now()
+	e.ClusterID = s.opts.ClusterID
+	s.opts.OnEvent(e)
+}
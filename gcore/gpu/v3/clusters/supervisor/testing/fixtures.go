@@ -0,0 +1,79 @@
+package testing
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	ClusterID        = "1aaaab48-10d0-46d9-80cc-85209284ceb4"
+	RebuildTaskID    = "50f53a35-42ed-40c4-82b2-5a37fb3e00bc"
+	DeleteTaskID     = "6a3c1f1e-3f7d-4b9e-8c4c-0b5f7e3f2a11"
+	ResizeTaskID     = "7b4d2a2f-4a8e-4c0f-9d5d-1c6a8f4e3b22"
+	BrokenServerID   = "d5a9a3b4-6f2e-4c8a-9b1d-2e3f4a5b6c7d"
+	DeferredServerID = "e6b0b4c5-7a3f-4d9b-8c2e-3f4a5b6c7d8e"
+)
+
+// Server renders a cluster server.
+func Server(id, name, status, updatedAt string) string {
+	return fmt.Sprintf(`
+    {
+      "id": "%s",
+      "name": "%s",
+      "status": "%s",
+      "flavor": "bm3-ai-1xlarge-h100-80-8",
+      "ip_addresses": [],
+      "security_groups": [],
+      "tags": [],
+      "created_at": "2024-01-01T00:00:00Z",
+      "updated_at": "%s"
+    }`, id, name, status, updatedAt)
+}
+
+// ServersResponse lists servers rendered with Server.
+func ServersResponse(servers ...string) string {
+	return fmt.Sprintf(`
+{
+  "count": %d,
+  "results": [%s
+  ]
+}
+`, len(servers), strings.Join(servers, ","))
+}
+
+// ClusterResponse renders a cluster with the given servers count.
+func ClusterResponse(serversCount int) string {
+	return fmt.Sprintf(`{"id": "%s", "name": "cluster", "servers_count": %d}`, ClusterID, serversCount)
+}
+
+// TasksResponse returns a task ID list.
+func TasksResponse(taskID string) string {
+	return fmt.Sprintf(`{"tasks": ["%s"]}`, taskID)
+}
+
+// TaskResponse renders a task in the given state.
+func TaskResponse(taskID, state, taskError string) string {
+	errorValue := "null"
+	if taskError != "" {
+		errorValue = fmt.Sprintf("%q", taskError)
+	}
+	return fmt.Sprintf(`
+{
+  "id": "%s",
+  "task_type": "gpu_cluster_operation",
+  "state": "%s",
+  "error": %s,
+  "client_id": 2,
+  "project_id": 1,
+  "created_on": "2025-06-25T08:42:42"
+}
+`, taskID, state, errorValue)
+}
+
+const ResizeRequest = `
+{
+  "action": "resize",
+  "servers_count": 3,
+  "tags": null
+}
+`
@@ -0,0 +1,247 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/gpu/v3/clusters/supervisor"
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+)
+
+func clusterURL(parts string) string {
+	return fmt.Sprintf("/v3/gpu/baremetal/%d/%d/clusters/%s/%s", fake.ProjectID, fake.RegionID, ClusterID, parts)
+}
+
+func clusterGetURL() string {
+	return fmt.Sprintf("/v3/gpu/baremetal/%d/%d/clusters/%s", fake.ProjectID, fake.RegionID, ClusterID)
+}
+
+func respond(t *testing.T, method, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, method)
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, body)
+	}
+}
+
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) record(e supervisor.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, fmt.Sprintf("%s %s", e.Type, e.ServerName))
+}
+
+func newSupervisor(t *testing.T, r *recorder, opts supervisor.Opts) *supervisor.Supervisor {
+	opts.ClusterID = ClusterID
+	opts.ServersCount = 3
+	opts.WaitSeconds = 5
+	opts.OnEvent = r.record
+	s, err := supervisor.New(supervisor.Clients{
+		GPU:   fake.ServiceTokenClient("gpu/baremetal", "v3"),
+		Tasks: fake.ServiceTokenClient("tasks", "v1"),
+	}, opts)
+	require.NoError(t, err)
+	return s
+}
+
+func TestCheckRebuild(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	th.Mux.HandleFunc(clusterURL("servers"), respond(t, "GET", ServersResponse(
+		Server("a1b2c3d4-0000-4000-8000-000000000001", "healthy", "ACTIVE", now),
+		Server(BrokenServerID, "broken", "ERROR", "2024-01-02T00:00:00Z"),
+		Server(DeferredServerID, "broken-too", "ERROR", "2024-01-02T00:00:00Z"),
+		Server("a1b2c3d4-0000-4000-8000-000000000004", "building", "BUILD", now),
+		Server("a1b2c3d4-0000-4000-8000-000000000005", "stopped", "SHUTOFF", "2024-01-02T00:00:00Z"),
+	)))
+	th.Mux.HandleFunc(clusterURL("servers/"+BrokenServerID+"/rebuild"), respond(t, "POST", TasksResponse(RebuildTaskID)))
+	th.Mux.HandleFunc("/v1/tasks/"+RebuildTaskID, respond(t, "GET", TaskResponse(RebuildTaskID, "FINISHED", "")))
+
+	r := &recorder{}
+	s := newSupervisor(t, r, supervisor.Opts{})
+	require.NoError(t, s.Check(context.Background()))
+	s.Wait()
+	require.Equal(t, []string{
+		"unhealthy broken",
+		"unhealthy broken-too",
+		"deferred broken-too",
+		"rebuild broken",
+		"repaired broken",
+	}, r.events)
+}
+
+func TestCheckReplace(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(clusterURL("servers"), respond(t, "GET", ServersResponse(
+		Server(BrokenServerID, "broken", "ERROR", "2024-01-02T00:00:00Z"),
+	)))
+	th.Mux.HandleFunc(clusterURL("servers/"+BrokenServerID+"/rebuild"), respond(t, "POST", TasksResponse(RebuildTaskID)))
+	th.Mux.HandleFunc("/v1/tasks/"+RebuildTaskID, respond(t, "GET", TaskResponse(RebuildTaskID, "ERROR", "rebuild failed")))
+	th.Mux.HandleFunc(clusterURL("servers/"+BrokenServerID), respond(t, "DELETE", TasksResponse(DeleteTaskID)))
+	th.Mux.HandleFunc("/v1/tasks/"+DeleteTaskID, respond(t, "GET", TaskResponse(DeleteTaskID, "FINISHED", "")))
+	th.Mux.HandleFunc(clusterURL("action"), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestJSONRequest(t, r, ResizeRequest)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, TasksResponse(ResizeTaskID))
+	})
+	th.Mux.HandleFunc("/v1/tasks/"+ResizeTaskID, respond(t, "GET", TaskResponse(ResizeTaskID, "FINISHED", "")))
+	th.Mux.HandleFunc(clusterGetURL(), respond(t, "GET", ClusterResponse(2)))
+
+	r := &recorder{}
+	s := newSupervisor(t, r, supervisor.Opts{})
+	require.NoError(t, s.Check(context.Background()))
+	s.Wait()
+	require.Equal(t, []string{
+		"unhealthy broken",
+		"rebuild broken",
+		"failed broken",
+		"replace broken",
+		"repaired broken",
+	}, r.events)
+}
+
+func TestCheckReportsWithoutLock(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(clusterURL("servers"), respond(t, "GET", ServersResponse(
+		Server(BrokenServerID, "broken", "ERROR", "2024-01-02T00:00:00Z"),
+		Server(DeferredServerID, "broken-too", "ERROR", "2024-01-02T00:00:00Z"),
+	)))
+	th.Mux.HandleFunc(clusterURL("servers/"+BrokenServerID+"/rebuild"), respond(t, "POST", TasksResponse(RebuildTaskID)))
+	th.Mux.HandleFunc("/v1/tasks/"+RebuildTaskID, respond(t, "GET", TaskResponse(RebuildTaskID, "FINISHED", "")))
+
+	r := &recorder{}
+	var s *supervisor.Supervisor
+	var nested int32
+	s, err := supervisor.New(supervisor.Clients{
+		GPU:   fake.ServiceTokenClient("gpu/baremetal", "v3"),
+		Tasks: fake.ServiceTokenClient("tasks", "v1"),
+	}, supervisor.Opts{
+		ClusterID:      ClusterID,
+		DisableReplace: true,
+		WaitSeconds:    5,
+		OnEvent: func(e supervisor.Event) {
+			r.record(e)
+			if e.Type == supervisor.EventDeferred {
+				// checking again from the callback must not deadlock
+				if atomic.AddInt32(&nested, 1) == 1 {
+					require.NoError(t, s.Check(context.Background()))
+				}
+			}
+		},
+	})
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		require.NoError(t, s.Check(context.Background()))
+		s.Wait()
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("check deadlocked")
+	}
+	require.Contains(t, r.events, "repaired broken")
+}
+
+func TestRepairResetsRebuilds(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(clusterURL("servers"), respond(t, "GET", ServersResponse(
+		Server(BrokenServerID, "broken", "ERROR", "2024-01-02T00:00:00Z"),
+	)))
+	th.Mux.HandleFunc(clusterURL("servers/"+BrokenServerID+"/rebuild"), respond(t, "POST", TasksResponse(RebuildTaskID)))
+	th.Mux.HandleFunc("/v1/tasks/"+RebuildTaskID, respond(t, "GET", TaskResponse(RebuildTaskID, "FINISHED", "")))
+
+	r := &recorder{}
+	s := newSupervisor(t, r, supervisor.Opts{})
+	for i := 0; i < 2; i++ {
+		require.NoError(t, s.Check(context.Background()))
+		s.Wait()
+	}
+	require.Equal(t, []string{
+		"unhealthy broken",
+		"rebuild broken",
+		"repaired broken",
+		"unhealthy broken",
+		"rebuild broken",
+		"repaired broken",
+	}, r.events)
+}
+
+func TestReplaceSerializesResize(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var mu sync.Mutex
+	count, resizing := 3, false
+	th.Mux.HandleFunc(clusterURL("servers"), respond(t, "GET", ServersResponse(
+		Server(BrokenServerID, "broken", "ERROR", "2024-01-02T00:00:00Z"),
+		Server(DeferredServerID, "broken-too", "ERROR", "2024-01-02T00:00:00Z"),
+	)))
+	for _, id := range []string{BrokenServerID, DeferredServerID} {
+		th.Mux.HandleFunc(clusterURL("servers/"+id+"/rebuild"), respond(t, "POST", TasksResponse(RebuildTaskID)))
+		th.Mux.HandleFunc(clusterURL("servers/"+id), func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			count--
+			mu.Unlock()
+			respond(t, "DELETE", TasksResponse(DeleteTaskID))(w, r)
+		})
+	}
+	th.Mux.HandleFunc("/v1/tasks/"+RebuildTaskID, respond(t, "GET", TaskResponse(RebuildTaskID, "ERROR", "rebuild failed")))
+	th.Mux.HandleFunc("/v1/tasks/"+DeleteTaskID, respond(t, "GET", TaskResponse(DeleteTaskID, "FINISHED", "")))
+	th.Mux.HandleFunc(clusterGetURL(), func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		body := ClusterResponse(count)
+		mu.Unlock()
+		respond(t, "GET", body)(w, r)
+	})
+	th.Mux.HandleFunc(clusterURL("action"), func(w http.ResponseWriter, r *http.Request) {
+		th.TestJSONRequest(t, r, ResizeRequest)
+		mu.Lock()
+		if resizing {
+			t.Error("concurrent resize")
+		}
+		count, resizing = 3, true
+		mu.Unlock()
+		respond(t, "POST", TasksResponse(ResizeTaskID))(w, r)
+	})
+	th.Mux.HandleFunc("/v1/tasks/"+ResizeTaskID, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		resizing = false
+		mu.Unlock()
+		respond(t, "GET", TaskResponse(ResizeTaskID, "FINISHED", ""))(w, r)
+	})
+
+	r := &recorder{}
+	s := newSupervisor(t, r, supervisor.Opts{MaxConcurrentRepairs: 2})
+	require.NoError(t, s.Check(context.Background()))
+	s.Wait()
+	require.Contains(t, r.events, "repaired broken")
+	require.Contains(t, r.events, "repaired broken-too")
+	require.Equal(t, 3, count)
+}
@@ -0,0 +1,62 @@
+package events
+
+import (
+	"github.com/G-Core/gcorelabscloud-go/gcore/heat/v1/stack/stacks/types"
+	"github.com/G-Core/gcorelabscloud-go/pagination"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+)
+
+// ListOptsBuilder allows extensions to add additional parameters to the List request.
+type ListOptsBuilder interface {
+	ToEventListQuery() (string, error)
+}
+
+// ListOpts allows the filtering and sorting of paginated collections through the API.
+type ListOpts struct {
+	// ResourceName filters events of the resource with the logical name.
+	ResourceName string `q:"resource_name"`
+	// ResourceStatus filters events by status, such as CREATE_FAILED.
+	ResourceStatus string `q:"resource_status"`
+	// ResourceType filters events by resource type.
+	ResourceType string `q:"resource_type"`
+	// NestedDepth includes events of nested stacks down to the depth.
+	NestedDepth int `q:"nested_depth"`
+	// Marker is the ID of the last event of the previous page.
+	Marker  string        `q:"marker"`
+	Limit   int           `q:"limit"`
+	SortDir types.SortDir `q:"sort_dir"`
+}
+
+// ToEventListQuery formats a ListOpts into a query string.
+func (opts ListOpts) ToEventListQuery() (string, error) {
+	q, err := gcorecloud.BuildQueryString(opts)
+	if err != nil {
+		return "", err
+	}
+	return q.String(), nil
+}
+
+// List returns a Pager which allows you to iterate over the events of a stack.
+func List(c *gcorecloud.ServiceClient, stackID string, opts ListOptsBuilder) pagination.Pager {
+	url := listURL(c, stackID)
+	if opts != nil {
+		query, err := opts.ToEventListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(c, url, func(r pagination.PageResult) pagination.Page {
+		return EventPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// ListAll is a convenience function that returns all events of a stack.
+func ListAll(c *gcorecloud.ServiceClient, stackID string, opts ListOptsBuilder) ([]Event, error) {
+	pages, err := List(c, stackID, opts).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return ExtractEvents(pages)
+}
@@ -0,0 +1,67 @@
+package events
+
+import (
+	"strings"
+	"time"
+
+	"github.com/G-Core/gcorelabscloud-go/pagination"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+)
+
+// Event is a status transition of a stack or of one of its resources.
+// Events of the stack itself have the stack name as logical resource ID.
+type Event struct {
+	ID                   string            `json:"id"`
+	EventTime            time.Time         `json:"event_time"`
+	Links                []gcorecloud.Link `json:"links"`
+	LogicalResourceID    string            `json:"logical_resource_id"`
+	PhysicalResourceID   string            `json:"physical_resource_id"`
+	ResourceName         string            `json:"resource_name"`
+	ResourceStatus       string            `json:"resource_status"`
+	ResourceStatusReason string            `json:"resource_status_reason"`
+	ResourceType         string            `json:"resource_type"`
+}
+
+// Failed reports whether the event is a transition to a failed status.
+func (e Event) Failed() bool {
+	return strings.HasSuffix(e.ResourceStatus, "_FAILED")
+}
+
+// EventPage is the page returned by a pager when traversing over a
+// collection of stack events.
+type EventPage struct {
+	pagination.LinkedPageBase
+}
+
+// NextPageURL is invoked when a paginated collection of events has reached
+// the end of a page and the pager seeks to traverse over a new one. In order
+// to do this, it needs to construct the next page's URL.
+func (r EventPage) NextPageURL() (string, error) {
+	var s struct {
+		Links []gcorecloud.Link `json:"links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return gcorecloud.ExtractNextURL(s.Links)
+}
+
+// IsEmpty checks whether an EventPage struct is empty.
+func (r EventPage) IsEmpty() (bool, error) {
+	is, err := ExtractEvents(r)
+	return len(is) == 0, err
+}
+
+// ExtractEvents accepts a Page struct, specifically an EventPage struct,
+// and extracts the elements into a slice of Event structs.
+func ExtractEvents(r pagination.Page) ([]Event, error) {
+	var s []Event
+	err := ExtractEventsInto(r, &s)
+	return s, err
+}
+
+func ExtractEventsInto(r pagination.Page, v interface{}) error {
+	return r.(EventPage).Result.ExtractIntoSlicePtr(v, "results")
+}
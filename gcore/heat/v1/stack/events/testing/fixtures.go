@@ -0,0 +1,59 @@
+package testing
+
+import (
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/heat/v1/stack/events"
+)
+
+const ListResponse = `
+{
+  "count": 2,
+  "results": [
+    {
+      "id": "1a7e5c6e-1f2b-4c8e-9d3a-2f0b6c1d7e01",
+      "event_time": "2020-03-17T21:59:54Z",
+      "logical_resource_id": "server",
+      "physical_resource_id": "",
+      "resource_name": "server",
+      "resource_status": "CREATE_IN_PROGRESS",
+      "resource_status_reason": "state changed",
+      "links": []
+    },
+    {
+      "id": "1a7e5c6e-1f2b-4c8e-9d3a-2f0b6c1d7e02",
+      "event_time": "2020-03-17T22:00:31Z",
+      "logical_resource_id": "server",
+      "physical_resource_id": "96dc3da2-d305-403e-af04-84e6e3718794",
+      "resource_name": "server",
+      "resource_status": "CREATE_FAILED",
+      "resource_status_reason": "ResourceInError: resources.server: Went to status ERROR",
+      "links": []
+    }
+  ]
+}
+`
+
+var (
+	Event1 = events.Event{
+		ID:                   "1a7e5c6e-1f2b-4c8e-9d3a-2f0b6c1d7e01",
+		EventTime:            time.Date(2020, 3, 17, 21, 59, 54, 0, time.UTC),
+		Links:                []gcorecloud.Link{},
+		LogicalResourceID:    "server",
+		ResourceName:         "server",
+		ResourceStatus:       "CREATE_IN_PROGRESS",
+		ResourceStatusReason: "state changed",
+	}
+	Event2 = events.Event{
+		ID:                   "1a7e5c6e-1f2b-4c8e-9d3a-2f0b6c1d7e02",
+		EventTime:            time.Date(2020, 3, 17, 22, 0, 31, 0, time.UTC),
+		Links:                []gcorecloud.Link{},
+		LogicalResourceID:    "server",
+		PhysicalResourceID:   "96dc3da2-d305-403e-af04-84e6e3718794",
+		ResourceName:         "server",
+		ResourceStatus:       "CREATE_FAILED",
+		ResourceStatusReason: "ResourceInError: resources.server: Went to status ERROR",
+	}
+	ExpectedEventSlice = []events.Event{Event1, Event2}
+)
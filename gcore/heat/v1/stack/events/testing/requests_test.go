@@ -0,0 +1,80 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/heat/v1/stack/events"
+	"github.com/G-Core/gcorelabscloud-go/gcore/heat/v1/stack/stacks/types"
+	"github.com/G-Core/gcorelabscloud-go/pagination"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+
+	"github.com/stretchr/testify/require"
+
+	log "github.com/sirupsen/logrus"
+
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+)
+
+var stackID = "stack"
+
+func prepareListEventsTestURL(stackID string) string {
+	return fmt.Sprintf("/v1/heat/%d/%d/stacks/%s/events", fake.ProjectID, fake.RegionID, stackID)
+}
+
+func TestList(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareListEventsTestURL(stackID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		require.Equal(t, "server", r.URL.Query().Get("resource_name"))
+		require.Equal(t, "asc", r.URL.Query().Get("sort_dir"))
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := fmt.Fprint(w, ListResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	client := fake.ServiceTokenClient("heat", "v1")
+	opts := events.ListOpts{ResourceName: "server", SortDir: types.SortAsc}
+	count := 0
+	err := events.List(client, stackID, opts).EachPage(func(page pagination.Page) (bool, error) {
+		count++
+		actual, err := events.ExtractEvents(page)
+		require.NoError(t, err)
+		require.Equal(t, ExpectedEventSlice, actual)
+		require.False(t, actual[0].Failed())
+		require.True(t, actual[1].Failed())
+		return true, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
+
+func TestListAll(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareListEventsTestURL(stackID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := fmt.Fprint(w, ListResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	client := fake.ServiceTokenClient("heat", "v1")
+	actual, err := events.ListAll(client, stackID, nil)
+	require.NoError(t, err)
+	require.Equal(t, ExpectedEventSlice, actual)
+}
@@ -0,0 +1,11 @@
+package events
+
+import gcorecloud "github.com/G-Core/gcorelabscloud-go"
+
+func rootURL(c *gcorecloud.ServiceClient, stackID string) string {
+	return c.ServiceURL("stacks", stackID, "events")
+}
+
+func listURL(c *gcorecloud.ServiceClient, stackID string) string {
+	return rootURL(c, stackID)
+}
@@ -2,8 +2,10 @@ package stacks
 
 import (
 	"fmt"
+	"strings"
 
 	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/heat/v1/stack/events"
 )
 
 type ErrInvalidEnvironment struct {
@@ -39,3 +41,39 @@ type ErrTemplateRequired struct {
 func (e ErrTemplateRequired) Error() string {
 	return "template required for this function"
 }
+
+// ErrInvalidTemplate lists problems found by local template validation.
+type ErrInvalidTemplate struct {
+	gcorecloud.BaseError
+	Problems []string
+}
+
+func (e ErrInvalidTemplate) Error() string {
+	return fmt.Sprintf("template is invalid:\n  %s", strings.Join(e.Problems, "\n  "))
+}
+
+// ErrStackFailed is returned by Wait when a stack stops in a status other than the awaited ones.
+type ErrStackFailed struct {
+	gcorecloud.BaseError
+	Name   string
+	ID     string
+	Status string
+	Reason string
+	// Resource is the first failed transition of a resource, nil when no resource failed.
+	Resource *events.Event
+}
+
+func (e ErrStackFailed) Error() string {
+	message := fmt.Sprintf("stack %s is %s", e.Name, e.Status)
+	if e.Resource != nil {
+		resource := e.Resource.LogicalResourceID
+		if e.Resource.ResourceType != "" {
+			resource = fmt.Sprintf("%s (%s)", resource, e.Resource.ResourceType)
+		}
+		return fmt.Sprintf("%s: resource %s is %s: %s", message, resource, e.Resource.ResourceStatus, e.Resource.ResourceStatusReason)
+	}
+	if e.Reason != "" {
+		return fmt.Sprintf("%s: %s", message, e.Reason)
+	}
+	return message
+}
@@ -0,0 +1,873 @@
+package stacks
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// HOTVersions contains the heat_template_version values accepted by Heat.
+var HOTVersions = map[string]bool{
+	"2013-05-23": true,
+	"2014-10-16": true,
+	"2015-04-30": true,
+	"2015-10-15": true,
+	"2016-04-08": true,
+	"2016-10-14": true,
+	"2017-02-24": true,
+	"2017-09-01": true,
+	"2018-03-02": true,
+	"2018-08-31": true,
+	"newton":     true,
+	"ocata":      true,
+	"pike":       true,
+	"queens":     true,
+	"rocky":      true,
+}
+
+// SupportedResourceTypes contains the resource types available in the cloud. Types registered in the
+// resource_registry of the environment and nested templates are accepted as well.
+var SupportedResourceTypes = map[string]bool{
+	"OS::Cinder::Volume":                 true,
+	"OS::Cinder::VolumeAttachment":       true,
+	"OS::Heat::AutoScalingGroup":         true,
+	"OS::Heat::CloudConfig":              true,
+	"OS::Heat::MultipartMime":            true,
+	"OS::Heat::None":                     true,
+	"OS::Heat::RandomString":             true,
+	"OS::Heat::ResourceChain":            true,
+	"OS::Heat::ResourceGroup":            true,
+	"OS::Heat::ScalingPolicy":            true,
+	"OS::Heat::SoftwareConfig":           true,
+	"OS::Heat::SoftwareDeployment":       true,
+	"OS::Heat::Stack":                    true,
+	"OS::Heat::Value":                    true,
+	"OS::Heat::WaitCondition":            true,
+	"OS::Heat::WaitConditionHandle":      true,
+	"OS::Neutron::FloatingIP":            true,
+	"OS::Neutron::FloatingIPAssociation": true,
+	"OS::Neutron::Net":                   true,
+	"OS::Neutron::Port":                  true,
+	"OS::Neutron::Router":                true,
+	"OS::Neutron::RouterInterface":       true,
+	"OS::Neutron::SecurityGroup":         true,
+	"OS::Neutron::SecurityGroupRule":     true,
+	"OS::Neutron::Subnet":                true,
+	"OS::Nova::KeyPair":                  true,
+	"OS::Nova::Server":                   true,
+	"OS::Nova::ServerGroup":              true,
+	"OS::Octavia::HealthMonitor":         true,
+	"OS::Octavia::L7Policy":              true,
+	"OS::Octavia::L7Rule":                true,
+	"OS::Octavia::Listener":              true,
+	"OS::Octavia::LoadBalancer":          true,
+	"OS::Octavia::Pool":                  true,
+	"OS::Octavia::PoolMember":            true,
+}
+
+// PseudoParameters are the parameters Heat defines for every stack.
+var PseudoParameters = map[string]bool{
+	"OS::stack_name": true,
+	"OS::stack_id":   true,
+	"OS::project_id": true,
+}
+
+var (
+	hotSections        = keySet("heat_template_version", "description", "parameter_groups", "parameters", "resources", "outputs", "conditions")
+	hotParameterKeys   = keySet("type", "label", "description", "default", "hidden", "constraints", "immutable", "tags")
+	hotParameterTypes  = keySet("string", "number", "json", "comma_delimited_list", "boolean")
+	hotConstraintKeys  = keySet("length", "range", "modulo", "allowed_values", "allowed_pattern", "custom_constraint")
+	hotResourceKeys    = keySet("type", "properties", "metadata", "depends_on", "update_policy", "deletion_policy", "external_id", "condition")
+	hotOutputKeys      = keySet("description", "value", "condition")
+	hotResourceFacades = keySet("metadata", "deletion_policy", "update_policy")
+	hotTrue            = keySet("t", "true", "on", "y", "yes", "1")
+	hotFalse           = keySet("f", "false", "off", "n", "no", "0")
+)
+
+func keySet(keys ...string) map[string]bool {
+	m := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		m[k] = true
+	}
+	return m
+}
+
+// hot is a parsed HOT template with its environment.
+type hot struct {
+	description string
+	parameters  map[string]map[string]interface{}
+	resources   map[string]map[string]interface{}
+	outputs     map[string]interface{}
+	conditions  map[string]interface{}
+	// registry contains resource types of the environment resource registry.
+	registry map[string]bool
+	// envParameters contains parameters and parameter_defaults of the environment.
+	envParameters map[string]interface{}
+	problems      []string
+}
+
+func (h *hot) problem(path, format string, args ...interface{}) {
+	h.problems = append(h.problems, path+": "+fmt.Sprintf(format, args...))
+}
+
+// normalize converts maps decoded from YAML to maps with string keys.
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, value := range v {
+			m[fmt.Sprint(k)] = normalize(value)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, value := range v {
+			m[k] = normalize(value)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, value := range v {
+			l[i] = normalize(value)
+		}
+		return l
+	default:
+		return v
+	}
+}
+
+func sortedKeys(m interface{}) []string {
+	keys := reflect.ValueOf(m).MapKeys()
+	result := make([]string, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, k.String())
+	}
+	sort.Strings(result)
+	return result
+}
+
+// loadHOT parses the template and environment of the options.
+func loadHOT(opts CreateOpts) (*hot, error) {
+	if opts.TemplateOpts == nil {
+		return nil, ErrTemplateRequired{}
+	}
+	if err := opts.TemplateOpts.Validate(); err != nil {
+		return nil, err
+	}
+	template := normalize(opts.TemplateOpts.Parsed).(map[string]interface{})
+	h := &hot{
+		parameters:    map[string]map[string]interface{}{},
+		resources:     map[string]map[string]interface{}{},
+		outputs:       map[string]interface{}{},
+		conditions:    map[string]interface{}{},
+		registry:      map[string]bool{},
+		envParameters: map[string]interface{}{},
+	}
+
+	version, ok := template["heat_template_version"]
+	if !ok {
+		return nil, fmt.Errorf("only HOT templates with heat_template_version can be checked locally")
+	}
+	if !HOTVersions[fmt.Sprint(version)] {
+		h.problem("heat_template_version", "unknown version %v", version)
+	}
+	for _, key := range sortedKeys(template) {
+		if !hotSections[key] {
+			h.problem(key, "unknown section")
+		}
+	}
+	if description, ok := template["description"].(string); ok {
+		h.description = description
+	}
+	h.parameters = h.mapSection(template, "parameters")
+	h.resources = h.mapSection(template, "resources")
+	if len(h.resources) == 0 {
+		h.problem("resources", "template has no resources")
+	}
+	if outputs, ok := template["outputs"].(map[string]interface{}); ok {
+		h.outputs = outputs
+	} else if template["outputs"] != nil {
+		h.problem("outputs", "must be a map")
+	}
+	if conditions, ok := template["conditions"].(map[string]interface{}); ok {
+		h.conditions = conditions
+	} else if template["conditions"] != nil {
+		h.problem("conditions", "must be a map")
+	}
+
+	if opts.EnvironmentOpts != nil {
+		if err := opts.EnvironmentOpts.Validate(); err != nil {
+			return nil, err
+		}
+		env := normalize(opts.EnvironmentOpts.Parsed).(map[string]interface{})
+		for _, section := range []string{"parameter_defaults", "parameters"} {
+			if values, ok := env[section].(map[string]interface{}); ok {
+				for k, v := range values {
+					h.envParameters[k] = v
+				}
+			}
+		}
+		if registry, ok := env["resource_registry"].(map[string]interface{}); ok {
+			for k := range registry {
+				if k != "resources" && k != "base_url" && k != "hooks" {
+					h.registry[k] = true
+				}
+			}
+		}
+	}
+	return h, nil
+}
+
+// mapSection returns a section whose entries are maps, reporting entries of other types.
+func (h *hot) mapSection(template map[string]interface{}, section string) map[string]map[string]interface{} {
+	result := map[string]map[string]interface{}{}
+	raw, ok := template[section].(map[string]interface{})
+	if !ok {
+		if template[section] != nil {
+			h.problem(section, "must be a map")
+		}
+		return result
+	}
+	for _, name := range sortedKeys(raw) {
+		entry, ok := raw[name].(map[string]interface{})
+		if !ok {
+			h.problem(section+"."+name, "must be a map")
+			continue
+		}
+		result[name] = entry
+	}
+	return result
+}
+
+// validate checks the template and returns the resolved parameter values.
+func (h *hot) validate(given map[string]interface{}) map[string]interface{} {
+	values := h.validateParameters(given)
+	for _, name := range sortedKeys(h.resources) {
+		h.validateResource(name, h.resources[name])
+	}
+	h.checkCycles()
+	for _, name := range sortedKeys(h.conditions) {
+		h.walk("conditions."+name, h.conditions[name], "")
+	}
+	for _, name := range sortedKeys(h.outputs) {
+		path := "outputs." + name
+		output, ok := h.outputs[name].(map[string]interface{})
+		if !ok {
+			h.problem(path, "must be a map")
+			continue
+		}
+		for _, key := range sortedKeys(output) {
+			if !hotOutputKeys[key] {
+				h.problem(path, "unknown key %s", key)
+			}
+		}
+		if _, ok := output["value"]; !ok {
+			h.problem(path, "value is required")
+		}
+		h.checkCondition(path+".condition", output["condition"])
+		h.walk(path+".value", output["value"], "")
+	}
+	return values
+}
+
+func (h *hot) validateParameters(given map[string]interface{}) map[string]interface{} {
+	values := map[string]interface{}{}
+	for _, name := range sortedKeys(h.parameters) {
+		path := "parameters." + name
+		p := h.parameters[name]
+		for _, key := range sortedKeys(p) {
+			if !hotParameterKeys[key] {
+				h.problem(path, "unknown key %s", key)
+			}
+		}
+		typ, _ := p["type"].(string)
+		if !hotParameterTypes[typ] {
+			h.problem(path, "invalid type %q, expected one of boolean, comma_delimited_list, json, number, string", p["type"])
+			continue
+		}
+		constraints, ok := p["constraints"].([]interface{})
+		if !ok && p["constraints"] != nil {
+			h.problem(path+".constraints", "must be a list")
+		}
+		for i, c := range constraints {
+			constraint, ok := c.(map[string]interface{})
+			if !ok {
+				h.problem(fmt.Sprintf("%s.constraints[%d]", path, i), "must be a map")
+				continue
+			}
+			for _, key := range sortedKeys(constraint) {
+				if !hotConstraintKeys[key] && key != "description" {
+					h.problem(fmt.Sprintf("%s.constraints[%d]", path, i), "unknown constraint %s", key)
+				}
+			}
+			if pattern, ok := constraint["allowed_pattern"]; ok {
+				if _, err := regexp.Compile(fmt.Sprint(pattern)); err != nil {
+					h.problem(fmt.Sprintf("%s.constraints[%d]", path, i), "invalid allowed_pattern: %s", err)
+				}
+			}
+		}
+		if def, ok := p["default"]; ok && def != nil {
+			if _, err := checkParameter(typ, constraints, def); err != nil {
+				h.problem(path+".default", "%s", err)
+			}
+		}
+
+		value, source := given[name], "value"
+		if _, ok := given[name]; !ok {
+			value, ok = h.envParameters[name]
+			source = "environment value"
+			if !ok {
+				value, ok = p["default"]
+				source = "default"
+				if !ok || value == nil {
+					h.problem(path, "value is required")
+					continue
+				}
+			}
+		}
+		converted, err := checkParameter(typ, constraints, value)
+		if err != nil {
+			if source != "default" {
+				h.problem(path, "%s %v: %s", source, value, err)
+			}
+			continue
+		}
+		values[name] = converted
+	}
+	for _, name := range sortedKeys(given) {
+		if _, ok := h.parameters[name]; !ok {
+			h.problem("parameters."+name, "not defined in the template")
+		}
+	}
+	return values
+}
+
+// checkParameter converts a parameter value to its type and checks the constraints.
+func checkParameter(typ string, constraints []interface{}, value interface{}) (interface{}, error) {
+	converted, err := convertParameter(typ, value)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range constraints {
+		constraint, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if err := checkConstraint(typ, constraint, converted); err != nil {
+			if description, ok := constraint["description"].(string); ok {
+				return nil, fmt.Errorf("%s", description)
+			}
+			return nil, err
+		}
+	}
+	return converted, nil
+}
+
+func convertParameter(typ string, value interface{}) (interface{}, error) {
+	s, isString := value.(string)
+	switch typ {
+	case "string":
+		switch value.(type) {
+		case string, int, float64, bool:
+			return fmt.Sprint(value), nil
+		}
+		return nil, fmt.Errorf("must be a string")
+	case "number":
+		switch v := value.(type) {
+		case int:
+			return float64(v), nil
+		case float64:
+			return v, nil
+		}
+		if isString {
+			if f, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+				return f, nil
+			}
+		}
+		return nil, fmt.Errorf("must be a number")
+	case "boolean":
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+		switch s := strings.ToLower(fmt.Sprint(value)); {
+		case hotTrue[s]:
+			return true, nil
+		case hotFalse[s]:
+			return false, nil
+		}
+		return nil, fmt.Errorf("must be a boolean")
+	case "comma_delimited_list":
+		if l, ok := value.([]interface{}); ok {
+			return l, nil
+		}
+		if !isString {
+			return nil, fmt.Errorf("must be a comma delimited list")
+		}
+		result := []interface{}{}
+		if strings.TrimSpace(s) != "" {
+			for _, item := range strings.Split(s, ",") {
+				result = append(result, strings.TrimSpace(item))
+			}
+		}
+		return result, nil
+	case "json":
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			return value, nil
+		}
+		if isString {
+			var v interface{}
+			if err := json.Unmarshal([]byte(s), &v); err == nil {
+				switch v.(type) {
+				case map[string]interface{}, []interface{}:
+					return v, nil
+				}
+			}
+		}
+		return nil, fmt.Errorf("must be a JSON map or list")
+	}
+	return value, nil
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+func checkConstraint(typ string, constraint map[string]interface{}, value interface{}) error {
+	bounds := func(key string) (min, max *float64) {
+		m, _ := constraint[key].(map[string]interface{})
+		if v, ok := toFloat(m["min"]); ok {
+			min = &v
+		}
+		if v, ok := toFloat(m["max"]); ok {
+			max = &v
+		}
+		return
+	}
+	describe := func(name string, min, max *float64) error {
+		switch {
+		case min != nil && max != nil:
+			return fmt.Errorf("%s must be between %v and %v", name, *min, *max)
+		case min != nil:
+			return fmt.Errorf("%s must be at least %v", name, *min)
+		default:
+			return fmt.Errorf("%s must be at most %v", name, *max)
+		}
+	}
+	outside := func(v float64, min, max *float64) bool {
+		return min != nil && v < *min || max != nil && v > *max
+	}
+
+	if _, ok := constraint["length"]; ok {
+		var length int
+		switch v := value.(type) {
+		case string:
+			length = utf8.RuneCountInString(v)
+		case []interface{}:
+			length = len(v)
+		case map[string]interface{}:
+			length = len(v)
+		}
+		if min, max := bounds("length"); outside(float64(length), min, max) {
+			return describe("length", min, max)
+		}
+	}
+	if _, ok := constraint["range"]; ok {
+		if v, ok := toFloat(value); ok {
+			if min, max := bounds("range"); outside(v, min, max) {
+				return describe("value", min, max)
+			}
+		}
+	}
+	if m, ok := constraint["modulo"].(map[string]interface{}); ok {
+		v, okValue := toFloat(value)
+		step, okStep := toFloat(m["step"])
+		offset, _ := toFloat(m["offset"])
+		if okValue && okStep && step != 0 && (v != float64(int64(v)) || (int64(v)-int64(offset))%int64(step) != 0) {
+			return fmt.Errorf("value must be a multiple of %v with offset %v", step, offset)
+		}
+	}
+	if allowed, ok := constraint["allowed_values"].([]interface{}); ok {
+		items := []interface{}{value}
+		if typ == "comma_delimited_list" {
+			items, _ = value.([]interface{})
+		}
+		for _, item := range items {
+			found := false
+			for _, a := range allowed {
+				if fmt.Sprint(a) == fmt.Sprint(item) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				names := make([]string, len(allowed))
+				for i, a := range allowed {
+					names[i] = fmt.Sprint(a)
+				}
+				return fmt.Errorf("%v is not one of %s", item, strings.Join(names, ", "))
+			}
+		}
+	}
+	if pattern, ok := constraint["allowed_pattern"]; ok {
+		re, err := regexp.Compile("^(?:" + fmt.Sprint(pattern) + ")$")
+		if err == nil && !re.MatchString(fmt.Sprint(value)) {
+			return fmt.Errorf("must match pattern %v", pattern)
+		}
+	}
+	return nil
+}
+
+func (h *hot) resourceTypeKnown(typ string) bool {
+	return SupportedResourceTypes[typ] || h.registry[typ] ||
+		strings.HasSuffix(typ, ".yaml") || strings.HasSuffix(typ, ".yml") || strings.HasSuffix(typ, ".template")
+}
+
+func (h *hot) validateResource(name string, r map[string]interface{}) {
+	path := "resources." + name
+	for _, key := range sortedKeys(r) {
+		if !hotResourceKeys[key] {
+			h.problem(path, "unknown key %s", key)
+		}
+	}
+	typ, ok := r["type"].(string)
+	if !ok {
+		h.problem(path, "type is required")
+	} else if !h.resourceTypeKnown(typ) {
+		h.problem(path+".type", "unsupported resource type %s", typ)
+	}
+	if typ == "OS::Heat::ResourceGroup" || typ == "OS::Heat::AutoScalingGroup" {
+		key := "resource_def"
+		if typ == "OS::Heat::AutoScalingGroup" {
+			key = "resource"
+		}
+		properties, _ := r["properties"].(map[string]interface{})
+		def, _ := properties[key].(map[string]interface{})
+		if nested, ok := def["type"].(string); ok && !h.resourceTypeKnown(nested) {
+			h.problem(path+".properties."+key+".type", "unsupported resource type %s", nested)
+		}
+	}
+	for _, dep := range h.dependsOn(r) {
+		if dep == name {
+			h.problem(path+".depends_on", "resource depends on itself")
+		} else if _, ok := h.resources[dep]; !ok {
+			h.problem(path+".depends_on", "undefined resource %s", dep)
+		}
+	}
+	h.checkCondition(path+".condition", r["condition"])
+	for _, key := range []string{"properties", "metadata", "update_policy", "deletion_policy"} {
+		if v, ok := r[key]; ok {
+			h.walk(path+"."+key, v, name)
+		}
+	}
+}
+
+func (h *hot) checkCondition(path string, condition interface{}) {
+	if name, ok := condition.(string); ok {
+		if _, ok := h.conditions[name]; !ok {
+			h.problem(path, "undefined condition %s", name)
+		}
+	}
+}
+
+// dependsOn returns the explicit dependencies of a resource.
+func (h *hot) dependsOn(r map[string]interface{}) []string {
+	switch deps := r["depends_on"].(type) {
+	case string:
+		return []string{deps}
+	case []interface{}:
+		result := make([]string, 0, len(deps))
+		for _, d := range deps {
+			result = append(result, fmt.Sprint(d))
+		}
+		return result
+	}
+	return nil
+}
+
+// dependencies returns explicit and implicit dependencies of a resource.
+func (h *hot) dependencies(name string) []string {
+	r := h.resources[name]
+	refs := map[string]bool{}
+	for _, dep := range h.dependsOn(r) {
+		refs[dep] = true
+	}
+	for _, key := range []string{"properties", "metadata"} {
+		h.references(r[key], refs)
+	}
+	var result []string
+	for _, ref := range sortedKeys(refs) {
+		if _, ok := h.resources[ref]; ok && ref != name {
+			result = append(result, ref)
+		}
+	}
+	return result
+}
+
+// references collects resources referenced with get_resource and get_attr.
+func (h *hot) references(v interface{}, refs map[string]bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if len(v) == 1 {
+			if name, ok := v["get_resource"].(string); ok {
+				refs[name] = true
+			}
+			if args, ok := v["get_attr"].([]interface{}); ok && len(args) > 0 {
+				if name, ok := args[0].(string); ok {
+					refs[name] = true
+				}
+			}
+		}
+		for _, value := range v {
+			h.references(value, refs)
+		}
+	case []interface{}:
+		for _, value := range v {
+			h.references(value, refs)
+		}
+	}
+}
+
+func (h *hot) checkCycles() {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[string]int{}
+	var stack []string
+	var visit func(name string) bool
+	visit = func(name string) bool {
+		switch state[name] {
+		case visiting:
+			i := 0
+			for stack[i] != name {
+				i++
+			}
+			cycle := append(append([]string{}, stack[i:]...), name)
+			h.problem("resources", "dependency cycle %s", strings.Join(cycle, " -> "))
+			return false
+		case done:
+			return true
+		}
+		state[name] = visiting
+		stack = append(stack, name)
+		for _, dep := range h.dependencies(name) {
+			if !visit(dep) {
+				return false
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = done
+		return true
+	}
+	for _, name := range sortedKeys(h.resources) {
+		if !visit(name) {
+			return
+		}
+	}
+}
+
+// hotFunction checks the arguments of an intrinsic function.
+type hotFunction func(h *hot, path string, arg interface{}, self string) string
+
+func listArgs(min, max int) hotFunction {
+	return func(h *hot, path string, arg interface{}, self string) string {
+		l, ok := arg.([]interface{})
+		switch {
+		case !ok:
+			return "arguments must be a list"
+		case len(l) < min || max > 0 && len(l) > max:
+			if min == max {
+				return fmt.Sprintf("expects %d arguments, got %d", min, len(l))
+			}
+			return fmt.Sprintf("expects %d to %d arguments, got %d", min, max, len(l))
+		}
+		return ""
+	}
+}
+
+func mapArgs(required ...string) hotFunction {
+	return func(h *hot, path string, arg interface{}, self string) string {
+		m, ok := arg.(map[string]interface{})
+		if !ok {
+			return "arguments must be a map"
+		}
+		for _, key := range required {
+			if _, ok := m[key]; !ok {
+				return fmt.Sprintf("%s is required", key)
+			}
+		}
+		return ""
+	}
+}
+
+func strReplace(h *hot, path string, arg interface{}, self string) string {
+	if msg := mapArgs("template", "params")(h, path, arg, self); msg != "" {
+		return msg
+	}
+	params := arg.(map[string]interface{})["params"]
+	if _, ok := params.(map[string]interface{}); !ok && !isFunction(params) {
+		return "params must be a map"
+	}
+	return ""
+}
+
+func isFunction(v interface{}) bool {
+	m, ok := v.(map[string]interface{})
+	if !ok || len(m) != 1 {
+		return false
+	}
+	for k := range m {
+		_, ok = hotFunctions[k]
+	}
+	return ok
+}
+
+var hotFunctions map[string]hotFunction
+
+func init() {
+	hotFunctions = map[string]hotFunction{
+		"get_param": func(h *hot, path string, arg interface{}, self string) string {
+			name := arg
+			if l, ok := arg.([]interface{}); ok && len(l) > 0 {
+				name = l[0]
+			}
+			s, ok := name.(string)
+			if !ok {
+				return "parameter name must be a string"
+			}
+			if _, ok := h.parameters[s]; !ok && !PseudoParameters[s] {
+				return fmt.Sprintf("undefined parameter %s", s)
+			}
+			return ""
+		},
+		"get_resource": func(h *hot, path string, arg interface{}, self string) string {
+			s, ok := arg.(string)
+			if !ok {
+				return "resource name must be a string"
+			}
+			return h.checkReference(s, self)
+		},
+		"get_attr": func(h *hot, path string, arg interface{}, self string) string {
+			if msg := listArgs(2, 0)(h, path, arg, self); msg != "" {
+				return msg
+			}
+			s, ok := arg.([]interface{})[0].(string)
+			if !ok {
+				return "resource name must be a string"
+			}
+			return h.checkReference(s, self)
+		},
+		"get_file": func(h *hot, path string, arg interface{}, self string) string {
+			if _, ok := arg.(string); !ok {
+				return "file name must be a string"
+			}
+			return ""
+		},
+		"resource_facade": func(h *hot, path string, arg interface{}, self string) string {
+			if s, ok := arg.(string); !ok || !hotResourceFacades[s] {
+				return "argument must be one of deletion_policy, metadata, update_policy"
+			}
+			return ""
+		},
+		"list_join": func(h *hot, path string, arg interface{}, self string) string {
+			if msg := listArgs(2, 0)(h, path, arg, self); msg != "" {
+				return msg
+			}
+			if _, ok := arg.([]interface{})[0].(string); !ok {
+				return "delimiter must be a string"
+			}
+			return ""
+		},
+		"str_replace":         strReplace,
+		"str_replace_strict":  strReplace,
+		"str_replace_vstrict": strReplace,
+		"repeat":              mapArgs("for_each", "template"),
+		"make_url":            mapArgs(),
+		"yaql":                mapArgs("expression"),
+		"digest":              listArgs(2, 2),
+		"equals":              listArgs(2, 2),
+		"contains":            listArgs(2, 2),
+		"filter":              listArgs(2, 2),
+		"str_split":           listArgs(2, 3),
+		"map_replace":         listArgs(1, 2),
+		"map_merge":           listArgs(1, 0),
+		"list_concat":         listArgs(1, 0),
+		"list_concat_unique":  listArgs(1, 0),
+		"and":                 listArgs(1, 0),
+		"or":                  listArgs(1, 0),
+		"not":                 func(h *hot, path string, arg interface{}, self string) string { return "" },
+		"if": func(h *hot, path string, arg interface{}, self string) string {
+			if msg := listArgs(2, 3)(h, path, arg, self); msg != "" {
+				return msg
+			}
+			if name, ok := arg.([]interface{})[0].(string); ok {
+				if _, ok := h.conditions[name]; !ok {
+					return fmt.Sprintf("undefined condition %s", name)
+				}
+			}
+			return ""
+		},
+	}
+}
+
+func (h *hot) checkReference(name, self string) string {
+	if name == self {
+		return "resource references itself"
+	}
+	if _, ok := h.resources[name]; !ok {
+		return fmt.Sprintf("undefined resource %s", name)
+	}
+	return ""
+}
+
+// walk checks the intrinsic functions of a value.
+func (h *hot) walk(path string, v interface{}, self string) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if len(v) == 1 {
+			for name, arg := range v {
+				if check, ok := hotFunctions[name]; ok {
+					if msg := check(h, path, arg, self); msg != "" {
+						h.problem(path, "%s: %s", name, msg)
+					}
+				} else if strings.HasPrefix(name, "Fn::") || strings.HasPrefix(name, "get_") || strings.HasPrefix(name, "str_") {
+					h.problem(path, "unknown function %s", name)
+				}
+			}
+		}
+		for _, k := range sortedKeys(v) {
+			h.walk(path+"."+k, v[k], self)
+		}
+	case []interface{}:
+		for i, value := range v {
+			h.walk(fmt.Sprintf("%s[%d]", path, i), value, self)
+		}
+	}
+}
+
+// ValidateTemplate checks a HOT template with its environment and parameters without calling the API: sections,
+// parameter types and constraints, resource types, dependencies and references of intrinsic functions.
+// Problems are returned as ErrInvalidTemplate.
+func ValidateTemplate(opts CreateOpts) error {
+	h, err := loadHOT(opts)
+	if err != nil {
+		return err
+	}
+	h.validate(opts.Parameters)
+	if len(h.problems) > 0 {
+		return ErrInvalidTemplate{Problems: h.problems}
+	}
+	return nil
+}
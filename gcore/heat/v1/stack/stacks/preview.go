@@ -0,0 +1,249 @@
+package stacks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// HiddenParameterValue replaces values of hidden parameters in previews.
+const HiddenParameterValue = "******"
+
+// PreviewResource is a resource of a stack preview.
+type PreviewResource struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// Properties are rendered with the parameters. Functions resolved only by Heat, such as get_attr, are kept as is.
+	Properties map[string]interface{} `json:"properties,omitempty"`
+	DependsOn  []string               `json:"depends_on,omitempty"`
+	Condition  interface{}            `json:"condition,omitempty"`
+}
+
+// StackPreview is a template rendered locally with its parameters.
+type StackPreview struct {
+	Name        string                 `json:"stack_name,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Parameters  map[string]interface{} `json:"parameters"`
+	// Resources are ordered so that every resource follows its dependencies.
+	Resources []PreviewResource      `json:"resources"`
+	Outputs   map[string]interface{} `json:"outputs,omitempty"`
+}
+
+// Preview validates a HOT template like ValidateTemplate and renders it with the parameters of the options.
+// get_param, str_replace and list_join are resolved locally; the graph of resources follows depends_on,
+// get_resource and get_attr references.
+func Preview(opts CreateOpts) (*StackPreview, error) {
+	h, err := loadHOT(opts)
+	if err != nil {
+		return nil, err
+	}
+	values := h.validate(opts.Parameters)
+	if len(h.problems) > 0 {
+		return nil, ErrInvalidTemplate{Problems: h.problems}
+	}
+	if opts.Name != "" {
+		values["OS::stack_name"] = opts.Name
+	}
+
+	preview := &StackPreview{
+		Name:        opts.Name,
+		Description: h.description,
+		Parameters:  map[string]interface{}{},
+		Outputs:     map[string]interface{}{},
+	}
+	// Hidden values are masked in rendered properties as well.
+	for name, p := range h.parameters {
+		if hidden, _ := convertParameter("boolean", p["hidden"]); hidden == true {
+			values[name] = HiddenParameterValue
+		}
+		if value, ok := values[name]; ok {
+			preview.Parameters[name] = value
+		}
+	}
+	for _, name := range h.order() {
+		r := h.resources[name]
+		resource := PreviewResource{
+			Name:      name,
+			Type:      fmt.Sprint(r["type"]),
+			DependsOn: h.dependencies(name),
+			Condition: r["condition"],
+		}
+		if properties, ok := render(r["properties"], values).(map[string]interface{}); ok {
+			resource.Properties = properties
+		}
+		preview.Resources = append(preview.Resources, resource)
+	}
+	for name, output := range h.outputs {
+		preview.Outputs[name] = render(output.(map[string]interface{})["value"], values)
+	}
+	return preview, nil
+}
+
+// order returns resources sorted by dependencies, names break ties.
+func (h *hot) order() []string {
+	remaining := map[string][]string{}
+	for name := range h.resources {
+		remaining[name] = h.dependencies(name)
+	}
+	var result []string
+	created := map[string]bool{}
+	for len(remaining) > 0 {
+		var ready []string
+		for name, deps := range remaining {
+			ok := true
+			for _, dep := range deps {
+				ok = ok && created[dep]
+			}
+			if ok {
+				ready = append(ready, name)
+			}
+		}
+		sort.Strings(ready)
+		for _, name := range ready {
+			created[name] = true
+			delete(remaining, name)
+		}
+		result = append(result, ready...)
+	}
+	return result
+}
+
+// render resolves the functions of a value which only depend on parameters.
+func render(v interface{}, values map[string]interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		rendered := make(map[string]interface{}, len(v))
+		for k, value := range v {
+			rendered[k] = render(value, values)
+		}
+		if len(rendered) != 1 {
+			return rendered
+		}
+		for name, arg := range rendered {
+			if result, ok := resolve(name, arg, values); ok {
+				return result
+			}
+		}
+		return rendered
+	case []interface{}:
+		rendered := make([]interface{}, len(v))
+		for i, value := range v {
+			rendered[i] = render(value, values)
+		}
+		return rendered
+	default:
+		return v
+	}
+}
+
+func isScalar(v interface{}) bool {
+	switch v.(type) {
+	case string, int, float64, bool:
+		return true
+	}
+	return false
+}
+
+func scalarString(v interface{}) string {
+	if f, ok := v.(float64); ok && f == float64(int64(f)) {
+		return fmt.Sprint(int64(f))
+	}
+	return fmt.Sprint(v)
+}
+
+// resolve evaluates a function whose arguments are rendered already.
+func resolve(name string, arg interface{}, values map[string]interface{}) (interface{}, bool) {
+	switch name {
+	case "get_param":
+		path, ok := arg.([]interface{})
+		if !ok {
+			path = []interface{}{arg}
+		}
+		value, ok := values[fmt.Sprint(path[0])]
+		if !ok {
+			return nil, false
+		}
+		for _, key := range path[1:] {
+			switch current := value.(type) {
+			case map[string]interface{}:
+				if value, ok = current[fmt.Sprint(key)]; !ok {
+					return nil, false
+				}
+			case []interface{}:
+				i, isInt := key.(int)
+				if f, isFloat := key.(float64); isFloat {
+					i, isInt = int(f), true
+				}
+				if !isInt || i < 0 || i >= len(current) {
+					return nil, false
+				}
+				value = current[i]
+			default:
+				return nil, false
+			}
+		}
+		return value, true
+	case "str_replace", "str_replace_strict", "str_replace_vstrict":
+		m := arg.(map[string]interface{})
+		template, ok := m["template"].(string)
+		params, okParams := m["params"].(map[string]interface{})
+		if !ok || !okParams {
+			return nil, false
+		}
+		keys := make([]string, 0, len(params))
+		for k, value := range params {
+			if !isScalar(value) {
+				return nil, false
+			}
+			keys = append(keys, k)
+		}
+		// Longer keys are replaced first so that keys containing other keys are kept whole.
+		sort.Slice(keys, func(i, j int) bool {
+			if len(keys[i]) != len(keys[j]) {
+				return len(keys[i]) > len(keys[j])
+			}
+			return keys[i] < keys[j]
+		})
+		for _, k := range keys {
+			template = strings.ReplaceAll(template, k, scalarString(params[k]))
+		}
+		return template, true
+	case "list_join":
+		args, ok := arg.([]interface{})
+		if !ok || len(args) < 2 {
+			return nil, false
+		}
+		delimiter, ok := args[0].(string)
+		if !ok {
+			return nil, false
+		}
+		var items []string
+		for _, list := range args[1:] {
+			l, ok := list.([]interface{})
+			if !ok {
+				return nil, false
+			}
+			for _, item := range l {
+				if !isScalar(item) {
+					return nil, false
+				}
+				items = append(items, scalarString(item))
+			}
+		}
+		return strings.Join(items, delimiter), true
+	}
+	return nil, false
+}
+
+// Graph formats the resources of the preview with their dependencies, one resource per line.
+func (p StackPreview) Graph() string {
+	var b strings.Builder
+	for _, r := range p.Resources {
+		fmt.Fprintf(&b, "%s (%s)", r.Name, r.Type)
+		if len(r.DependsOn) > 0 {
+			fmt.Fprintf(&b, " <- %s", strings.Join(r.DependsOn, ", "))
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
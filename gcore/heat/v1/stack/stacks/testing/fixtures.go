@@ -168,3 +168,254 @@ var (
 
 	ExpectedStackList1 = []stacks.StackList{StackList1}
 )
+
+const ValidHOT = `
+heat_template_version: 2018-08-31
+description: Web server
+parameters:
+  flavor:
+    type: string
+    default: g1-standard-1-2
+    constraints:
+      - allowed_values: [g1-standard-1-2, g1-standard-2-4]
+  count:
+    type: number
+    default: 1
+    constraints:
+      - range: {min: 1, max: 3}
+  dns:
+    type: comma_delimited_list
+    default: "8.8.8.8, 1.1.1.1"
+  tags:
+    type: json
+    default: {env: dev}
+  password:
+    type: string
+    hidden: true
+    constraints:
+      - length: {min: 8}
+        description: password must be at least 8 characters long
+  image:
+    type: string
+resources:
+  network:
+    type: OS::Neutron::Net
+    properties:
+      name:
+        str_replace:
+          template: $stack-net
+          params:
+            $stack: {get_param: "OS::stack_name"}
+  subnet:
+    type: OS::Neutron::Subnet
+    properties:
+      network: {get_resource: network}
+      cidr: 192.168.0.0/24
+      dns_nameservers: {get_param: dns}
+  server:
+    type: My::Server
+    depends_on: subnet
+    properties:
+      flavor: {get_param: flavor}
+      image: {get_param: image}
+      user_data:
+        str_replace:
+          template: "echo $password > /root/pass; echo $env"
+          params:
+            $password: {get_param: password}
+            $env: {get_param: [tags, env]}
+      metadata:
+        dns: {list_join: [",", {get_param: dns}]}
+  volume:
+    type: OS::Cinder::Volume
+    properties:
+      size: {get_param: count}
+outputs:
+  address:
+    value: {get_attr: [server, first_address]}
+`
+
+const HOTEnvironment = `
+parameter_defaults:
+  image: ubuntu-22.04
+resource_registry:
+  My::Server: server.yaml
+`
+
+const InvalidHOT = `
+heat_template_version: 2018-08-31
+parameters:
+  flavor:
+    type: text
+  size:
+    type: number
+    default: 200
+    constraints:
+      - range: {min: 1, max: 100}
+  name:
+    type: string
+    constraints:
+      - allowed_pattern: "[a-z]+"
+resources:
+  server:
+    type: OS::Nova::Instance
+    properties:
+      flavor: {get_param: flavour}
+      networks:
+        - port: {get_resource: port}
+  port:
+    type: OS::Neutron::Port
+    depends_on: [server, missing]
+    properties:
+      network: {get_resource: port}
+      fixed_ips: {list_join: ","}
+  group:
+    type: OS::Heat::ResourceGroup
+    properties:
+      resource_def:
+        type: OS::Nova::Unknown
+outputs:
+  ip:
+    description: missing value
+`
+
+const WaitStackResponse = `
+{
+  "id": "94b6fff4-16ac-4049-a4e3-5323c1ab6060",
+  "stack_name": "web",
+  "creation_time": "2020-03-17T21:59:54+00:00",
+  "updated_time": "2020-03-21T20:41:00+00:00",
+  "deletion_time": null,
+  "stack_status": "%s",
+  "stack_status_reason": "%s"
+}
+`
+
+const WaitUpdateStartedEvents = `
+{
+  "count": 3,
+  "results": [
+    {
+      "id": "e1",
+      "event_time": "2020-03-17T22:01:10Z",
+      "logical_resource_id": "web",
+      "resource_name": "web",
+      "resource_status": "CREATE_COMPLETE",
+      "resource_status_reason": "Stack CREATE completed successfully"
+    },
+    {
+      "id": "e3",
+      "event_time": "2020-03-21T20:41:02Z",
+      "logical_resource_id": "server",
+      "resource_name": "server",
+      "resource_status": "UPDATE_IN_PROGRESS",
+      "resource_status_reason": "state changed"
+    },
+    {
+      "id": "e2",
+      "event_time": "2020-03-21T20:41:00Z",
+      "logical_resource_id": "web",
+      "resource_name": "web",
+      "resource_status": "UPDATE_IN_PROGRESS",
+      "resource_status_reason": "Stack UPDATE started"
+    }
+  ]
+}
+`
+
+const WaitUpdateCompletedEvents = `
+{
+  "count": 5,
+  "results": [
+    {
+      "id": "e1",
+      "event_time": "2020-03-17T22:01:10Z",
+      "logical_resource_id": "web",
+      "resource_name": "web",
+      "resource_status": "CREATE_COMPLETE",
+      "resource_status_reason": "Stack CREATE completed successfully"
+    },
+    {
+      "id": "e2",
+      "event_time": "2020-03-21T20:41:00Z",
+      "logical_resource_id": "web",
+      "resource_name": "web",
+      "resource_status": "UPDATE_IN_PROGRESS",
+      "resource_status_reason": "Stack UPDATE started"
+    },
+    {
+      "id": "e3",
+      "event_time": "2020-03-21T20:41:02Z",
+      "logical_resource_id": "server",
+      "resource_name": "server",
+      "resource_status": "UPDATE_IN_PROGRESS",
+      "resource_status_reason": "state changed"
+    },
+    {
+      "id": "e4",
+      "event_time": "2020-03-21T20:41:30Z",
+      "logical_resource_id": "server",
+      "resource_name": "server",
+      "resource_status": "UPDATE_COMPLETE",
+      "resource_status_reason": "state changed"
+    },
+    {
+      "id": "e5",
+      "event_time": "2020-03-21T20:41:31Z",
+      "logical_resource_id": "web",
+      "resource_name": "web",
+      "resource_status": "UPDATE_COMPLETE",
+      "resource_status_reason": "Stack UPDATE completed successfully"
+    }
+  ]
+}
+`
+
+const WaitFailedEvents = `
+{
+  "count": 5,
+  "results": [
+    {
+      "id": "f1",
+      "event_time": "2020-03-21T20:41:00Z",
+      "logical_resource_id": "web",
+      "resource_name": "web",
+      "resource_status": "UPDATE_IN_PROGRESS",
+      "resource_status_reason": "Stack UPDATE started"
+    },
+    {
+      "id": "f2",
+      "event_time": "2020-03-21T20:41:05Z",
+      "logical_resource_id": "port",
+      "resource_name": "port",
+      "resource_status": "UPDATE_COMPLETE",
+      "resource_status_reason": "state changed"
+    },
+    {
+      "id": "f3",
+      "event_time": "2020-03-21T20:41:20Z",
+      "logical_resource_id": "server",
+      "resource_name": "server",
+      "resource_type": "OS::Nova::Server",
+      "resource_status": "UPDATE_FAILED",
+      "resource_status_reason": "ResourceInError: resources.server: Went to status ERROR due to \"No valid host was found\""
+    },
+    {
+      "id": "f4",
+      "event_time": "2020-03-21T20:41:21Z",
+      "logical_resource_id": "volume",
+      "resource_name": "volume",
+      "resource_status": "UPDATE_FAILED",
+      "resource_status_reason": "UPDATE aborted"
+    },
+    {
+      "id": "f5",
+      "event_time": "2020-03-21T20:41:22Z",
+      "logical_resource_id": "web",
+      "resource_name": "web",
+      "resource_status": "UPDATE_FAILED",
+      "resource_status_reason": "Resource UPDATE failed"
+    }
+  ]
+}
+`
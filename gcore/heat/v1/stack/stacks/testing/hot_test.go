@@ -0,0 +1,104 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/heat/v1/stack/stacks"
+	"github.com/stretchr/testify/require"
+)
+
+func hotOpts(template, environment string, parameters map[string]interface{}) stacks.CreateOpts {
+	opts := stacks.CreateOpts{
+		Name:         "web",
+		TemplateOpts: &stacks.Template{TE: stacks.TE{Bin: []byte(template)}},
+		Parameters:   parameters,
+	}
+	if environment != "" {
+		opts.EnvironmentOpts = &stacks.Environment{TE: stacks.TE{Bin: []byte(environment)}}
+	}
+	return opts
+}
+
+func TestValidateTemplate(t *testing.T) {
+	require.NoError(t, stacks.ValidateTemplate(hotOpts(ValidHOT, HOTEnvironment, map[string]interface{}{"password": "secret-password"})))
+
+	err := stacks.ValidateTemplate(hotOpts(ValidHOT, "", map[string]interface{}{
+		"password": "short",
+		"count":    "5",
+		"flavor":   "g1-gpu",
+		"extra":    "1",
+	}))
+	require.Error(t, err)
+	require.Equal(t, []string{
+		"parameters.count: value 5: value must be between 1 and 3",
+		"parameters.flavor: value g1-gpu: g1-gpu is not one of g1-standard-1-2, g1-standard-2-4",
+		"parameters.image: value is required",
+		"parameters.password: value short: password must be at least 8 characters long",
+		"parameters.extra: not defined in the template",
+		"resources.server.type: unsupported resource type My::Server",
+	}, err.(stacks.ErrInvalidTemplate).Problems)
+}
+
+func TestValidateInvalidTemplate(t *testing.T) {
+	err := stacks.ValidateTemplate(hotOpts(InvalidHOT, "", map[string]interface{}{"name": "Web1"}))
+	require.Error(t, err)
+	require.Equal(t, []string{
+		`parameters.flavor: invalid type "text", expected one of boolean, comma_delimited_list, json, number, string`,
+		"parameters.name: value Web1: must match pattern [a-z]+",
+		"parameters.size.default: value must be between 1 and 100",
+		"resources.group.properties.resource_def.type: unsupported resource type OS::Nova::Unknown",
+		"resources.port.depends_on: undefined resource missing",
+		"resources.port.properties.fixed_ips: list_join: arguments must be a list",
+		"resources.port.properties.network: get_resource: resource references itself",
+		"resources.server.type: unsupported resource type OS::Nova::Instance",
+		"resources.server.properties.flavor: get_param: undefined parameter flavour",
+		"resources: dependency cycle port -> server -> port",
+		"outputs.ip: value is required",
+	}, err.(stacks.ErrInvalidTemplate).Problems)
+
+	err = stacks.ValidateTemplate(hotOpts("AWSTemplateFormatVersion: 2010-09-09\n", "", nil))
+	require.EqualError(t, err, "only HOT templates with heat_template_version can be checked locally")
+}
+
+func TestPreview(t *testing.T) {
+	preview, err := stacks.Preview(hotOpts(ValidHOT, HOTEnvironment, map[string]interface{}{
+		"password": "secret-password",
+		"count":    "2",
+	}))
+	require.NoError(t, err)
+	require.Equal(t, "web", preview.Name)
+	require.Equal(t, "Web server", preview.Description)
+	require.Equal(t, map[string]interface{}{
+		"flavor":   "g1-standard-1-2",
+		"count":    float64(2),
+		"dns":      []interface{}{"8.8.8.8", "1.1.1.1"},
+		"tags":     map[string]interface{}{"env": "dev"},
+		"password": stacks.HiddenParameterValue,
+		"image":    "ubuntu-22.04",
+	}, preview.Parameters)
+
+	require.Equal(t, "network (OS::Neutron::Net)\n"+
+		"volume (OS::Cinder::Volume)\n"+
+		"subnet (OS::Neutron::Subnet) <- network\n"+
+		"server (My::Server) <- subnet\n", preview.Graph())
+
+	require.Equal(t, map[string]interface{}{"name": "web-net"}, preview.Resources[0].Properties)
+	require.Equal(t, map[string]interface{}{"size": float64(2)}, preview.Resources[1].Properties)
+	require.Equal(t, map[string]interface{}{
+		"network":         map[string]interface{}{"get_resource": "network"},
+		"cidr":            "192.168.0.0/24",
+		"dns_nameservers": []interface{}{"8.8.8.8", "1.1.1.1"},
+	}, preview.Resources[2].Properties)
+	require.Equal(t, map[string]interface{}{
+		"flavor":    "g1-standard-1-2",
+		"image":     "ubuntu-22.04",
+		"user_data": "echo ****** > /root/pass; echo dev",
+		"metadata":  map[string]interface{}{"dns": "8.8.8.8,1.1.1.1"},
+	}, preview.Resources[3].Properties)
+	require.Equal(t, map[string]interface{}{
+		"address": map[string]interface{}{"get_attr": []interface{}{"server", "first_address"}},
+	}, preview.Outputs)
+
+	_, err = stacks.Preview(hotOpts(ValidHOT, HOTEnvironment, nil))
+	require.EqualError(t, err, "template is invalid:\n  parameters.password: value is required")
+}
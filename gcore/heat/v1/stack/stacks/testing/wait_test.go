@@ -0,0 +1,118 @@
+package testing
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/heat/v1/stack/events"
+	"github.com/G-Core/gcorelabscloud-go/gcore/heat/v1/stack/stacks"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+
+	"github.com/stretchr/testify/require"
+
+	log "github.com/sirupsen/logrus"
+
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+)
+
+const waitStackID = "94b6fff4-16ac-4049-a4e3-5323c1ab6060"
+
+func handleWait(t *testing.T, statuses []string, eventResponses []string) {
+	gets := 0
+	th.Mux.HandleFunc(prepareGetTestURL(waitStackID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		status := statuses[min(gets, len(statuses)-1)]
+		gets++
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := fmt.Fprintf(w, WaitStackResponse, status, "Resource UPDATE failed")
+		if err != nil {
+			log.Error(err)
+		}
+	})
+	lists := 0
+	th.Mux.HandleFunc(prepareGetTestURL(waitStackID)+"/events", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		require.Equal(t, "asc", r.URL.Query().Get("sort_dir"))
+		response := eventResponses[min(lists, len(eventResponses)-1)]
+		lists++
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := fmt.Fprint(w, response)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+}
+
+func TestWait(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	handleWait(t, []string{"UPDATE_IN_PROGRESS", "UPDATE_COMPLETE"}, []string{WaitUpdateStartedEvents, WaitUpdateCompletedEvents})
+
+	client := fake.ServiceTokenClient("heat", "v1")
+	var received []string
+	stack, err := stacks.Wait(client, "web", waitStackID, []string{"CREATE_COMPLETE", "UPDATE_COMPLETE"}, func(event events.Event) {
+		received = append(received, event.ID+" "+event.LogicalResourceID+" "+event.ResourceStatus)
+	}, 10)
+	require.NoError(t, err)
+	require.Equal(t, "UPDATE_COMPLETE", stack.StackStatus)
+	// The event of the previous action is skipped and every event is received once, in time order.
+	require.Equal(t, []string{
+		"e2 web UPDATE_IN_PROGRESS",
+		"e3 server UPDATE_IN_PROGRESS",
+		"e4 server UPDATE_COMPLETE",
+		"e5 web UPDATE_COMPLETE",
+	}, received)
+}
+
+func TestWaitFailed(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	handleWait(t, []string{"UPDATE_FAILED"}, []string{WaitFailedEvents})
+
+	client := fake.ServiceTokenClient("heat", "v1")
+	stack, err := stacks.Wait(client, "web", waitStackID, []string{"UPDATE_COMPLETE"}, nil, 10)
+	require.Error(t, err)
+	require.Equal(t, "UPDATE_FAILED", stack.StackStatus)
+
+	var failed stacks.ErrStackFailed
+	require.True(t, errors.As(err, &failed))
+	require.Equal(t, "UPDATE_FAILED", failed.Status)
+	require.Equal(t, "Resource UPDATE failed", failed.Reason)
+	require.NotNil(t, failed.Resource)
+	require.Equal(t, "server", failed.Resource.LogicalResourceID)
+	require.Equal(t, `stack web is UPDATE_FAILED: resource server (OS::Nova::Server) is UPDATE_FAILED: `+
+		`ResourceInError: resources.server: Went to status ERROR due to "No valid host was found"`, err.Error())
+}
+
+func TestWaitDeleted(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	th.Mux.HandleFunc(prepareGetTestURL(waitStackID), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	client := fake.ServiceTokenClient("heat", "v1")
+	stack, err := stacks.Wait(client, "web", waitStackID, []string{stacks.StatusDeleteComplete}, nil, 10)
+	require.NoError(t, err)
+	require.Nil(t, stack)
+
+	_, err = stacks.Wait(client, "web", waitStackID, []string{"UPDATE_COMPLETE"}, nil, 10)
+	require.Error(t, err)
+}
+
+func TestFirstFailure(t *testing.T) {
+	list := []events.Event{
+		{LogicalResourceID: "web", ResourceStatus: "CREATE_FAILED"},
+		{LogicalResourceID: "port", ResourceStatus: "CREATE_COMPLETE"},
+		{LogicalResourceID: "server", ResourceStatus: "CREATE_FAILED"},
+	}
+	require.Equal(t, "server", stacks.FirstFailure("web", list).LogicalResourceID)
+	require.Nil(t, stacks.FirstFailure("web", list[:2]))
+}
@@ -0,0 +1,112 @@
+package stacks
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/heat/v1/stack/events"
+	"github.com/G-Core/gcorelabscloud-go/gcore/heat/v1/stack/stacks/types"
+)
+
+// StatusDeleteComplete is the status of deleted stacks. Stacks which are not found any more are treated as deleted.
+const StatusDeleteComplete = "DELETE_COMPLETE"
+
+// EventHandler receives the status transitions of a stack and its resources while waiting.
+type EventHandler func(event events.Event)
+
+// Wait polls the stack until it reaches one of the target statuses, such as CREATE_COMPLETE or UPDATE_COMPLETE.
+// The events of the running action are passed to the handler in order, each of them once; the handler may be nil.
+// A stack which stops in another status is reported with ErrStackFailed naming the first failed resource.
+// A negative timeout waits forever. The returned stack is nil when the stack was deleted.
+func Wait(c *gcorecloud.ServiceClient, name, id string, targetStatuses []string, handler EventHandler, timeout int) (*Stack, error) {
+	var (
+		stack   *Stack
+		since   time.Time
+		history []events.Event
+		failure error
+	)
+	seen := map[string]bool{}
+	err := gcorecloud.WaitFor(timeout, func() (bool, error) {
+		s, err := Get(c, id).Extract()
+		switch err.(type) {
+		case nil:
+		case gcorecloud.ErrDefault404:
+			if slices.Contains(targetStatuses, StatusDeleteComplete) {
+				stack = nil
+				return true, nil
+			}
+			return false, err
+		default:
+			return false, err
+		}
+		stack = s
+		if since.IsZero() {
+			since = s.actionTime()
+		}
+
+		list, err := events.ListAll(c, id, events.ListOpts{SortDir: types.SortAsc})
+		if err != nil {
+			return false, err
+		}
+		slices.SortStableFunc(list, func(a, b events.Event) int { return a.EventTime.Compare(b.EventTime) })
+		for _, event := range list {
+			if seen[event.ID] || event.EventTime.Before(since) {
+				continue
+			}
+			seen[event.ID] = true
+			history = append(history, event)
+			if handler != nil {
+				handler(event)
+			}
+		}
+
+		switch {
+		case slices.Contains(targetStatuses, s.StackStatus):
+			return true, nil
+		case strings.HasSuffix(s.StackStatus, "_IN_PROGRESS"):
+			return false, nil
+		}
+		failure = newErrStackFailed(name, s, history)
+		return false, failure
+	})
+	if err != nil {
+		if failure != nil {
+			return stack, failure
+		}
+		return nil, fmt.Errorf("cannot wait for stack %s: %w", name, err)
+	}
+	return stack, nil
+}
+
+// actionTime returns the start of the latest action of the stack.
+func (s Stack) actionTime() time.Time {
+	switch {
+	case s.DeletionTime != nil:
+		return *s.DeletionTime
+	case s.UpdatedTime != nil:
+		return *s.UpdatedTime
+	}
+	return s.CreationTime
+}
+
+// FirstFailure returns the earliest failed transition of a resource among the events of the stack,
+// nil when only the stack itself failed.
+func FirstFailure(name string, list []events.Event) *events.Event {
+	for i := range list {
+		if list[i].Failed() && list[i].LogicalResourceID != name {
+			return &list[i]
+		}
+	}
+	return nil
+}
+
+func newErrStackFailed(name string, s *Stack, history []events.Event) ErrStackFailed {
+	err := ErrStackFailed{Name: name, ID: s.ID, Status: s.StackStatus, Resource: FirstFailure(name, history)}
+	if s.StackStatusReason != nil {
+		err.Reason = *s.StackStatusReason
+	}
+	return err
+}
@@ -0,0 +1,401 @@
+package images
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/image/v1/images/types"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
+	instancesV2 "github.com/G-Core/gcorelabscloud-go/gcore/instance/v2/instances"
+	instancetypes "github.com/G-Core/gcorelabscloud-go/gcore/instance/v2/types"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+	"github.com/G-Core/gcorelabscloud-go/gcore/volume/v1/volumes"
+)
+
+const (
+	// DefaultBakeNameTemplate names image versions.
+	DefaultBakeNameTemplate = "{{.Family}}-v{{.Version}}"
+	// DefaultBakeWaitSeconds is how long every task of a bake is waited for.
+	DefaultBakeWaitSeconds = 1800
+	// FamilyMetadataKey is the image metadata key grouping versions of a baked image.
+	FamilyMetadataKey = "image_family"
+	// VersionMetadataKey is the image metadata key holding the version number of a baked image.
+	VersionMetadataKey = "image_version"
+	// SourceInstanceMetadataKey is the image metadata key holding the instance an image was baked from.
+	SourceInstanceMetadataKey = "source_instance_id"
+)
+
+const (
+	instanceStatusActive  = "ACTIVE"
+	instanceStatusShutoff = "SHUTOFF"
+)
+
+// CleanupScript is a shell script generalizing a Linux instance before it is baked.
+// User data is only applied when an instance is created, so it is meant to be run by BakeOpts.Cleanup, e.g. over SSH.
+const CleanupScript = `#!/bin/sh
+set -e
+cloud-init clean --logs || true
+truncate -s 0 /etc/machine-id
+rm -f /var/lib/dbus/machine-id /etc/ssh/ssh_host_*
+rm -f /root/.bash_history /home/*/.bash_history
+rm -rf /tmp/* /var/tmp/*
+sync
+`
+
+// BakeClients groups service clients used to bake an image.
+type BakeClients struct {
+	// Images is an images v1 client.
+	Images *gcorecloud.ServiceClient `validate:"required"`
+	// Instances is an instances v1 client.
+	Instances *gcorecloud.ServiceClient `validate:"required"`
+	// InstanceActions is an instances v2 client used to stop and start the instance.
+	InstanceActions *gcorecloud.ServiceClient `validate:"required"`
+	// Volumes is a volumes v1 client.
+	Volumes *gcorecloud.ServiceClient `validate:"required"`
+}
+
+// BakeOpts represents options used to build an image from an instance.
+type BakeOpts struct {
+	InstanceID string `validate:"required"`
+	// Family groups the image versions. Defaults to the instance name.
+	Family string
+	// NameTemplate is a text/template rendered with Family, Version, Date and Instance. Defaults to DefaultBakeNameTemplate.
+	NameTemplate string
+	// Retain is the number of versions of the family kept, including the new one. Older versions are deleted, 0 keeps all.
+	Retain int `validate:"omitempty,gt=0"`
+	// Cleanup runs on the instance before it is stopped, see CleanupScript.
+	Cleanup func(instance *instances.Instance) error
+	// Metadata is added to the instance metadata copied to the image.
+	Metadata map[string]string
+	// Image properties default to the image the boot volume was created from.
+	OSType         types.OSType
+	SshKey         types.SshKeyType
+	HwFirmwareType types.HwFirmwareType
+	HwMachineType  types.HwMachineType
+	Architecture   types.ImageArchitectureType
+	// KeepStopped leaves a running instance stopped after the image is created.
+	KeepStopped bool
+	WaitSeconds int `validate:"omitempty,gt=0"`
+}
+
+// BakeResult describes a baked image.
+type BakeResult struct {
+	ImageID   string   `json:"image_id"`
+	Name      string   `json:"name"`
+	Family    string   `json:"family"`
+	Version   int      `json:"version"`
+	VolumeID  string   `json:"volume_id"`
+	Stopped   bool     `json:"stopped"`
+	Restarted bool     `json:"restarted"`
+	Pruned    []string `json:"pruned,omitempty"`
+}
+
+type bakeNameData struct {
+	Family   string
+	Version  int
+	Date     string
+	Instance string
+}
+
+// diskPrefixes are the prefixes of virtual disk device names.
+var diskPrefixes = []string{"xvd", "vd", "sd", "hd"}
+
+// BootVolume returns the boot volume of an instance among its volumes.
+// The API returns no boot index for attached volumes, so the boot volume is assumed to be
+// the bootable volume attached as the first disk, e.g. /dev/vda, as the first disk is the one
+// created with boot index 0. A non-bootable volume on the first disk is never returned.
+// An error is returned when no volume or several volumes of the instance match.
+func BootVolume(instanceID string, vols []volumes.Volume) (*volumes.Volume, error) {
+	var boot *volumes.Volume
+	for n := range vols {
+		v := &vols[n]
+		if !v.Bootable {
+			continue
+		}
+		for _, attachment := range v.Attachments {
+			if attachment.ServerID != instanceID || DiskIndex(attachment.Device) != 0 {
+				continue
+			}
+			if boot != nil && boot.ID != v.ID {
+				return nil, fmt.Errorf("instance %s has several bootable volumes on the first disk: %s and %s", instanceID, boot.ID, v.ID)
+			}
+			boot = v
+		}
+	}
+	if boot == nil {
+		return nil, fmt.Errorf("instance %s has no bootable volume on the first disk", instanceID)
+	}
+	return boot, nil
+}
+
+// DiskIndex returns the position of a disk device in device name order, e.g. 0 for /dev/vda and 26 for /dev/vdaa,
+// or -1 when the device is not a virtual disk.
+func DiskIndex(device string) int {
+	name := device[strings.LastIndex(device, "/")+1:]
+	for _, prefix := range diskPrefixes {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		letters := name[len(prefix):]
+		if letters == "" {
+			return -1
+		}
+		index := 0
+		for _, c := range letters {
+			if c < 'a' || c > 'z' {
+				return -1
+			}
+			index = index*26 + int(c-'a') + 1
+		}
+		return index - 1
+	}
+	return -1
+}
+
+// FamilyVersions returns the project images of a family ordered from the newest version.
+func FamilyVersions(c *gcorecloud.ServiceClient, family string) ([]Image, error) {
+	all, err := ListAll(c, ListOpts{Private: true, MetadataKV: map[string]string{FamilyMetadataKey: family}})
+	if err != nil {
+		return nil, err
+	}
+	var result []Image
+	for _, image := range all {
+		if imageMetadata(image, FamilyMetadataKey) == family {
+			result = append(result, image)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return imageVersion(result[i]) > imageVersion(result[j])
+	})
+	return result, nil
+}
+
+func imageMetadata(image Image, key string) string {
+	for _, m := range image.Metadata {
+		if m.Key == key {
+			return m.Value
+		}
+	}
+	return ""
+}
+
+func imageVersion(image Image) int {
+	version, _ := strconv.Atoi(imageMetadata(image, VersionMetadataKey))
+	return version
+}
+
+// BuildFromInstance creates a new version of an image from the boot volume of an instance.
+// The optional cleanup runs first, then a running instance is stopped, the image is created from the volume
+// and the instance is started again. The instance metadata is copied to the image together with the family,
+// version and source instance. Versions of the family beyond Retain are deleted afterwards.
+func BuildFromInstance(clients BakeClients, opts BakeOpts) (*BakeResult, error) {
+	if err := gcorecloud.ValidateStruct(clients); err != nil {
+		return nil, err
+	}
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	waitSeconds := opts.WaitSeconds
+	if waitSeconds == 0 {
+		waitSeconds = DefaultBakeWaitSeconds
+	}
+	nameTemplate := opts.NameTemplate
+	if nameTemplate == "" {
+		nameTemplate = DefaultBakeNameTemplate
+	}
+	tmpl, err := template.New("name").Option("missingkey=error").Parse(nameTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid name template: %w", err)
+	}
+
+	instance, err := instances.Get(clients.Instances, opts.InstanceID).Extract()
+	if err != nil {
+		return nil, err
+	}
+	instanceID := opts.InstanceID
+	vols, err := volumes.ListAll(clients.Volumes, volumes.ListOpts{InstanceID: &instanceID})
+	if err != nil {
+		return nil, err
+	}
+	volume, err := BootVolume(opts.InstanceID, vols)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BakeResult{Family: opts.Family, VolumeID: volume.ID}
+	if result.Family == "" {
+		result.Family = instance.Name
+	}
+	versions, err := FamilyVersions(clients.Images, result.Family)
+	if err != nil {
+		return nil, err
+	}
+	result.Version = 1
+	if len(versions) != 0 {
+		result.Version = imageVersion(versions[0]) + 1
+	}
+	var name bytes.Buffer
+	if err := tmpl.Execute(&name, bakeNameData{
+		Family:   result.Family,
+		Version:  result.Version,
+		Date:     time.Now().UTC().Format("20060102"),
+		Instance: instance.Name,
+	}); err != nil {
+		return nil, fmt.Errorf("invalid name template: %w", err)
+	}
+	result.Name = strings.TrimSpace(name.String())
+
+	createOpts, err := bakeCreateOpts(clients.Images, instance, volume, opts)
+	if err != nil {
+		return nil, err
+	}
+	createOpts.Name = result.Name
+	createOpts.Metadata[FamilyMetadataKey] = result.Family
+	createOpts.Metadata[VersionMetadataKey] = strconv.Itoa(result.Version)
+
+	if opts.Cleanup != nil {
+		if err := opts.Cleanup(instance); err != nil {
+			return nil, fmt.Errorf("cleanup failed: %w", err)
+		}
+	}
+
+	running := instance.Status == instanceStatusActive
+	if running {
+		if err := instanceAction(clients.InstanceActions, clients.Images, opts.InstanceID, instancetypes.InstanceActionTypeStop, waitSeconds); err != nil {
+			return nil, fmt.Errorf("cannot stop instance %s: %w", opts.InstanceID, err)
+		}
+		result.Stopped = true
+	} else if instance.Status != instanceStatusShutoff {
+		return nil, fmt.Errorf("instance %s is %s", opts.InstanceID, instance.Status)
+	}
+
+	result.ImageID, err = createImage(clients.Images, createOpts, waitSeconds)
+	if running && !opts.KeepStopped {
+		if startErr := instanceAction(clients.InstanceActions, clients.Images, opts.InstanceID, instancetypes.InstanceActionTypeStart, waitSeconds); startErr != nil {
+			if err != nil {
+				return result, fmt.Errorf("cannot create image: %w, cannot start instance %s: %s", err, opts.InstanceID, startErr)
+			}
+			return result, fmt.Errorf("image %s created, cannot start instance %s: %w", result.ImageID, opts.InstanceID, startErr)
+		}
+		result.Restarted = true
+	}
+	if err != nil {
+		return result, fmt.Errorf("cannot create image: %w", err)
+	}
+
+	if opts.Retain > 0 && len(versions) >= opts.Retain {
+		for _, image := range versions[opts.Retain-1:] {
+			if err := deleteImage(clients.Images, image.ID, waitSeconds); err != nil {
+				return result, fmt.Errorf("image %s created, cannot delete old version %s: %w", result.ImageID, image.ID, err)
+			}
+			result.Pruned = append(result.Pruned, image.ID)
+		}
+	}
+	return result, nil
+}
+
+// bakeCreateOpts builds image options from the options, falling back to the image the volume was created from.
+func bakeCreateOpts(c *gcorecloud.ServiceClient, instance *instances.Instance, volume *volumes.Volume, opts BakeOpts) (CreateOpts, error) {
+	createOpts := CreateOpts{
+		Source:         types.ImageSourceVolume,
+		VolumeID:       volume.ID,
+		OSType:         opts.OSType,
+		SshKey:         opts.SshKey,
+		HwFirmwareType: opts.HwFirmwareType,
+		HwMachineType:  opts.HwMachineType,
+		Architecture:   opts.Architecture,
+		Metadata:       map[string]string{},
+	}
+	if sourceID := volume.VolumeImageMetadata.ImageID; sourceID != "" {
+		source, err := Get(c, sourceID).Extract()
+		switch err.(type) {
+		case nil:
+			if createOpts.OSType == "" {
+				createOpts.OSType = types.OSType(source.OSType)
+			}
+			if createOpts.SshKey == "" {
+				createOpts.SshKey = types.SshKeyType(source.SshKey)
+			}
+			if createOpts.HwFirmwareType == "" {
+				createOpts.HwFirmwareType = types.HwFirmwareType(source.HwFirmwareType)
+			}
+			if createOpts.HwMachineType == "" {
+				createOpts.HwMachineType = types.HwMachineType(source.HwMachineType)
+			}
+			if createOpts.Architecture == "" {
+				createOpts.Architecture = types.ImageArchitectureType(source.Architecture)
+			}
+			createOpts.IsBaremetal = source.IsBaremetal
+		case gcorecloud.ErrDefault404:
+		default:
+			return createOpts, err
+		}
+	}
+	if createOpts.OSType == "" {
+		createOpts.OSType = types.OsLinux
+	}
+	if createOpts.SshKey == "" {
+		createOpts.SshKey = types.SshKeyAllow
+	}
+
+	for _, m := range instance.MetadataDetailed {
+		if !m.ReadOnly {
+			createOpts.Metadata[m.Key] = m.Value
+		}
+	}
+	for k, v := range opts.Metadata {
+		createOpts.Metadata[k] = v
+	}
+	createOpts.Metadata[SourceInstanceMetadataKey] = instance.ID
+	return createOpts, nil
+}
+
+// instanceAction runs an action and waits for its task with the v1 client, tasks have no v2 endpoint.
+func instanceAction(c, taskClient *gcorecloud.ServiceClient, instanceID string, action instancetypes.InstanceActionType, waitSeconds int) error {
+	results, err := instancesV2.Action(c, instanceID, instancesV2.ActionOpts{Action: action}).Extract()
+	if err != nil {
+		return err
+	}
+	if len(results.Tasks) == 0 {
+		return fmt.Errorf("wrong task response")
+	}
+	return tasks.WaitForFinishedTask(taskClient, results.Tasks[0], waitSeconds)
+}
+
+func createImage(c *gcorecloud.ServiceClient, opts CreateOpts, waitSeconds int) (string, error) {
+	results, err := Create(c, opts).Extract()
+	if err != nil {
+		return "", err
+	}
+	if len(results.Tasks) == 0 {
+		return "", fmt.Errorf("wrong task response")
+	}
+	imageID, err := tasks.WaitTaskAndReturnResult(c, results.Tasks[0], true, waitSeconds, func(task tasks.TaskID) (interface{}, error) {
+		taskInfo, err := tasks.Get(c, string(task)).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
+		}
+		return ExtractImageIDFromTask(taskInfo)
+	})
+	if err != nil {
+		return "", err
+	}
+	return imageID.(string), nil
+}
+
+func deleteImage(c *gcorecloud.ServiceClient, imageID string, waitSeconds int) error {
+	results, err := Delete(c, imageID).Extract()
+	if err != nil {
+		return err
+	}
+	if len(results.Tasks) == 0 {
+		return nil
+	}
+	return tasks.WaitForFinishedTask(c, results.Tasks[0], waitSeconds)
+}
@@ -38,25 +38,31 @@ type UpdateResult struct {
 }
 
 type Image struct {
-	ID            string                   `json:"id"`
-	Name          string                   `json:"name"`
-	Description   string                   `json:"description"`
-	Status        string                   `json:"status"`
-	Tags          []string                 `json:"tags"`
-	Visibility    string                   `json:"visibility"`
-	MinDisk       int                      `json:"min_disk"`
-	MinRAM        int                      `json:"min_ram"`
-	OsDistro      string                   `json:"os_distro"`
-	OsVersion     string                   `json:"os_version"`
-	DisplayOrder  int                      `json:"display_order"`
-	CreatedAt     gcorecloud.JSONRFC3339Z  `json:"created_at"`
-	UpdatedAt     *gcorecloud.JSONRFC3339Z `json:"updated_at"`
-	Size          int64                    `json:"size"`
-	CreatorTaskID *string                  `json:"creator_task_id"`
-	TaskID        *string                  `json:"task_id"`
-	Region        string                   `json:"region"`
-	DiskFormat    string                   `json:"disk_format"`
-	Metadata      []metadata.Metadata      `json:"metadata_detailed"`
+	ID             string                   `json:"id"`
+	Name           string                   `json:"name"`
+	Description    string                   `json:"description"`
+	Status         string                   `json:"status"`
+	Tags           []string                 `json:"tags"`
+	Visibility     string                   `json:"visibility"`
+	MinDisk        int                      `json:"min_disk"`
+	MinRAM         int                      `json:"min_ram"`
+	OsDistro       string                   `json:"os_distro"`
+	OsVersion      string                   `json:"os_version"`
+	DisplayOrder   int                      `json:"display_order"`
+	CreatedAt      gcorecloud.JSONRFC3339Z  `json:"created_at"`
+	UpdatedAt      *gcorecloud.JSONRFC3339Z `json:"updated_at"`
+	Size           int64                    `json:"size"`
+	CreatorTaskID  *string                  `json:"creator_task_id"`
+	TaskID         *string                  `json:"task_id"`
+	Region         string                   `json:"region"`
+	DiskFormat     string                   `json:"disk_format"`
+	Metadata       []metadata.Metadata      `json:"metadata_detailed"`
+	OSType         string                   `json:"os_type"`
+	HwFirmwareType string                   `json:"hw_firmware_type"`
+	HwMachineType  string                   `json:"hw_machine_type"`
+	Architecture   string                   `json:"architecture"`
+	SshKey         string                   `json:"ssh_key"`
+	IsBaremetal    *bool                    `json:"is_baremetal"`
 }
 
 // ImagePage is the page returned by a pager when traversing over a
@@ -0,0 +1,189 @@
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/image/v1/images"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
+	"github.com/G-Core/gcorelabscloud-go/gcore/volume/v1/volumes"
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+)
+
+func bakeClients() images.BakeClients {
+	return images.BakeClients{
+		Images:          fake.ServiceTokenClient("images", "v1"),
+		Instances:       fake.ServiceTokenClient("instances", "v1"),
+		InstanceActions: fake.ServiceTokenClient("instances", "v2"),
+		Volumes:         fake.ServiceTokenClient("volumes", "v1"),
+	}
+}
+
+func respondJSON(t *testing.T, method, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, method)
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, body)
+	}
+}
+
+// handleBake serves a bake of an instance in the given status and records the instance actions and deleted images.
+func handleBake(t *testing.T, status string) (*[]string, *[]string) {
+	var mu sync.Mutex
+	var actions, deleted []string
+	const tasksResponse = `{"tasks": ["50f53a35-42ed-40c4-82b2-5a37fb3e00bc"]}`
+
+	th.Mux.HandleFunc(fmt.Sprintf("/v1/instances/%d/%d/%s", fake.ProjectID, fake.RegionID, BakeInstanceID),
+		respondJSON(t, "GET", BakeInstanceResponse(status)))
+	th.Mux.HandleFunc(fmt.Sprintf("/v1/volumes/%d/%d", fake.ProjectID, fake.RegionID), func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, BakeInstanceID, r.URL.Query().Get("instance_id"))
+		respondJSON(t, "GET", BakeVolumesResponse)(w, r)
+	})
+	th.Mux.HandleFunc(prepareGetTestURL(BakeSourceID), respondJSON(t, "GET", BakeSourceImageResponse))
+	th.Mux.HandleFunc(prepareListTestURL(), func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			th.TestJSONRequest(t, r, BakeCreateRequest)
+			respondJSON(t, "POST", tasksResponse)(w, r)
+			return
+		}
+		respondJSON(t, "GET", BakeImagesResponse)(w, r)
+	})
+	th.Mux.HandleFunc(prepareDeleteTestURL(BakeOldImageID), func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		deleted = append(deleted, BakeOldImageID)
+		mu.Unlock()
+		respondJSON(t, "DELETE", tasksResponse)(w, r)
+	})
+	th.Mux.HandleFunc(fmt.Sprintf("/v2/instances/%d/%d/%s/action", fake.ProjectID, fake.RegionID, BakeInstanceID), func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Action string `json:"action"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		mu.Lock()
+		actions = append(actions, body.Action)
+		mu.Unlock()
+		respondJSON(t, "POST", tasksResponse)(w, r)
+	})
+	th.Mux.HandleFunc(fmt.Sprintf("/v1/tasks/%s", BakeTaskID), respondJSON(t, "GET", BakeTaskResponse))
+	return &actions, &deleted
+}
+
+func TestBuildFromInstance(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	actions, deleted := handleBake(t, "ACTIVE")
+
+	cleaned := false
+	result, err := images.BuildFromInstance(bakeClients(), images.BakeOpts{
+		InstanceID: BakeInstanceID,
+		Retain:     2,
+		Metadata:   map[string]string{"role": "golden"},
+		Cleanup: func(instance *instances.Instance) error {
+			require.Empty(t, *actions)
+			cleaned = true
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, cleaned)
+	require.Equal(t, &images.BakeResult{
+		ImageID:   BakeImageID,
+		Name:      "web-v3",
+		Family:    "web",
+		Version:   3,
+		VolumeID:  BakeVolumeID,
+		Stopped:   true,
+		Restarted: true,
+		Pruned:    []string{BakeOldImageID},
+	}, result)
+	require.Equal(t, []string{"stop", "start"}, *actions)
+	require.Equal(t, []string{BakeOldImageID}, *deleted)
+}
+
+func TestBuildFromInstanceStopped(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	actions, deleted := handleBake(t, "SHUTOFF")
+
+	result, err := images.BuildFromInstance(bakeClients(), images.BakeOpts{
+		InstanceID: BakeInstanceID,
+		Metadata:   map[string]string{"role": "golden"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, BakeImageID, result.ImageID)
+	require.False(t, result.Stopped)
+	require.False(t, result.Restarted)
+	require.Empty(t, *actions)
+	require.Empty(t, *deleted)
+}
+
+func TestBuildFromInstanceNameTemplate(t *testing.T) {
+	_, err := images.BuildFromInstance(bakeClients(), images.BakeOpts{
+		InstanceID:   BakeInstanceID,
+		NameTemplate: "{{.Family",
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid name template")
+}
+
+func TestBootVolume(t *testing.T) {
+	vols := []volumes.Volume{
+		{ID: "data", Bootable: true, Attachments: []volumes.Attachment{{ServerID: BakeInstanceID, Device: "/dev/vdb"}}},
+		{ID: "boot", Bootable: true, Attachments: []volumes.Attachment{{ServerID: BakeInstanceID, Device: "/dev/vda"}}},
+		{ID: "other", Bootable: true, Attachments: []volumes.Attachment{{ServerID: "other", Device: "/dev/sda"}}},
+	}
+	volume, err := images.BootVolume(BakeInstanceID, vols)
+	require.NoError(t, err)
+	require.Equal(t, "boot", volume.ID)
+
+	_, err = images.BootVolume(BakeInstanceID, vols[2:])
+	require.Error(t, err)
+
+	// the first device in lexicographic order is not necessarily the first disk
+	_, err = images.BootVolume(BakeInstanceID, []volumes.Volume{
+		{ID: "data", Bootable: true, Attachments: []volumes.Attachment{{ServerID: BakeInstanceID, Device: "/dev/vdaa"}}},
+		{ID: "cdrom", Bootable: true, Attachments: []volumes.Attachment{{ServerID: BakeInstanceID, Device: "/dev/sr0"}}},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no bootable volume on the first disk")
+
+	// a non-bootable volume on the first disk is never imaged
+	_, err = images.BootVolume(BakeInstanceID, []volumes.Volume{
+		{ID: "data", Attachments: []volumes.Attachment{{ServerID: BakeInstanceID, Device: "/dev/vda"}}},
+		{ID: "boot", Bootable: true, Attachments: []volumes.Attachment{{ServerID: BakeInstanceID, Device: "/dev/vdb"}}},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no bootable volume on the first disk")
+
+	_, err = images.BootVolume(BakeInstanceID, []volumes.Volume{
+		{ID: "boot", Bootable: true, Attachments: []volumes.Attachment{{ServerID: BakeInstanceID, Device: "/dev/vda"}}},
+		{ID: "boot-too", Bootable: true, Attachments: []volumes.Attachment{{ServerID: BakeInstanceID, Device: "/dev/sda"}}},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "several bootable volumes")
+}
+
+func TestDiskIndex(t *testing.T) {
+	for device, index := range map[string]int{
+		"/dev/vda":  0,
+		"/dev/sda":  0,
+		"/dev/xvda": 0,
+		"/dev/vdb":  1,
+		"/dev/vdz":  25,
+		"/dev/vdaa": 26,
+		"/dev/vdab": 27,
+		"/dev/sr0":  -1,
+		"/dev/vd":   -1,
+		"":          -1,
+	} {
+		require.Equal(t, index, images.DiskIndex(device), device)
+	}
+}
@@ -184,3 +184,125 @@ var (
 	}
 	ExpectedMetadataList = []metadata.Metadata{Metadata1, Metadata2}
 )
+
+const (
+	BakeInstanceID  = "a7e7e8d6-0bf7-4ac9-8170-831b47ee2ba9"
+	BakeVolumeID    = "726ecfcc-7fd0-4e30-a86e-7892524aa483"
+	BakeSourceID    = "f01fd9a0-9548-48ba-82dc-a8c8b2d6f2f1"
+	BakeImageID     = "8a7b3a5e-54e9-4d3a-b1e4-2b55a5a8a1f0"
+	BakeOldImageID  = "1c3c9c5b-1d52-4a0f-a46c-dcfa7c8b6a11"
+	BakeLastImageID = "9e0e7a2f-5c1d-4f7f-8a1a-6d1e2a3b4c5d"
+	BakeTaskID      = "50f53a35-42ed-40c4-82b2-5a37fb3e00bc"
+)
+
+// BakeInstanceResponse renders the baked instance in the given status.
+func BakeInstanceResponse(status string) string {
+	return fmt.Sprintf(`
+{
+  "instance_id": "%s",
+  "instance_name": "web",
+  "status": "%s",
+  "vm_state": "active",
+  "metadata_detailed": [
+    {"key": "team", "value": "frontend", "read_only": false},
+    {"key": "task_id", "value": "d1e1b1a7-7c0d-4b0a-8c53-1a1a8f1f2b3c", "read_only": true}
+  ],
+  "volumes": [
+    {"id": "%s", "delete_on_termination": false},
+    {"id": "0b6a7d5a-0b58-4b4c-9a8c-8a6f1a1b2c3d", "delete_on_termination": false}
+  ]
+}
+`, BakeInstanceID, status, BakeVolumeID)
+}
+
+var BakeVolumesResponse = fmt.Sprintf(`
+{
+  "count": 2,
+  "results": [
+    {
+      "id": "0b6a7d5a-0b58-4b4c-9a8c-8a6f1a1b2c3d",
+      "name": "web-data",
+      "bootable": false,
+      "attachments": [{"server_id": "%[1]s", "volume_id": "0b6a7d5a-0b58-4b4c-9a8c-8a6f1a1b2c3d", "device": "/dev/vdb"}]
+    },
+    {
+      "id": "%[2]s",
+      "name": "web-boot",
+      "bootable": true,
+      "attachments": [{"server_id": "%[1]s", "volume_id": "%[2]s", "device": "/dev/vda"}],
+      "volume_image_metadata": {"image_id": "%[3]s", "image_name": "ubuntu-24.04"}
+    }
+  ]
+}
+`, BakeInstanceID, BakeVolumeID, BakeSourceID)
+
+const BakeSourceImageResponse = `
+{
+  "id": "f01fd9a0-9548-48ba-82dc-a8c8b2d6f2f1",
+  "name": "ubuntu-24.04",
+  "status": "active",
+  "os_type": "linux",
+  "ssh_key": "allow",
+  "hw_firmware_type": "uefi",
+  "hw_machine_type": "q35",
+  "architecture": "x86_64",
+  "is_baremetal": false
+}
+`
+
+var BakeImagesResponse = fmt.Sprintf(`
+{
+  "count": 2,
+  "results": [
+    {
+      "id": "%s",
+      "name": "web-v1",
+      "metadata_detailed": [
+        {"key": "image_family", "value": "web", "read_only": false},
+        {"key": "image_version", "value": "1", "read_only": false}
+      ]
+    },
+    {
+      "id": "%s",
+      "name": "web-v2",
+      "metadata_detailed": [
+        {"key": "image_family", "value": "web", "read_only": false},
+        {"key": "image_version", "value": "2", "read_only": false}
+      ]
+    }
+  ]
+}
+`, BakeOldImageID, BakeLastImageID)
+
+const BakeCreateRequest = `
+{
+  "name": "web-v3",
+  "source": "volume",
+  "volume_id": "726ecfcc-7fd0-4e30-a86e-7892524aa483",
+  "os_type": "linux",
+  "ssh_key": "allow",
+  "hw_firmware_type": "uefi",
+  "hw_machine_type": "q35",
+  "architecture": "x86_64",
+  "is_baremetal": false,
+  "metadata": {
+    "team": "frontend",
+    "role": "golden",
+    "image_family": "web",
+    "image_version": "3",
+    "source_instance_id": "a7e7e8d6-0bf7-4ac9-8170-831b47ee2ba9"
+  }
+}
+`
+
+var BakeTaskResponse = fmt.Sprintf(`
+{
+  "id": "%s",
+  "task_type": "create_image",
+  "state": "FINISHED",
+  "created_on": "2025-06-25T08:42:42",
+  "created_resources": {
+    "images": ["%s"]
+  }
+}
+`, BakeTaskID, BakeImageID)
@@ -0,0 +1,151 @@
+package transfer
+
+import (
+	"fmt"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/image/v1/images"
+	"github.com/G-Core/gcorelabscloud-go/gcore/image/v1/images/types"
+	"github.com/G-Core/gcorelabscloud-go/gcore/region/v1/regions"
+	regiontypes "github.com/G-Core/gcorelabscloud-go/gcore/region/v1/types"
+)
+
+// ClientFactory returns a service client of a region.
+type ClientFactory func(regionID int) (*gcorecloud.ServiceClient, error)
+
+// Clients groups service clients used to copy images between regions.
+type Clients struct {
+	// Images returns images v1 clients, used to read the source image and to find existing copies.
+	Images ClientFactory `validate:"required"`
+	// Upload returns downloadimage v1 clients the copies are uploaded with.
+	Upload ClientFactory `validate:"required"`
+	// Regions is a regions v1 client. It is required when no target regions are set.
+	Regions *gcorecloud.ServiceClient
+}
+
+// CopyOpts represents options used to copy a project image to other regions.
+type CopyOpts struct {
+	ImageID        string `validate:"required"`
+	SourceRegionID int    `validate:"required"`
+	// URL is where the image file is downloaded from, e.g. the URL it was uploaded from or a staged object.
+	URL string `validate:"required,url"`
+	// RegionIDs defaults to every active region except the source one.
+	RegionIDs []int `validate:"omitempty,dive,gt=0"`
+	// Name defaults to the source image name. Regions with an image of this name are skipped.
+	Name        string
+	WaitSeconds int `validate:"omitempty,gt=0"`
+}
+
+// CopyResult describes the copy of an image in a region.
+type CopyResult struct {
+	RegionID int    `json:"region_id"`
+	ImageID  string `json:"image_id,omitempty"`
+	// Skipped is set when the region already has an image with the same name.
+	Skipped bool   `json:"skipped"`
+	Error   string `json:"error,omitempty"`
+}
+
+// UploadOptsFromImage builds upload options recreating an image from the URL.
+func UploadOptsFromImage(image *images.Image, url string) images.UploadOpts {
+	opts := images.UploadOpts{
+		Name:           image.Name,
+		URL:            url,
+		OsDistro:       image.OsDistro,
+		OsVersion:      image.OsVersion,
+		OSType:         types.OSType(image.OSType),
+		HwFirmwareType: types.HwFirmwareType(image.HwFirmwareType),
+		HwMachineType:  types.HwMachineType(image.HwMachineType),
+		Architecture:   types.ImageArchitectureType(image.Architecture),
+		SshKey:         types.SshKeyType(image.SshKey),
+		IsBaremetal:    image.IsBaremetal,
+	}
+	for _, m := range image.Metadata {
+		if m.ReadOnly {
+			continue
+		}
+		if opts.Metadata == nil {
+			opts.Metadata = map[string]string{}
+		}
+		opts.Metadata[m.Key] = m.Value
+	}
+	return opts
+}
+
+// Copy uploads a project image to other regions with the metadata of the source image.
+// Images cannot be downloaded from the API, so the copies are uploaded from the URL.
+// Regions are processed one by one, a failed region is reported in its result and does not stop the others.
+func Copy(clients Clients, opts CopyOpts) ([]CopyResult, error) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	if err := gcorecloud.ValidateStruct(clients); err != nil {
+		return nil, err
+	}
+	if len(opts.RegionIDs) == 0 && clients.Regions == nil {
+		return nil, fmt.Errorf("regions client is required when no target regions are set")
+	}
+	waitSeconds := opts.WaitSeconds
+	if waitSeconds == 0 {
+		waitSeconds = DefaultWaitSeconds
+	}
+
+	sourceClient, err := clients.Images(opts.SourceRegionID)
+	if err != nil {
+		return nil, err
+	}
+	source, err := images.Get(sourceClient, opts.ImageID).Extract()
+	if err != nil {
+		return nil, err
+	}
+	uploadOpts := UploadOptsFromImage(source, opts.URL)
+	if opts.Name != "" {
+		uploadOpts.Name = opts.Name
+	}
+
+	regionIDs := opts.RegionIDs
+	if len(regionIDs) == 0 {
+		all, err := regions.ListAll(clients.Regions, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, region := range all {
+			if region.State == regiontypes.RegionStateActive && region.ID != opts.SourceRegionID {
+				regionIDs = append(regionIDs, region.ID)
+			}
+		}
+	}
+
+	results := make([]CopyResult, 0, len(regionIDs))
+	for _, regionID := range regionIDs {
+		result := CopyResult{RegionID: regionID}
+		imageID, skipped, err := copyTo(clients, regionID, uploadOpts, waitSeconds)
+		result.ImageID, result.Skipped = imageID, skipped
+		if err != nil {
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func copyTo(clients Clients, regionID int, opts images.UploadOpts, waitSeconds int) (string, bool, error) {
+	c, err := clients.Images(regionID)
+	if err != nil {
+		return "", false, err
+	}
+	existing, err := images.ListAll(c, images.ListOpts{Private: true})
+	if err != nil {
+		return "", false, err
+	}
+	for _, image := range existing {
+		if image.Name == opts.Name {
+			return image.ID, true, nil
+		}
+	}
+	c, err = clients.Upload(regionID)
+	if err != nil {
+		return "", false, err
+	}
+	imageID, err := uploadImage(c, opts, waitSeconds)
+	return imageID, false, err
+}
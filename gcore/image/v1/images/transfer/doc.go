@@ -0,0 +1,52 @@
+/*
+Package transfer uploads local image files through an S3 compatible staging storage and copies images between regions
+
+The images API only creates images from a URL. Upload stages the file with a presigned multipart upload, verifying
+every part checksum, and creates the image from the staged object. An interrupted upload is resumed with the state file.
+
+Example to upload a local file
+
+	result, err := transfer.Upload(ctx, downloadImageClient, transfer.UploadOpts{
+		Path:       "ubuntu-24.04-golden.qcow2",
+		Presigner:  presigner,
+		StatePath:  "ubuntu-24.04-golden.qcow2.upload",
+		// The staged object is kept to copy the image to other regions.
+		KeepStaged: true,
+		Image: images.UploadOpts{
+			Name:           "ubuntu-24.04-golden",
+			OSType:         types.OsLinux,
+			HwFirmwareType: types.HwFirmwareUEFI,
+			Architecture:   types.ArchitectureX8664,
+		},
+		Progress: func(p transfer.Progress) {
+			fmt.Printf("%s %d/%d\n", p.Phase, p.Done, p.Total)
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+Example to copy the image to every other region
+
+	results, err := transfer.Copy(transfer.Clients{
+		Images: func(regionID int) (*gcorecloud.ServiceClient, error) {
+			return gcore.ClientServiceFromProvider(provider, gcorecloud.EndpointOpts{
+				Name: "images", Region: regionID, Project: projectID, Version: "v1",
+			})
+		},
+		Upload: func(regionID int) (*gcorecloud.ServiceClient, error) {
+			return gcore.ClientServiceFromProvider(provider, gcorecloud.EndpointOpts{
+				Name: "downloadimage", Region: regionID, Project: projectID, Version: "v1",
+			})
+		},
+		Regions: regionsClient,
+	}, transfer.CopyOpts{
+		ImageID:        result.ImageID,
+		SourceRegionID: 76,
+		URL:            result.URL,
+	})
+	if err != nil {
+		panic(err)
+	}
+*/
+package transfer
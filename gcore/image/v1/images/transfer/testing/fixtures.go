@@ -0,0 +1,112 @@
+package testing
+
+import "fmt"
+
+const (
+	ImageID  = "f01fd9a0-9548-48ba-82dc-a8c8b2d6f2f1"
+	CopyID   = "2a7ac4ba-4d6c-4ce2-9b1c-3c4f2f7f0e5a"
+	TaskID   = "50f53a35-42ed-40c4-82b2-5a37fb3e00bc"
+	UploadID = "2~hWkJ6FZ1uJ5dY3cM0vI2lxFqS1v6mUj"
+)
+
+// TaskResponse renders a finished image upload task creating the image.
+func TaskResponse(imageID string) string {
+	return fmt.Sprintf(`
+{
+  "id": "%s",
+  "task_type": "upload_image",
+  "state": "FINISHED",
+  "error": null,
+  "client_id": 2,
+  "project_id": 1,
+  "created_on": "2025-06-25T08:42:42",
+  "created_resources": {
+    "images": ["%s"]
+  }
+}
+`, TaskID, imageID)
+}
+
+const TasksResponse = `
+{
+  "tasks": [
+    "50f53a35-42ed-40c4-82b2-5a37fb3e00bc"
+  ]
+}
+`
+
+const ImageResponse = `
+{
+  "id": "f01fd9a0-9548-48ba-82dc-a8c8b2d6f2f1",
+  "name": "ubuntu-golden",
+  "status": "active",
+  "visibility": "private",
+  "min_disk": 3,
+  "min_ram": 0,
+  "os_distro": "ubuntu",
+  "os_version": "24.04",
+  "os_type": "linux",
+  "hw_firmware_type": "uefi",
+  "hw_machine_type": "q35",
+  "architecture": "x86_64",
+  "ssh_key": "allow",
+  "is_baremetal": false,
+  "created_at": "2025-06-25T08:42:42+0000",
+  "size": 3221225472,
+  "region": "Luxembourg",
+  "disk_format": "qcow2",
+  "metadata_detailed": [
+    {"key": "image_id", "value": "f01fd9a0-9548-48ba-82dc-a8c8b2d6f2f1", "read_only": true},
+    {"key": "team", "value": "ml", "read_only": false}
+  ]
+}
+`
+
+// ImagesResponse lists a project image with the given name.
+func ImagesResponse(name string) string {
+	return fmt.Sprintf(`
+{
+  "count": 1,
+  "results": [
+    {
+      "id": "%s",
+      "name": "%s",
+      "status": "active",
+      "visibility": "private",
+      "created_at": "2025-06-25T08:42:42+0000"
+    }
+  ]
+}
+`, CopyID, name)
+}
+
+const RegionsResponse = `
+{
+  "count": 4,
+  "results": [
+    {"id": 1, "display_name": "Luxembourg", "state": "ACTIVE", "endpoint_type": "public", "created_on": "2020-04-10T11:37:58", "keystone_id": 1, "keystone_name": "ED-10"},
+    {"id": 2, "display_name": "Manassas", "state": "ACTIVE", "endpoint_type": "public", "created_on": "2020-04-10T11:37:58", "keystone_id": 2, "keystone_name": "ED-11"},
+    {"id": 3, "display_name": "Frankfurt", "state": "ACTIVE", "endpoint_type": "public", "created_on": "2020-04-10T11:37:58", "keystone_id": 3, "keystone_name": "ED-12"},
+    {"id": 4, "display_name": "Paris", "state": "MAINTENANCE", "endpoint_type": "public", "created_on": "2020-04-10T11:37:58", "keystone_id": 4, "keystone_name": "ED-13"}
+  ]
+}
+`
+
+const CopyRequest = `
+{
+  "name": "ubuntu-golden",
+  "url": "https://s3.example.com/staging/ubuntu-golden.qcow2",
+  "os_distro": "ubuntu",
+  "os_version": "24.04",
+  "os_type": "linux",
+  "hw_firmware_type": "uefi",
+  "hw_machine_type": "q35",
+  "architecture": "x86_64",
+  "ssh_key": "allow",
+  "is_baremetal": false,
+  "cow_format": false,
+  "metadata": {
+    "team": "ml"
+  }
+}
+`
@@ -0,0 +1,308 @@
+package testing
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" // nolint: gosec
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore"
+	"github.com/G-Core/gcorelabscloud-go/gcore/image/v1/images"
+	"github.com/G-Core/gcorelabscloud-go/gcore/image/v1/images/transfer"
+	"github.com/G-Core/gcorelabscloud-go/gcore/image/v1/images/types"
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+)
+
+const stagedURL = "https://s3.example.com/staging/ubuntu-golden.qcow2"
+
+// presigner stages parts on the test server.
+type presigner struct {
+	mu       sync.Mutex
+	creates  int
+	failPart int
+	wrongTag bool
+	puts     map[int]int
+	parts    map[int][]byte
+	complete []transfer.Part
+	deleted  []string
+}
+
+func newPresigner() *presigner {
+	p := &presigner{puts: map[int]int{}, parts: map[int][]byte{}}
+	th.Mux.HandleFunc("/staging/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		number, _ := strconv.Atoi(r.URL.Query().Get("partNumber"))
+		body, _ := io.ReadAll(r.Body)
+		sum := md5.Sum(body) // nolint: gosec
+		etag := hex.EncodeToString(sum[:])
+		p.mu.Lock()
+		p.puts[number]++
+		p.parts[number] = body
+		if p.wrongTag {
+			etag = strings.Repeat("0", 32)
+		}
+		p.mu.Unlock()
+		w.Header().Set("ETag", fmt.Sprintf("%q", etag))
+		w.WriteHeader(http.StatusOK)
+	})
+	return p
+}
+
+func (p *presigner) Create(_ context.Context, key string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.creates++
+	return UploadID, nil
+}
+
+func (p *presigner) PartURL(_ context.Context, key, uploadID string, number int) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if number == p.failPart {
+		p.failPart = 0
+		return "", errors.New("connection reset")
+	}
+	return fmt.Sprintf("%s/staging/%s?uploadId=%s&partNumber=%d", th.Server.URL, key, uploadID, number), nil
+}
+
+func (p *presigner) Complete(_ context.Context, key, uploadID string, parts []transfer.Part) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.complete = parts
+	return stagedURL, nil
+}
+
+func (p *presigner) Delete(_ context.Context, key string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.deleted = append(p.deleted, key)
+	return nil
+}
+
+func (p *presigner) staged() []byte {
+	var b bytes.Buffer
+	for _, part := range p.complete {
+		b.Write(p.parts[part.Number])
+	}
+	return b.Bytes()
+}
+
+func writeFile(t *testing.T, size int) string {
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	path := filepath.Join(t.TempDir(), "ubuntu-golden.qcow2")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func handleImageUpload(t *testing.T, regionID int, imageID string, requests *[]map[string]interface{}) {
+	th.Mux.HandleFunc(fmt.Sprintf("/v1/downloadimage/%d/%d", fake.ProjectID, regionID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		*requests = append(*requests, body)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, TasksResponse)
+	})
+	th.Mux.HandleFunc(fmt.Sprintf("/v1/tasks/%s", TaskID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, TaskResponse(imageID))
+	})
+}
+
+func uploadOpts(path string, p transfer.Presigner) transfer.UploadOpts {
+	return transfer.UploadOpts{
+		Path:      path,
+		Presigner: p,
+		PartSize:  transfer.MinPartSize,
+		Image: images.UploadOpts{
+			Name:           "ubuntu-golden",
+			OSType:         types.OsLinux,
+			HwFirmwareType: types.HwFirmwareUEFI,
+			Architecture:   types.ArchitectureX8664,
+			Metadata:       map[string]string{"team": "ml"},
+		},
+	}
+}
+
+func TestUpload(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	p := newPresigner()
+	var requests []map[string]interface{}
+	handleImageUpload(t, fake.RegionID, ImageID, &requests)
+
+	path := writeFile(t, transfer.MinPartSize+100)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var progress []transfer.Progress
+	opts := uploadOpts(path, p)
+	opts.Progress = func(p transfer.Progress) { progress = append(progress, p) }
+
+	result, err := transfer.Upload(context.Background(), fake.ServiceTokenClient("downloadimage", "v1"), opts)
+	require.NoError(t, err)
+	require.Equal(t, ImageID, result.ImageID)
+	require.Equal(t, stagedURL, result.URL)
+	require.Equal(t, int64(len(data)), result.Size)
+	require.Len(t, p.complete, 2)
+	require.Equal(t, data, p.staged())
+	require.Equal(t, []string{"ubuntu-golden.qcow2"}, p.deleted)
+
+	require.Len(t, requests, 1)
+	require.Equal(t, stagedURL, requests[0]["url"])
+	require.Equal(t, "uefi", requests[0]["hw_firmware_type"])
+	require.Equal(t, map[string]interface{}{"team": "ml", transfer.ChecksumMetadataKey: result.SHA256}, requests[0]["metadata"])
+	require.Equal(t, map[string]string{"team": "ml"}, opts.Image.Metadata)
+
+	require.Equal(t, []transfer.Progress{
+		{Phase: transfer.PhaseUpload, Done: transfer.MinPartSize, Total: int64(len(data))},
+		{Phase: transfer.PhaseUpload, Done: int64(len(data)), Total: int64(len(data))},
+		{Phase: transfer.PhaseImport, Total: 1},
+		{Phase: transfer.PhaseImport, Done: 1, Total: 1},
+	}, progress)
+}
+
+func TestUploadResume(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	p := newPresigner()
+	p.failPart = 2
+	var requests []map[string]interface{}
+	handleImageUpload(t, fake.RegionID, ImageID, &requests)
+
+	path := writeFile(t, 2*transfer.MinPartSize+10)
+	opts := uploadOpts(path, p)
+	opts.StatePath = path + ".upload"
+	client := fake.ServiceTokenClient("downloadimage", "v1")
+
+	_, err := transfer.Upload(context.Background(), client, opts)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "connection reset")
+	require.FileExists(t, opts.StatePath)
+	require.Empty(t, requests)
+
+	result, err := transfer.Upload(context.Background(), client, opts)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Resumed)
+	require.Equal(t, 1, p.creates)
+	require.Equal(t, map[int]int{1: 1, 2: 1, 3: 1}, p.puts)
+	require.Len(t, p.complete, 3)
+	_, err = os.Stat(opts.StatePath)
+	require.True(t, os.IsNotExist(err))
+	require.Len(t, requests, 1)
+}
+
+func TestUploadChecksumMismatch(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	p := newPresigner()
+	p.wrongTag = true
+	path := writeFile(t, 100)
+
+	_, err := transfer.Upload(context.Background(), fake.ServiceTokenClient("downloadimage", "v1"), uploadOpts(path, p))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "checksum mismatch")
+	require.Nil(t, p.complete)
+}
+
+func regionalClient(name string) transfer.ClientFactory {
+	provider := fake.ServiceTokenClient(name, "v1").ProviderClient
+	return func(regionID int) (*gcorecloud.ServiceClient, error) {
+		return gcore.ClientServiceFromProvider(provider, gcorecloud.EndpointOpts{
+			Name:    name,
+			Region:  regionID,
+			Project: fake.ProjectID,
+			Version: "v1",
+		})
+	}
+}
+
+func TestCopy(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/regions", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, RegionsResponse)
+	})
+	th.Mux.HandleFunc(fmt.Sprintf("/v1/images/%d/1/%s", fake.ProjectID, ImageID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, ImageResponse)
+	})
+	for regionID, name := range map[int]string{2: "debian-12", 3: "ubuntu-golden"} {
+		name := name
+		th.Mux.HandleFunc(fmt.Sprintf("/v1/images/%d/%d", fake.ProjectID, regionID), func(w http.ResponseWriter, r *http.Request) {
+			th.TestMethod(t, r, "GET")
+			require.Equal(t, "true", r.URL.Query().Get("private"))
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = fmt.Fprint(w, ImagesResponse(name))
+		})
+	}
+	var requests []map[string]interface{}
+	handleImageUpload(t, 2, ImageID, &requests)
+
+	results, err := transfer.Copy(transfer.Clients{
+		Images:  regionalClient("images"),
+		Upload:  regionalClient("downloadimage"),
+		Regions: fake.ServiceTokenClient("regions", "v1"),
+	}, transfer.CopyOpts{
+		ImageID:        ImageID,
+		SourceRegionID: 1,
+		URL:            stagedURL,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []transfer.CopyResult{
+		{RegionID: 2, ImageID: ImageID},
+		{RegionID: 3, ImageID: CopyID, Skipped: true},
+	}, results)
+
+	require.Len(t, requests, 1)
+	actual, err := json.Marshal(requests[0])
+	require.NoError(t, err)
+	require.JSONEq(t, CopyRequest, string(actual))
+}
+
+func TestCopyRequiresRegions(t *testing.T) {
+	_, err := transfer.Copy(transfer.Clients{
+		Images: regionalClient("images"),
+		Upload: regionalClient("downloadimage"),
+	}, transfer.CopyOpts{
+		ImageID:        ImageID,
+		SourceRegionID: 1,
+		URL:            stagedURL,
+	})
+	require.Error(t, err)
+	th.AssertEquals(t, "regions client is required when no target regions are set", err.Error())
+}
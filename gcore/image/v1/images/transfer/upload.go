@@ -0,0 +1,342 @@
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" // nolint: gosec
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/image/v1/images"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+)
+
+const (
+	// DefaultPartSize is the size of uploaded parts.
+	DefaultPartSize = 64 << 20
+	// MinPartSize is the smallest part size accepted by S3 compatible storages.
+	MinPartSize = 5 << 20
+	// DefaultWaitSeconds is how long an image upload task is waited for.
+	DefaultWaitSeconds = 3600
+	// ChecksumMetadataKey is the image metadata key the SHA-256 checksum of the file is stored in.
+	ChecksumMetadataKey = "checksum_sha256"
+)
+
+const (
+	// PhaseUpload reports bytes of the file staged.
+	PhaseUpload = "upload"
+	// PhaseImport reports the image upload task, Done is set once it is finished.
+	PhaseImport = "import"
+)
+
+// Part is an uploaded part of a staged file.
+type Part struct {
+	Number int    `json:"number"`
+	Size   int64  `json:"size"`
+	ETag   string `json:"etag"`
+	MD5    string `json:"md5"`
+}
+
+// Presigner stages files in an S3 compatible storage with presigned multipart uploads.
+type Presigner interface {
+	// Create starts a multipart upload of the object and returns its upload ID.
+	Create(ctx context.Context, key string) (string, error)
+	// PartURL returns a URL a part is uploaded to with PUT. Parts are numbered from 1.
+	PartURL(ctx context.Context, key, uploadID string, number int) (string, error)
+	// Complete assembles the parts and returns a URL the API downloads the object from.
+	Complete(ctx context.Context, key, uploadID string, parts []Part) (string, error)
+	// Delete removes the staged object.
+	Delete(ctx context.Context, key string) error
+}
+
+// Progress describes how far an upload is.
+type Progress struct {
+	Phase string `json:"phase"`
+	Done  int64  `json:"done"`
+	Total int64  `json:"total"`
+}
+
+// UploadOpts represents options used to upload a local file as an image.
+type UploadOpts struct {
+	// Path is the local image file.
+	Path string `validate:"required"`
+	// Image is the image to create. URL is set to the staged object.
+	Image     images.UploadOpts `validate:"-"`
+	Presigner Presigner         `validate:"required"`
+	// Key is the staged object key. Defaults to the file name.
+	Key string
+	// PartSize defaults to DefaultPartSize.
+	PartSize int64 `validate:"omitempty,gte=5242880"`
+	// StatePath is where the upload state is saved after every part. An interrupted upload with the same
+	// file and part size is resumed from it. The upload is not resumable without it.
+	StatePath string
+	// HTTPClient is used to upload parts. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+	Progress   func(Progress)
+	// KeepStaged disables deleting the staged object once the image is created.
+	KeepStaged  bool
+	WaitSeconds int `validate:"omitempty,gt=0"`
+}
+
+// UploadResult describes an uploaded image.
+type UploadResult struct {
+	ImageID string `json:"image_id"`
+	URL     string `json:"url"`
+	SHA256  string `json:"sha256"`
+	Size    int64  `json:"size"`
+	// Resumed is the number of parts reused from the saved state.
+	Resumed int `json:"resumed"`
+}
+
+// State is the saved state of a multipart upload.
+type State struct {
+	Key      string    `json:"key"`
+	UploadID string    `json:"upload_id"`
+	Size     int64     `json:"size"`
+	ModTime  time.Time `json:"mod_time"`
+	PartSize int64     `json:"part_size"`
+	Parts    []Part    `json:"parts"`
+}
+
+func (s *State) matches(key string, info os.FileInfo, partSize int64) bool {
+	return s.Key == key && s.UploadID != "" && s.Size == info.Size() && s.ModTime.Equal(info.ModTime()) && s.PartSize == partSize
+}
+
+func loadState(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("cannot read upload state %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+func saveState(path string, s *State) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Upload stages a local file with a presigned multipart upload and creates an image from the staged object.
+// Every part is sent with its MD5 checksum and the ETag returned by the storage is checked against it.
+// The SHA-256 checksum of the whole file is stored in the image metadata under ChecksumMetadataKey.
+// With StatePath set, parts already uploaded by an interrupted call are verified against the file and skipped.
+func Upload(ctx context.Context, c *gcorecloud.ServiceClient, opts UploadOpts) (*UploadResult, error) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	if opts.Image.Name == "" {
+		return nil, fmt.Errorf("image name is required")
+	}
+	partSize := opts.PartSize
+	if partSize == 0 {
+		partSize = DefaultPartSize
+	}
+	key := opts.Key
+	if key == "" {
+		key = filepath.Base(opts.Path)
+	}
+	waitSeconds := opts.WaitSeconds
+	if waitSeconds == 0 {
+		waitSeconds = DefaultWaitSeconds
+	}
+	progress := opts.Progress
+	if progress == nil {
+		progress = func(Progress) {}
+	}
+
+	f, err := os.Open(opts.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	var state *State
+	if opts.StatePath != "" {
+		if state, err = loadState(opts.StatePath); err != nil {
+			return nil, err
+		}
+	}
+	if state == nil || !state.matches(key, info, partSize) {
+		uploadID, err := opts.Presigner.Create(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("cannot start upload of %s: %w", key, err)
+		}
+		state = &State{Key: key, UploadID: uploadID, Size: info.Size(), ModTime: info.ModTime(), PartSize: partSize}
+	}
+
+	u := uploader{client: opts.HTTPClient, presigner: opts.Presigner, state: state}
+	if u.client == nil {
+		u.client = http.DefaultClient
+	}
+	checksum := sha256.New()
+	result := &UploadResult{Size: info.Size()}
+	buf := make([]byte, partSize)
+	count := int((info.Size() + partSize - 1) / partSize)
+	if count == 0 {
+		count = 1
+	}
+	var done int64
+	for number := 1; number <= count; number++ {
+		n, err := io.ReadFull(f, buf)
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		chunk := buf[:n]
+		checksum.Write(chunk)
+		reused, err := u.part(ctx, number, chunk)
+		if err != nil {
+			return nil, err
+		}
+		if reused {
+			result.Resumed++
+		}
+		if opts.StatePath != "" {
+			if err := saveState(opts.StatePath, state); err != nil {
+				return nil, fmt.Errorf("cannot save upload state: %w", err)
+			}
+		}
+		done += int64(n)
+		progress(Progress{Phase: PhaseUpload, Done: done, Total: info.Size()})
+	}
+
+	result.SHA256 = hex.EncodeToString(checksum.Sum(nil))
+	result.URL, err = opts.Presigner.Complete(ctx, key, state.UploadID, state.Parts)
+	if err != nil {
+		return nil, fmt.Errorf("cannot complete upload of %s: %w", key, err)
+	}
+	if opts.StatePath != "" {
+		_ = os.Remove(opts.StatePath)
+	}
+
+	imageOpts := opts.Image
+	imageOpts.URL = result.URL
+	imageOpts.Metadata = make(map[string]string, len(opts.Image.Metadata)+1)
+	for k, v := range opts.Image.Metadata {
+		imageOpts.Metadata[k] = v
+	}
+	imageOpts.Metadata[ChecksumMetadataKey] = result.SHA256
+
+	progress(Progress{Phase: PhaseImport, Total: 1})
+	result.ImageID, err = uploadImage(c, imageOpts, waitSeconds)
+	if err != nil {
+		return result, err
+	}
+	progress(Progress{Phase: PhaseImport, Done: 1, Total: 1})
+
+	if !opts.KeepStaged {
+		if err := opts.Presigner.Delete(ctx, key); err != nil {
+			return result, fmt.Errorf("image %s created, cannot delete staged object %s: %w", result.ImageID, key, err)
+		}
+	}
+	return result, nil
+}
+
+type uploader struct {
+	client    *http.Client
+	presigner Presigner
+	state     *State
+}
+
+// part uploads a part unless the state has it with the same checksum.
+func (u uploader) part(ctx context.Context, number int, chunk []byte) (bool, error) {
+	sum := md5.Sum(chunk) // nolint: gosec
+	md5Hex := hex.EncodeToString(sum[:])
+	index := number - 1
+	if index < len(u.state.Parts) {
+		saved := u.state.Parts[index]
+		if saved.Number == number && saved.MD5 == md5Hex {
+			return true, nil
+		}
+		u.state.Parts = u.state.Parts[:index]
+	}
+
+	url, err := u.presigner.PartURL(ctx, u.state.Key, u.state.UploadID, number)
+	if err != nil {
+		return false, fmt.Errorf("cannot presign part %d: %w", number, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(chunk))
+	if err != nil {
+		return false, err
+	}
+	req.ContentLength = int64(len(chunk))
+	req.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString(sum[:]))
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("cannot upload part %d: %w", number, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return false, fmt.Errorf("cannot upload part %d: %s", number, resp.Status)
+	}
+	etag := resp.Header.Get("ETag")
+	if err := verifyETag(etag, md5Hex); err != nil {
+		return false, fmt.Errorf("part %d: %w", number, err)
+	}
+	u.state.Parts = append(u.state.Parts, Part{Number: number, Size: int64(len(chunk)), ETag: etag, MD5: md5Hex})
+	return false, nil
+}
+
+// verifyETag compares an ETag with the part MD5. ETags which are not an MD5, e.g. of encrypted objects, are accepted.
+func verifyETag(etag, md5Hex string) error {
+	value := strings.Trim(etag, `"`)
+	if len(value) != hex.EncodedLen(md5.Size) {
+		return nil
+	}
+	if _, err := hex.DecodeString(value); err != nil {
+		return nil
+	}
+	if !strings.EqualFold(value, md5Hex) {
+		return fmt.Errorf("checksum mismatch, sent %s, stored %s", md5Hex, value)
+	}
+	return nil
+}
+
+func uploadImage(c *gcorecloud.ServiceClient, opts images.UploadOpts, waitSeconds int) (string, error) {
+	results, err := images.Upload(c, opts).Extract()
+	if err != nil {
+		return "", err
+	}
+	if len(results.Tasks) == 0 {
+		return "", fmt.Errorf("wrong task response")
+	}
+	imageID, err := tasks.WaitTaskAndReturnResult(c, results.Tasks[0], true, waitSeconds, func(task tasks.TaskID) (interface{}, error) {
+		taskInfo, err := tasks.Get(c, string(task)).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
+		}
+		return images.ExtractImageIDFromTask(taskInfo)
+	})
+	if err != nil {
+		return "", err
+	}
+	return imageID.(string), nil
+}
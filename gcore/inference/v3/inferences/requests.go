@@ -103,6 +103,22 @@ func (opts UpdateInferenceDeploymentOpts) ToRegistryCredentialUpdateMap() (map[s
 	return gcorecloud.BuildRequestBody(opts, "")
 }
 
+// KeepCommand represents update options which do not send a nil command, so the deployment keeps its command.
+// UpdateInferenceDeploymentOpts sends a nil command as null.
+type KeepCommand UpdateInferenceDeploymentOpts
+
+// ToRegistryCredentialUpdateMap builds a request body from KeepCommand.
+func (opts KeepCommand) ToRegistryCredentialUpdateMap() (map[string]interface{}, error) {
+	b, err := UpdateInferenceDeploymentOpts(opts).ToRegistryCredentialUpdateMap()
+	if err != nil {
+		return nil, err
+	}
+	if opts.Command == nil {
+		delete(b, "command")
+	}
+	return b, nil
+}
+
 // UpdateInferenceDeployment update existing inference deployment.
 func UpdateInferenceDeployment(c *gcorecloud.ServiceClient, name string, opts UpdateInferenceDeploymentOptsBuilder) (r tasks.Result) {
 	url := updateURL(c, name)
@@ -0,0 +1,349 @@
+package inferences
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+)
+
+const (
+	// StatusActive is the status of a deployed inference.
+	StatusActive = "ACTIVE"
+	// DefaultRolloutTimeout is how long a rollout waits for the deployment to become healthy.
+	DefaultRolloutTimeout = 15 * time.Minute
+	// DefaultRolloutPollInterval is how often a rollout polls the deployment.
+	DefaultRolloutPollInterval = 10 * time.Second
+	// DefaultHealthyChecks is how many consecutive healthy polls finish a rollout.
+	DefaultHealthyChecks = 3
+)
+
+// failedStatuses are deployment statuses ending a rollout without waiting for the deadline.
+var failedStatuses = map[string]bool{"ERROR": true, "FAILED": true}
+
+// CanaryOpts represents options used to verify an update on a temporary deployment before the rollout.
+type CanaryOpts struct {
+	// RegionID is the region the canary runs in with a single container.
+	RegionID int `validate:"required"`
+	// Name defaults to the deployment name followed by -canary.
+	Name string
+}
+
+// RolloutOpts represents options used to roll out an update of an inference deployment.
+type RolloutOpts struct {
+	// Update is applied over the current spec, fields left empty keep their current values.
+	Update UpdateInferenceDeploymentOpts
+	// Canary runs the updated spec as a separate deployment first. The rollout stops if the canary is not healthy.
+	Canary *CanaryOpts
+	// Timeout is the deadline for the deployment to become healthy. Defaults to DefaultRolloutTimeout.
+	Timeout time.Duration `validate:"omitempty,gt=0"`
+	// PollInterval defaults to DefaultRolloutPollInterval.
+	PollInterval time.Duration `validate:"omitempty,gt=0"`
+	// HealthyChecks defaults to DefaultHealthyChecks.
+	HealthyChecks int `validate:"omitempty,gt=0"`
+	// DisableRollback keeps the failed spec applied.
+	DisableRollback bool
+}
+
+// RolloutResult describes a rollout.
+type RolloutResult struct {
+	Name string `json:"name"`
+	// Previous is the spec snapshot taken before the update.
+	Previous UpdateInferenceDeploymentOpts `json:"previous"`
+	// Applied is the full spec the update was applied with.
+	Applied    UpdateInferenceDeploymentOpts `json:"applied"`
+	CanaryName string                        `json:"canary_name,omitempty"`
+	RolledBack bool                          `json:"rolled_back"`
+	Deployment *InferenceDeployment          `json:"deployment"`
+}
+
+// SpecFromDeployment builds update options restoring the spec of a deployment.
+// The command is left out: the API returns it as a single string which cannot be reliably
+// split back into arguments, so a rollout keeps the current command unless the update sets it.
+func SpecFromDeployment(d *InferenceDeployment) UpdateInferenceDeploymentOpts {
+	description, image, port, authEnabled := d.Description, d.Image, d.ListeningPort, d.AuthEnabled
+	timeout, flavor := d.Timeout, d.FlavorName
+	spec := UpdateInferenceDeploymentOpts{
+		Description:   &description,
+		Image:         &image,
+		ListeningPort: &port,
+		AuthEnabled:   &authEnabled,
+		Timeout:       &timeout,
+		FlavorName:    &flavor,
+		Probes:        d.Probes,
+	}
+	if len(d.Envs) != 0 {
+		spec.Envs = make(map[string]string, len(d.Envs))
+		for k, v := range d.Envs {
+			spec.Envs[k] = v
+		}
+	}
+	for _, container := range d.Containers {
+		spec.Containers = append(spec.Containers, CreateContainerOpts{
+			RegionID: container.RegionID,
+			Scale:    container.Scale,
+		})
+	}
+	if d.CredentialsName != "" {
+		credentials := d.CredentialsName
+		spec.CredentialsName = &credentials
+	}
+	if d.Logging != nil {
+		logging := &CreateLoggingOpts{Enabled: d.Logging.Enabled}
+		if d.Logging.DestinationRegionID != nil {
+			logging.DestinationRegionID = *d.Logging.DestinationRegionID
+		}
+		if d.Logging.TopicName != nil {
+			logging.TopicName = *d.Logging.TopicName
+		}
+		logging.RetentionPolicy.Period = d.Logging.RetentionPolicy
+		spec.Logging = logging
+	}
+	return spec
+}
+
+// MergeUpdate returns the base spec with every field set in the update replaced.
+func MergeUpdate(base, update UpdateInferenceDeploymentOpts) UpdateInferenceDeploymentOpts {
+	merged := base
+	if update.Description != nil {
+		merged.Description = update.Description
+	}
+	if update.Image != nil {
+		merged.Image = update.Image
+	}
+	if update.ListeningPort != nil {
+		merged.ListeningPort = update.ListeningPort
+	}
+	if update.AuthEnabled != nil {
+		merged.AuthEnabled = update.AuthEnabled
+	}
+	if update.Containers != nil {
+		merged.Containers = update.Containers
+	}
+	if update.Timeout != nil {
+		merged.Timeout = update.Timeout
+	}
+	if update.Envs != nil {
+		merged.Envs = update.Envs
+	}
+	if update.Command != nil {
+		merged.Command = update.Command
+	}
+	if update.Logging != nil {
+		merged.Logging = update.Logging
+	}
+	if update.Probes != nil {
+		merged.Probes = update.Probes
+	}
+	if update.FlavorName != nil {
+		merged.FlavorName = update.FlavorName
+	}
+	if update.CredentialsName != nil {
+		merged.CredentialsName = update.CredentialsName
+	}
+	return merged
+}
+
+// CheckHealth returns whether a deployment is active with every container ready at least at its minimal scale.
+// A failed deployment or a container with an error message is returned as an error.
+func CheckHealth(d *InferenceDeployment) (bool, error) {
+	if failedStatuses[d.Status] {
+		return false, fmt.Errorf("deployment %s is %s", d.Name, d.Status)
+	}
+	for _, container := range d.Containers {
+		if container.ErrorMessage != "" {
+			return false, fmt.Errorf("deployment %s in region %d: %s", d.Name, container.RegionID, container.ErrorMessage)
+		}
+	}
+	if d.Status != StatusActive {
+		return false, nil
+	}
+	for _, container := range d.Containers {
+		status := container.DeployStatus
+		if status.Ready < container.Scale.Min || status.Ready < status.Total {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// matchesSpec returns whether a deployment already reports the applied image and flavor,
+// so a stale healthy state of the previous spec is not taken for the rollout result.
+func matchesSpec(d *InferenceDeployment, spec UpdateInferenceDeploymentOpts) bool {
+	if spec.Image != nil && d.Image != *spec.Image {
+		return false
+	}
+	if spec.FlavorName != nil && d.FlavorName != *spec.FlavorName {
+		return false
+	}
+	return true
+}
+
+type rolloutWaiter struct {
+	c        *gcorecloud.ServiceClient
+	timeout  time.Duration
+	interval time.Duration
+	checks   int
+}
+
+// wait polls the deployment until it is healthy for the required number of consecutive polls.
+func (w rolloutWaiter) wait(ctx context.Context, name string, spec UpdateInferenceDeploymentOpts) (*InferenceDeployment, error) {
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	healthy := 0
+	var lastErr error
+	for {
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return nil, fmt.Errorf("deployment %s is not healthy: %w, last error: %s", name, ctx.Err(), lastErr)
+			}
+			return nil, fmt.Errorf("deployment %s is not healthy: %w", name, ctx.Err())
+		case <-ticker.C:
+		}
+		d, err := GetInferenceDeployment(w.c, name).Extract()
+		if err != nil {
+			lastErr = err
+			healthy = 0
+			continue
+		}
+		ok, err := CheckHealth(d)
+		if err != nil {
+			return d, err
+		}
+		if !ok || !matchesSpec(d, spec) {
+			healthy = 0
+			continue
+		}
+		healthy++
+		if healthy >= w.checks {
+			return d, nil
+		}
+	}
+}
+
+// Rollout applies an update to an inference deployment and waits for it to become healthy.
+// The current spec is snapshot first and the update is merged over it, so the deployment is patched with a full spec.
+// The command is only sent when the update sets it, the previous command is then restored on rollback.
+// If the deployment does not become healthy within the timeout, the snapshot is re-applied.
+// With canary options the merged spec first runs as a separate single container deployment, which is deleted afterwards.
+func Rollout(ctx context.Context, c *gcorecloud.ServiceClient, name string, opts RolloutOpts) (*RolloutResult, error) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	w := rolloutWaiter{c: c, timeout: opts.Timeout, interval: opts.PollInterval, checks: opts.HealthyChecks}
+	if w.timeout == 0 {
+		w.timeout = DefaultRolloutTimeout
+	}
+	if w.interval == 0 {
+		w.interval = DefaultRolloutPollInterval
+	}
+	if w.checks == 0 {
+		w.checks = DefaultHealthyChecks
+	}
+
+	current, err := GetInferenceDeployment(c, name).Extract()
+	if err != nil {
+		return nil, err
+	}
+	result := &RolloutResult{Name: name, Previous: SpecFromDeployment(current)}
+	if opts.Update.Command != nil && current.Command != nil {
+		// the command is only restored when the update replaces it
+		result.Previous.Command = SplitCommand(*current.Command)
+	}
+	result.Applied = MergeUpdate(result.Previous, opts.Update)
+
+	if opts.Canary != nil {
+		if err := gcorecloud.ValidateStruct(opts.Canary); err != nil {
+			return nil, err
+		}
+		result.CanaryName = opts.Canary.Name
+		if result.CanaryName == "" {
+			result.CanaryName = name + "-canary"
+		}
+		canary := result.Applied
+		if canary.Command == nil && current.Command != nil {
+			canary.Command = SplitCommand(*current.Command)
+		}
+		if err := runCanary(ctx, w, result.CanaryName, opts.Canary.RegionID, canary); err != nil {
+			return result, fmt.Errorf("canary failed, deployment %s is not changed: %w", name, err)
+		}
+	}
+
+	if err := updateSpec(c, name, result.Applied); err != nil {
+		return result, err
+	}
+	result.Deployment, err = w.wait(ctx, name, result.Applied)
+	if err == nil {
+		return result, nil
+	}
+	if opts.DisableRollback || errors.Is(ctx.Err(), context.Canceled) {
+		return result, err
+	}
+
+	if rollbackErr := updateSpec(c, name, result.Previous); rollbackErr != nil {
+		return result, fmt.Errorf("rollout failed: %w, cannot restore previous spec: %s", err, rollbackErr)
+	}
+	result.RolledBack = true
+	if d, waitErr := w.wait(ctx, name, result.Previous); waitErr == nil {
+		result.Deployment = d
+	}
+	return result, fmt.Errorf("rollout failed, previous spec restored: %w", err)
+}
+
+// updateSpec patches the deployment with the spec. A spec without a command does not send it at all,
+// so the current command of the deployment is kept.
+func updateSpec(c *gcorecloud.ServiceClient, name string, spec UpdateInferenceDeploymentOpts) error {
+	return UpdateInferenceDeployment(c, name, KeepCommand(spec)).Err
+}
+
+// runCanary creates a single container deployment with the spec, waits for it to become healthy and deletes it.
+func runCanary(ctx context.Context, w rolloutWaiter, name string, regionID int, spec UpdateInferenceDeploymentOpts) error {
+	opts := CreateInferenceDeploymentOpts{
+		Name:            name,
+		Envs:            spec.Envs,
+		Command:         spec.Command,
+		CredentialsName: spec.CredentialsName,
+		Probes:          spec.Probes,
+		Timeout:         spec.Timeout,
+	}
+	if spec.Description != nil {
+		opts.Description = *spec.Description
+	}
+	if spec.Image != nil {
+		opts.Image = *spec.Image
+	}
+	if spec.ListeningPort != nil {
+		opts.ListeningPort = *spec.ListeningPort
+	}
+	if spec.AuthEnabled != nil {
+		opts.AuthEnabled = *spec.AuthEnabled
+	}
+	if spec.FlavorName != nil {
+		opts.FlavorName = *spec.FlavorName
+	}
+	scale := ContainerScale{Min: 1, Max: 1}
+	for _, container := range spec.Containers {
+		if container.RegionID == regionID {
+			scale.Triggers = container.Scale.Triggers
+		}
+	}
+	opts.Containers = []CreateContainerOpts{{RegionID: regionID, Scale: scale}}
+
+	if err := CreateInferenceDeployment(w.c, opts).Err; err != nil {
+		return err
+	}
+	_, err := w.wait(ctx, name, spec)
+	if deleteErr := DeleteInferenceDeployment(w.c, name).Err; deleteErr != nil {
+		if err != nil {
+			return fmt.Errorf("%w, cannot delete canary %s: %s", err, name, deleteErr)
+		}
+		return fmt.Errorf("cannot delete canary %s: %w", name, deleteErr)
+	}
+	return err
+}
@@ -0,0 +1,220 @@
+package spec
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"sort"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/inference/v3/inferences"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+)
+
+// ApplyOpts represents options used to apply a spec.
+type ApplyOpts struct {
+	// DryRun computes the changes without creating or updating the deployment.
+	DryRun bool
+}
+
+// ApplyResult describes an applied spec.
+type ApplyResult struct {
+	Name    string `json:"name"`
+	Created bool   `json:"created"`
+	// Changed are the spec fields differing from the deployment.
+	Changed []string `json:"changed"`
+	// Update is the update request sent, or to be sent on a dry run.
+	Update *inferences.UpdateInferenceDeploymentOpts `json:"update,omitempty"`
+	Tasks  *tasks.TaskResults                        `json:"tasks,omitempty"`
+}
+
+// Diff compares a spec with a deployment and builds update options changing only the differing fields.
+// Fields the spec leaves empty are not compared. Flavor, timeout and registry credentials are always sent,
+// because an update request without them resets them. The command is sent only when the spec sets it and
+// the update is sent with inferences.KeepCommand otherwise. Environment variables cannot be cleared by an update.
+func Diff(current *inferences.InferenceDeployment, desired *Spec) (inferences.UpdateInferenceDeploymentOpts, []string) {
+	cur := inferences.SpecFromDeployment(current)
+	var changed []string
+	opts := inferences.UpdateInferenceDeploymentOpts{
+		Timeout:         cur.Timeout,
+		FlavorName:      &desired.FlavorName,
+		CredentialsName: desired.CredentialsName,
+	}
+
+	if desired.Description != current.Description {
+		opts.Description = &desired.Description
+		changed = append(changed, "description")
+	}
+	if desired.Image != current.Image {
+		opts.Image = &desired.Image
+		changed = append(changed, "image")
+	}
+	if desired.ListeningPort != current.ListeningPort {
+		opts.ListeningPort = &desired.ListeningPort
+		changed = append(changed, "listening_port")
+	}
+	if desired.AuthEnabled != current.AuthEnabled {
+		opts.AuthEnabled = &desired.AuthEnabled
+		changed = append(changed, "auth_enabled")
+	}
+	if desired.FlavorName != current.FlavorName {
+		changed = append(changed, "flavor_name")
+	}
+	if desired.Timeout != nil {
+		opts.Timeout = desired.Timeout
+		if *desired.Timeout != current.Timeout {
+			changed = append(changed, "timeout")
+		}
+	}
+	if desired.Command != nil {
+		opts.Command = desired.Command
+		var currentCommand []string
+		if current.Command != nil {
+			currentCommand = inferences.SplitCommand(*current.Command)
+		}
+		if !slices.Equal(desired.Command, currentCommand) {
+			changed = append(changed, "command")
+		}
+	}
+	desiredCredentials := ""
+	if desired.CredentialsName != nil {
+		desiredCredentials = *desired.CredentialsName
+	}
+	if desiredCredentials != current.CredentialsName {
+		changed = append(changed, "credentials_name")
+	}
+	if len(desired.Envs) != 0 && !reflect.DeepEqual(desired.Envs, current.Envs) {
+		opts.Envs = desired.Envs
+		changed = append(changed, "envs")
+	}
+	if !covers(sortContainers(desired.Containers), sortContainers(cur.Containers), false) {
+		opts.Containers = desired.Containers
+		changed = append(changed, "containers")
+	}
+	if desired.Logging != nil {
+		logging := *desired.Logging
+		if cur.Logging != nil {
+			// Empty topic and destination are chosen by the API.
+			if logging.TopicName == "" {
+				logging.TopicName = cur.Logging.TopicName
+			}
+			if logging.DestinationRegionID == 0 {
+				logging.DestinationRegionID = cur.Logging.DestinationRegionID
+			}
+		}
+		if cur.Logging == nil || !covers(logging, *cur.Logging, false) {
+			opts.Logging = desired.Logging
+			changed = append(changed, "logging")
+		}
+	}
+	// Probe fields left empty, e.g. thresholds or the http_get schema, take the API defaults.
+	if desired.Probes != nil && (cur.Probes == nil || !covers(desired.Probes, cur.Probes, true)) {
+		opts.Probes = desired.Probes
+		changed = append(changed, "probes")
+	}
+	return opts, changed
+}
+
+// Apply creates the deployment of the spec, or updates the fields of an existing deployment which differ from it.
+func Apply(c *gcorecloud.ServiceClient, s *Spec, opts ApplyOpts) (*ApplyResult, error) {
+	if err := Validate(s); err != nil {
+		return nil, err
+	}
+	result := &ApplyResult{Name: s.Name}
+	current, err := inferences.GetInferenceDeployment(c, s.Name).Extract()
+	switch err.(type) {
+	case nil:
+	case gcorecloud.ErrDefault404:
+		result.Created = true
+		if opts.DryRun {
+			return result, nil
+		}
+		result.Tasks, err = inferences.CreateInferenceDeployment(c, *s).Extract()
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	default:
+		return nil, err
+	}
+
+	update, changed := Diff(current, s)
+	result.Changed = changed
+	if len(changed) == 0 {
+		return result, nil
+	}
+	result.Update = &update
+	if opts.DryRun {
+		return result, nil
+	}
+	result.Tasks, err = inferences.UpdateInferenceDeployment(c, s.Name, inferences.KeepCommand(update)).Extract()
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func sortContainers(containers []inferences.CreateContainerOpts) []inferences.CreateContainerOpts {
+	sorted := append([]inferences.CreateContainerOpts(nil), containers...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].RegionID < sorted[j].RegionID })
+	return sorted
+}
+
+// covers returns whether every value set in desired equals the value in current, compared in their JSON form.
+// Null values of desired are not compared, neither are zero numbers and empty strings with ignoreEmpty.
+func covers(desired, current interface{}, ignoreEmpty bool) bool {
+	d, err := toGeneric(desired)
+	if err != nil {
+		return false
+	}
+	c, err := toGeneric(current)
+	if err != nil {
+		return false
+	}
+	return coversValue(d, c, ignoreEmpty)
+}
+
+func toGeneric(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	err = json.Unmarshal(data, &generic)
+	return generic, err
+}
+
+func coversValue(desired, current interface{}, ignoreEmpty bool) bool {
+	switch d := desired.(type) {
+	case nil:
+		return true
+	case float64:
+		return (ignoreEmpty && d == 0) || reflect.DeepEqual(desired, current)
+	case string:
+		return (ignoreEmpty && d == "") || reflect.DeepEqual(desired, current)
+	case map[string]interface{}:
+		c, ok := current.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range d {
+			if !coversValue(value, c[key], ignoreEmpty) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		c, ok := current.([]interface{})
+		if !ok || len(c) != len(d) {
+			return false
+		}
+		for i := range d {
+			if !coversValue(d[i], c[i], ignoreEmpty) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(desired, current)
+	}
+}
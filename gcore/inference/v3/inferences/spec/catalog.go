@@ -0,0 +1,111 @@
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/inference/v3/credentials"
+	"github.com/G-Core/gcorelabscloud-go/gcore/inference/v3/flavors"
+	"github.com/G-Core/gcorelabscloud-go/gcore/inference/v3/secrets"
+	"github.com/G-Core/gcorelabscloud-go/gcore/region/v1/regions"
+)
+
+// Clients groups service clients used to load a Catalog.
+type Clients struct {
+	// Inference is an inference v3 client used for flavors, registry credentials and secrets.
+	Inference *gcorecloud.ServiceClient
+	// Regions is a regions v1 client. Region IDs are not checked without it.
+	Regions *gcorecloud.ServiceClient
+}
+
+// Catalog holds the resources of a project a spec may reference.
+// A nil set is not checked.
+type Catalog struct {
+	Regions     map[int]string
+	Flavors     map[string]bool
+	Credentials map[string]bool
+	Secrets     map[string]bool
+}
+
+// LoadCatalog lists inference flavors, registry credentials, secrets and regions.
+func LoadCatalog(clients Clients) (*Catalog, error) {
+	c := &Catalog{
+		Flavors:     map[string]bool{},
+		Credentials: map[string]bool{},
+		Secrets:     map[string]bool{},
+	}
+	flavorList, err := flavors.ListAllFlavor(clients.Inference)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list flavors: %w", err)
+	}
+	for _, f := range flavorList {
+		c.Flavors[f.Name] = true
+	}
+	credentialList, err := credentials.ListAll(clients.Inference)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list registry credentials: %w", err)
+	}
+	for _, rc := range credentialList {
+		c.Credentials[rc.Name] = true
+	}
+	secretList, err := secrets.ListAll(clients.Inference)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list secrets: %w", err)
+	}
+	for _, s := range secretList {
+		c.Secrets[s.Name] = true
+	}
+	if clients.Regions != nil {
+		regionList, err := regions.ListAll(clients.Regions, nil)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list regions: %w", err)
+		}
+		c.Regions = make(map[int]string, len(regionList))
+		for _, r := range regionList {
+			c.Regions[r.ID] = r.DisplayName
+		}
+	}
+	return c, nil
+}
+
+// Validate checks the spec with Validate and then checks every region, flavor, registry credential
+// and secret it references exists. Every problem found is returned.
+func (c *Catalog) Validate(s *Spec) error {
+	errs := []error{Validate(s)}
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+	if c.Flavors != nil && s.FlavorName != "" && !c.Flavors[s.FlavorName] {
+		fail("flavor %s does not exist, available: %s", s.FlavorName, names(c.Flavors))
+	}
+	if c.Credentials != nil && s.CredentialsName != nil && *s.CredentialsName != "" && !c.Credentials[*s.CredentialsName] {
+		fail("registry credentials %s do not exist", *s.CredentialsName)
+	}
+	for _, container := range s.Containers {
+		if _, ok := c.Regions[container.RegionID]; c.Regions != nil && !ok {
+			fail("region %d does not exist", container.RegionID)
+		}
+		sqs := container.Scale.Triggers.Sqs
+		if c.Secrets != nil && sqs != nil && sqs.SecretName != "" && !c.Secrets[sqs.SecretName] {
+			fail("region %d: secret %s does not exist", container.RegionID, sqs.SecretName)
+		}
+	}
+	if s.Logging != nil && s.Logging.Enabled {
+		if _, ok := c.Regions[s.Logging.DestinationRegionID]; c.Regions != nil && !ok {
+			fail("logging destination region %d does not exist", s.Logging.DestinationRegionID)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func names(set map[string]bool) string {
+	list := make([]string, 0, len(set))
+	for name := range set {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return strings.Join(list, ", ")
+}
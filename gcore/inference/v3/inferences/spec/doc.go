@@ -0,0 +1,52 @@
+/*
+Package spec reads inference deployment spec files, validates them and applies them to a project
+
+A spec file is the YAML or JSON body of an inference deployment create request:
+
+	name: llama
+	image: registry.example.com/llama:1.2
+	listening_port: 8080
+	flavor_name: inference-16vcpu-232gib-1xh100-80gb
+	credentials_name: registry
+	timeout: 120
+	command: ["python", "serve.py"]
+	envs:
+	  MODEL: llama-3-8b
+	containers:
+	  - region_id: 1
+	    scale:
+	      min: 1
+	      max: 3
+	      triggers:
+	        gpu_utilization:
+	          threshold: 80
+	probes:
+	  readiness_probe:
+	    enabled: true
+	    probe:
+	      http_get:
+	        path: /health
+	        port: 8080
+
+Example to validate a spec file against the project and apply it
+
+	s, err := spec.Load("llama.yaml")
+	if err != nil {
+		panic(err)
+	}
+
+	catalog, err := spec.LoadCatalog(spec.Clients{Inference: client, Regions: regionClient})
+	if err != nil {
+		panic(err)
+	}
+
+	if err := catalog.Validate(s); err != nil {
+		panic(err)
+	}
+
+	result, err := spec.Apply(client, s, spec.ApplyOpts{})
+	if err != nil {
+		panic(err)
+	}
+*/
+package spec
@@ -0,0 +1,191 @@
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/inference/v3/inferences"
+	"gopkg.in/yaml.v2"
+)
+
+// Spec is the desired state of an inference deployment.
+type Spec = inferences.CreateInferenceDeploymentOpts
+
+// Parse decodes a YAML or JSON spec and validates it with Validate. Unknown fields are rejected.
+func Parse(data []byte) (*Spec, error) {
+	data = bytes.TrimSpace(data)
+	if !bytes.HasPrefix(data, []byte("{")) {
+		var doc interface{}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		converted, err := toJSONValue(doc)
+		if err != nil {
+			return nil, err
+		}
+		if data, err = json.Marshal(converted); err != nil {
+			return nil, err
+		}
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var s Spec
+	if err := decoder.Decode(&s); err != nil {
+		return nil, err
+	}
+	if err := Validate(&s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Load reads and validates a spec file.
+func Load(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// toJSONValue converts a YAML document into values encoding/json accepts.
+func toJSONValue(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			k, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("key %v is not a string", key)
+			}
+			converted, err := toJSONValue(value)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = converted
+		}
+		return m, nil
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, value := range v {
+			converted, err := toJSONValue(value)
+			if err != nil {
+				return nil, err
+			}
+			s[i] = converted
+		}
+		return s, nil
+	default:
+		return v, nil
+	}
+}
+
+// Validate checks a spec without calling the API. Every problem found is returned.
+func Validate(s *Spec) error {
+	var errs []error
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+	if s.Name == "" {
+		fail("name is required")
+	}
+	if s.Image == "" {
+		fail("image is required")
+	}
+	if s.FlavorName == "" {
+		fail("flavor_name is required")
+	}
+	if s.ListeningPort <= 0 || s.ListeningPort > 65535 {
+		fail("listening_port %d is out of range 1-65535", s.ListeningPort)
+	}
+	if s.Timeout != nil && *s.Timeout < 0 {
+		fail("timeout %d is negative", *s.Timeout)
+	}
+	if s.Command != nil && len(s.Command) == 0 {
+		fail("command is empty")
+	}
+	if s.CredentialsName != nil && *s.CredentialsName == "" {
+		fail("credentials_name is empty")
+	}
+
+	if len(s.Containers) == 0 {
+		fail("at least one container is required")
+	}
+	regions := map[int]bool{}
+	for _, container := range s.Containers {
+		if container.RegionID <= 0 {
+			fail("container region_id %d is invalid", container.RegionID)
+			continue
+		}
+		if regions[container.RegionID] {
+			fail("region %d has more than one container", container.RegionID)
+		}
+		regions[container.RegionID] = true
+		scale := container.Scale
+		if scale.Min < 0 || scale.Max <= 0 || scale.Min > scale.Max {
+			fail("region %d: scale min %d and max %d are invalid", container.RegionID, scale.Min, scale.Max)
+		}
+		if sqs := scale.Triggers.Sqs; sqs != nil && (sqs.QueueURL == "" || sqs.SecretName == "") {
+			fail("region %d: sqs trigger requires queue_url and secret_name", container.RegionID)
+		}
+	}
+
+	if s.Logging != nil && s.Logging.Enabled && s.Logging.DestinationRegionID <= 0 {
+		fail("logging destination_region_id is required when logging is enabled")
+	}
+	if s.Probes != nil {
+		probes := []struct {
+			name  string
+			probe *inferences.ProbeConfiguration
+		}{
+			{"liveness_probe", s.Probes.LivenessProbe},
+			{"readiness_probe", s.Probes.ReadinessProbe},
+			{"startup_probe", s.Probes.StartupProbe},
+		}
+		for _, p := range probes {
+			if err := validateProbe(p.probe); err != nil {
+				fail("%s: %w", p.name, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func validateProbe(c *inferences.ProbeConfiguration) error {
+	if c == nil || !c.Enabled {
+		return nil
+	}
+	if c.Probe == nil {
+		return fmt.Errorf("probe is required when enabled")
+	}
+	handlers := 0
+	if c.Probe.Exec != nil {
+		handlers++
+		if len(c.Probe.Exec.Command) == 0 {
+			return fmt.Errorf("exec command is empty")
+		}
+	}
+	if c.Probe.TcpSocket != nil {
+		handlers++
+		if c.Probe.TcpSocket.Port <= 0 {
+			return fmt.Errorf("tcp_socket port is required")
+		}
+	}
+	if c.Probe.HttpGet != nil {
+		handlers++
+		if c.Probe.HttpGet.Port <= 0 {
+			return fmt.Errorf("http_get port is required")
+		}
+	}
+	if handlers != 1 {
+		return fmt.Errorf("exactly one of exec, tcp_socket and http_get is required")
+	}
+	return nil
+}
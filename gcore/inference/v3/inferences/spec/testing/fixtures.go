@@ -0,0 +1,203 @@
+package testing
+
+const SpecYAML = `
+name: test-inf
+image: nginx:1.25
+listening_port: 8080
+flavor_name: inference-16vcpu-232gib-1xh100-80gb
+credentials_name: dockerhub
+timeout: 120
+command: ["nginx", "-g", "daemon-off"]
+envs:
+  KEY: "12345"
+containers:
+  - region_id: 1
+    scale:
+      min: 1
+      max: 3
+      triggers:
+        cpu:
+          threshold: 80
+        sqs:
+          queue_url: https://sqs.example.com/queue
+          queue_length: 10
+          secret_name: sqs-secret
+logging:
+  enabled: true
+  destination_region_id: 1
+  retention_policy:
+    period: 30
+probes:
+  readiness_probe:
+    enabled: true
+    probe:
+      period_seconds: 5
+      http_get:
+        path: /health
+        port: 8080
+`
+
+const DeploymentResponse = `
+{
+  "project_id": 1,
+  "name": "test-inf",
+  "image": "nginx:1.24",
+  "listening_port": 8080,
+  "status": "ACTIVE",
+  "auth_enabled": false,
+  "containers": [
+    {
+      "deploy_status": {
+        "ready": 1,
+        "total": 1
+      },
+      "region_id": 1,
+      "scale": {
+        "max": 3,
+        "min": 1,
+        "cooldown_period": 60,
+        "triggers": {
+          "cpu": {
+            "threshold": 80
+          },
+          "sqs": {
+            "queue_url": "https://sqs.example.com/queue",
+            "queue_length": 10,
+            "activation_queue_length": 0,
+            "scale_on_flight": false,
+            "scale_on_delayed": false,
+            "aws_region": "",
+            "aws_endpoint": null,
+            "secret_name": "sqs-secret"
+          }
+        }
+      }
+    }
+  ],
+  "timeout": 120,
+  "envs": {
+    "KEY": "12345"
+  },
+  "flavor_name": "inference-16vcpu-232gib-1xh100-80gb",
+  "command": "nginx -g daemon-off",
+  "credentials_name": "dockerhub",
+  "logging": {
+    "enabled": true,
+    "destination_region_id": 1,
+    "topic_name": "test-inf-logs",
+    "retention_policy": 30,
+    "opensearch_dashboards_link": ""
+  },
+  "probes": {
+    "readiness_probe": {
+      "enabled": true,
+      "probe": {
+        "failure_threshold": 3,
+        "initial_delay_seconds": 0,
+        "period_seconds": 5,
+        "timeout_seconds": 1,
+        "success_threshold": 1,
+        "exec": null,
+        "tcp_socket": null,
+        "http_get": {
+          "headers": {},
+          "host": null,
+          "path": "/health",
+          "port": 8080,
+          "schema": "HTTP"
+        }
+      }
+    }
+  }
+}
+`
+
+const UpdateRequest = `
+{
+  "image": "nginx:1.25",
+  "timeout": 120,
+  "command": ["nginx", "-g", "daemon-off"],
+  "flavor_name": "inference-16vcpu-232gib-1xh100-80gb",
+  "credentials_name": "dockerhub"
+}
+`
+
+const UpdateRequestWithoutCommand = `
+{
+  "image": "nginx:1.25",
+  "timeout": 120,
+  "flavor_name": "inference-16vcpu-232gib-1xh100-80gb",
+  "credentials_name": "dockerhub"
+}
+`
+
+const TasksResponse = `
+{
+  "tasks": [
+    "50f53a35-42ed-40c4-82b2-5a37fb3e00bc"
+  ]
+}
+`
+
+const FlavorsResponse = `
+{
+  "count": 1,
+  "results": [
+    {
+      "name": "inference-16vcpu-232gib-1xh100-80gb",
+      "cpu": 16,
+      "memory": 232,
+      "gpu": 1,
+      "gpu_model": "H100",
+      "gpu_memory": 80
+    }
+  ]
+}
+`
+
+const CredentialsResponse = `
+{
+  "count": 1,
+  "results": [
+    {
+      "project_id": 1,
+      "name": "dockerhub",
+      "username": "user",
+      "registry_url": "registry.example.com"
+    }
+  ]
+}
+`
+
+const SecretsResponse = `
+{
+  "count": 1,
+  "results": [
+    {
+      "name": "other-secret",
+      "type": "aws-iam",
+      "data": {
+        "aws_access_key_id": "key",
+        "aws_secret_access_key": "secret"
+      }
+    }
+  ]
+}
+`
+
+const RegionsResponse = `
+{
+  "count": 1,
+  "results": [
+    {
+      "id": 1,
+      "display_name": "Luxembourg",
+      "keystone_name": "Luxembourg",
+      "state": "ACTIVE",
+      "endpoint_type": "public",
+      "created_on": "2020-04-10T11:37:58",
+      "keystone_id": 1
+    }
+  ]
+}
+`
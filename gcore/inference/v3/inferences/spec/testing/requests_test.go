@@ -0,0 +1,213 @@
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/inference/v3/inferences"
+	"github.com/G-Core/gcorelabscloud-go/gcore/inference/v3/inferences/spec"
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+)
+
+func prepareDeploymentURL(name string) string {
+	return fmt.Sprintf("/v3/inference/%d/deployments/%s", fake.ProjectID, name)
+}
+
+func respond(t *testing.T, method, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, method)
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, body)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test-inf.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(SpecYAML), 0600))
+
+	s, err := spec.Load(path)
+	require.NoError(t, err)
+	require.Equal(t, "test-inf", s.Name)
+	require.Equal(t, []string{"nginx", "-g", "daemon-off"}, s.Command)
+	require.Equal(t, "dockerhub", *s.CredentialsName)
+	require.Len(t, s.Containers, 1)
+	require.Equal(t, 80, s.Containers[0].Scale.Triggers.Cpu.Threshold)
+	require.Equal(t, "sqs-secret", s.Containers[0].Scale.Triggers.Sqs.SecretName)
+	require.Equal(t, 30, *s.Logging.RetentionPolicy.Period)
+	require.Equal(t, "/health", s.Probes.ReadinessProbe.Probe.HttpGet.Path)
+
+	_, err = spec.Parse([]byte(`{"name": "test-inf", "imagee": "nginx"}`))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "imagee")
+}
+
+func TestValidate(t *testing.T) {
+	err := spec.Validate(&spec.Spec{
+		Name:          "test-inf",
+		Image:         "nginx",
+		ListeningPort: 70000,
+		FlavorName:    "inference-16vcpu-232gib-1xh100-80gb",
+		Containers: []inferences.CreateContainerOpts{
+			{RegionID: 1, Scale: inferences.ContainerScale{Min: 2, Max: 1}},
+			{RegionID: 1, Scale: inferences.ContainerScale{Min: 0, Max: 1}},
+		},
+		Probes: &inferences.Probes{
+			LivenessProbe: &inferences.ProbeConfiguration{Enabled: true, Probe: &inferences.Probe{}},
+		},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "listening_port 70000")
+	require.Contains(t, err.Error(), "region 1: scale min 2 and max 1")
+	require.Contains(t, err.Error(), "region 1 has more than one container")
+	require.Contains(t, err.Error(), "liveness_probe: exactly one of exec")
+}
+
+func TestCatalogValidate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v3/inference/flavors", respond(t, "GET", FlavorsResponse))
+	th.Mux.HandleFunc(fmt.Sprintf("/v3/inference/%d/registry_credentials", fake.ProjectID), respond(t, "GET", CredentialsResponse))
+	th.Mux.HandleFunc(fmt.Sprintf("/v3/inference/%d/secrets", fake.ProjectID), respond(t, "GET", SecretsResponse))
+	th.Mux.HandleFunc("/v1/regions", respond(t, "GET", RegionsResponse))
+
+	catalog, err := spec.LoadCatalog(spec.Clients{
+		Inference: fake.ServiceTokenClient("inferences", "v3"),
+		Regions:   fake.ServiceTokenClient("regions", "v1"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Luxembourg", catalog.Regions[1])
+
+	s, err := spec.Parse([]byte(SpecYAML))
+	require.NoError(t, err)
+	s.Containers = append(s.Containers, inferences.CreateContainerOpts{RegionID: 2, Scale: inferences.ContainerScale{Min: 1, Max: 1}})
+	err = catalog.Validate(s)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "region 1: secret sqs-secret does not exist")
+	require.Contains(t, err.Error(), "region 2 does not exist")
+	require.NotContains(t, err.Error(), "flavor")
+	require.NotContains(t, err.Error(), "registry credentials")
+}
+
+func TestApplyUpdate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareDeploymentURL("test-inf"), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = fmt.Fprint(w, DeploymentResponse)
+		case http.MethodPatch:
+			th.TestJSONRequest(t, r, UpdateRequest)
+			_, _ = fmt.Fprint(w, TasksResponse)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	s, err := spec.Parse([]byte(SpecYAML))
+	require.NoError(t, err)
+	client := fake.ServiceTokenClient("inferences", "v3")
+
+	result, err := spec.Apply(client, s, spec.ApplyOpts{DryRun: true})
+	require.NoError(t, err)
+	require.False(t, result.Created)
+	require.Equal(t, []string{"image"}, result.Changed)
+	require.Nil(t, result.Tasks)
+
+	result, err = spec.Apply(client, s, spec.ApplyOpts{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"image"}, result.Changed)
+	require.Len(t, result.Tasks.Tasks, 1)
+}
+
+func TestApplyKeepsCommand(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareDeploymentURL("test-inf"), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = fmt.Fprint(w, DeploymentResponse)
+		case http.MethodPatch:
+			th.TestJSONRequest(t, r, UpdateRequestWithoutCommand)
+			_, _ = fmt.Fprint(w, TasksResponse)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	s, err := spec.Parse([]byte(SpecYAML))
+	require.NoError(t, err)
+	s.Command = nil
+	result, err := spec.Apply(fake.ServiceTokenClient("inferences", "v3"), s, spec.ApplyOpts{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"image"}, result.Changed)
+	require.Nil(t, result.Update.Command)
+	require.Len(t, result.Tasks.Tasks, 1)
+}
+
+func TestApplyCreate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareDeploymentURL("test-inf"), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		w.WriteHeader(http.StatusNotFound)
+	})
+	th.Mux.HandleFunc(fmt.Sprintf("/v3/inference/%d/deployments", fake.ProjectID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, TasksResponse)
+	})
+
+	s, err := spec.Parse([]byte(SpecYAML))
+	require.NoError(t, err)
+	result, err := spec.Apply(fake.ServiceTokenClient("inferences", "v3"), s, spec.ApplyOpts{})
+	require.NoError(t, err)
+	require.True(t, result.Created)
+	require.Len(t, result.Tasks.Tasks, 1)
+}
+
+func TestDiff(t *testing.T) {
+	s, err := spec.Parse([]byte(SpecYAML))
+	require.NoError(t, err)
+	var current inferences.InferenceDeployment
+	require.NoError(t, json.Unmarshal([]byte(DeploymentResponse), &current))
+
+	current.Image = s.Image
+	_, changed := spec.Diff(&current, s)
+	require.Empty(t, changed)
+
+	command := `nginx -g "daemon off;"`
+	current.Command = &command
+	s.Command = []string{"nginx", "-g", "daemon off;"}
+	_, changed = spec.Diff(&current, s)
+	require.Empty(t, changed)
+	s.Command = nil
+	opts, changed := spec.Diff(&current, s)
+	require.Empty(t, changed)
+	require.Nil(t, opts.Command)
+
+	s.Containers[0].Scale.Max = 5
+	s.Probes.ReadinessProbe.Probe.PeriodSeconds = 10
+	s.Envs = map[string]string{"KEY": "67890"}
+	opts, changed = spec.Diff(&current, s)
+	require.Equal(t, []string{"envs", "containers", "probes"}, changed)
+	require.Equal(t, 5, opts.Containers[0].Scale.Max)
+	require.Nil(t, opts.Logging)
+}
@@ -1,6 +1,8 @@
 package testing
 
 import (
+	"fmt"
+
 	"github.com/G-Core/gcorelabscloud-go/gcore/inference/v3/inferences"
 	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
 	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
@@ -284,3 +286,43 @@ var (
 		Tasks: []tasks.TaskID{"50f53a35-42ed-40c4-82b2-5a37fb3e00bc"},
 	}
 )
+
+// DeploymentResponse renders a deployment with a single container in the given state.
+func DeploymentResponse(name, image, status string, ready, total int, errorMessage string) string {
+	return fmt.Sprintf(`
+{
+  "project_id": 1,
+  "name": "%s",
+  "image": "%s",
+  "listening_port": 8080,
+  "status": "%s",
+  "auth_enabled": false,
+  "containers": [
+    {
+      "deploy_status": {
+        "ready": %d,
+        "total": %d
+      },
+      "region_id": 1,
+      "error_message": "%s",
+      "scale": {
+        "max": 3,
+        "min": 1,
+        "triggers": {
+          "cpu": {
+            "threshold": 80
+          }
+        }
+      }
+    }
+  ],
+  "timeout": 120,
+  "envs": {
+    "KEY": "12345"
+  },
+  "flavor_name": "inference-16vcpu-232gib-1xh100-80gb",
+  "command": "nginx -g 'daemon off;'",
+  "credentials_name": "dockerhub"
+}
+`, name, image, status, ready, total, errorMessage)
+}
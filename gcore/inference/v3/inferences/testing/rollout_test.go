@@ -0,0 +1,178 @@
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/inference/v3/inferences"
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+)
+
+const (
+	oldImage = "nginx:1.24"
+	newImage = "nginx:1.25"
+)
+
+type fakeDeployment struct {
+	mu     sync.Mutex
+	image  string
+	broken bool
+	// patches are the images of every PATCH request.
+	patches []string
+	// commands are the commands of every PATCH request, nil when the command is not sent.
+	commands []interface{}
+}
+
+func (f *fakeDeployment) handle(t *testing.T, name string) {
+	th.Mux.HandleFunc(prepareGetTestURL(name), func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		switch r.Method {
+		case http.MethodGet:
+			if f.broken && f.image == newImage {
+				respondJSON(w, DeploymentResponse(name, f.image, "ACTIVE", 0, 1, ""))
+				return
+			}
+			respondJSON(w, DeploymentResponse(name, f.image, "ACTIVE", 1, 1, ""))
+		case http.MethodPatch:
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			var request map[string]interface{}
+			require.NoError(t, json.Unmarshal(body, &request))
+			command, ok := request["command"]
+			require.True(t, !ok || command != nil, "command must not be reset")
+			f.commands = append(f.commands, command)
+			require.Equal(t, "dockerhub", request["credentials_name"])
+			require.Equal(t, "inference-16vcpu-232gib-1xh100-80gb", request["flavor_name"])
+			require.EqualValues(t, 120, request["timeout"])
+			f.image = request["image"].(string)
+			f.patches = append(f.patches, f.image)
+			respondJSON(w, TasksResponse)
+		}
+	})
+}
+
+func respondJSON(w http.ResponseWriter, body string) {
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, err := fmt.Fprint(w, body)
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+func rolloutOpts() inferences.RolloutOpts {
+	image := newImage
+	return inferences.RolloutOpts{
+		Update:        inferences.UpdateInferenceDeploymentOpts{Image: &image},
+		Timeout:       300 * time.Millisecond,
+		PollInterval:  10 * time.Millisecond,
+		HealthyChecks: 2,
+	}
+}
+
+func TestRollout(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	f := &fakeDeployment{image: oldImage}
+	f.handle(t, "test-inf")
+
+	client := fake.ServiceTokenClient("inferences", "v3")
+	result, err := inferences.Rollout(context.Background(), client, "test-inf", rolloutOpts())
+	require.NoError(t, err)
+	require.False(t, result.RolledBack)
+	require.Equal(t, []string{newImage}, f.patches)
+	require.Equal(t, oldImage, *result.Previous.Image)
+	require.Nil(t, result.Previous.Command)
+	require.Equal(t, newImage, result.Deployment.Image)
+	require.Equal(t, []interface{}{nil}, f.commands)
+}
+
+func TestRolloutRollback(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	f := &fakeDeployment{image: oldImage, broken: true}
+	f.handle(t, "test-inf")
+
+	client := fake.ServiceTokenClient("inferences", "v3")
+	result, err := inferences.Rollout(context.Background(), client, "test-inf", rolloutOpts())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "previous spec restored")
+	require.True(t, result.RolledBack)
+	require.Equal(t, []string{newImage, oldImage}, f.patches)
+	require.Equal(t, oldImage, result.Deployment.Image)
+	require.Equal(t, []interface{}{nil, nil}, f.commands)
+}
+
+func TestRolloutRollbackCommand(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	f := &fakeDeployment{image: oldImage, broken: true}
+	f.handle(t, "test-inf")
+
+	opts := rolloutOpts()
+	opts.Update.Command = []string{"nginx", "-g", "daemon off; worker_processes 2;"}
+	client := fake.ServiceTokenClient("inferences", "v3")
+	result, err := inferences.Rollout(context.Background(), client, "test-inf", opts)
+	require.Error(t, err)
+	require.True(t, result.RolledBack)
+	require.Equal(t, []interface{}{
+		[]interface{}{"nginx", "-g", "daemon off; worker_processes 2;"},
+		[]interface{}{"nginx", "-g", "daemon off;"},
+	}, f.commands)
+}
+
+func TestRolloutCanaryFailure(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	f := &fakeDeployment{image: oldImage}
+	f.handle(t, "test-inf")
+
+	var calls []string
+	th.Mux.HandleFunc(prepareListTestURL(), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var request inferences.CreateInferenceDeploymentOpts
+		require.NoError(t, json.Unmarshal(body, &request))
+		require.Equal(t, "test-inf-canary", request.Name)
+		require.Equal(t, newImage, request.Image)
+		require.Equal(t, []string{"nginx", "-g", "daemon off;"}, request.Command)
+		require.Len(t, request.Containers, 1)
+		require.Equal(t, 1, request.Containers[0].Scale.Max)
+		calls = append(calls, "POST canary")
+		respondJSON(w, TasksResponse)
+	})
+	th.Mux.HandleFunc(prepareGetTestURL("test-inf-canary"), func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			respondJSON(w, DeploymentResponse("test-inf-canary", newImage, "ACTIVE", 0, 1, "readiness probe failed"))
+		case http.MethodDelete:
+			calls = append(calls, "DELETE canary")
+			respondJSON(w, TasksResponse)
+		}
+	})
+
+	opts := rolloutOpts()
+	opts.Canary = &inferences.CanaryOpts{RegionID: 1}
+	client := fake.ServiceTokenClient("inferences", "v3")
+	_, err := inferences.Rollout(context.Background(), client, "test-inf", opts)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "readiness probe failed")
+	require.Equal(t, []string{"POST canary", "DELETE canary"}, calls)
+	require.Empty(t, f.patches)
+}
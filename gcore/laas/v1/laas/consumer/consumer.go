@@ -0,0 +1,354 @@
+package consumer
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/laas/v1/laas"
+)
+
+const (
+	// DefaultKafkaPort is used for LaaS Kafka hosts without a port.
+	DefaultKafkaPort = 9093
+	// DefaultClientID identifies the consumer to brokers.
+	DefaultClientID     = "gcorelabscloud-go"
+	DefaultDialTimeout  = 10 * time.Second
+	DefaultPollInterval = time.Second
+	DefaultMaxBytes     = 1 << 20
+)
+
+// SASL mechanisms supported by the consumer.
+const (
+	MechanismPlain       = "PLAIN"
+	MechanismSCRAMSHA256 = "SCRAM-SHA-256"
+	MechanismSCRAMSHA512 = "SCRAM-SHA-512"
+)
+
+// Special offsets of TailOpts.
+const (
+	// OffsetNewest starts after the last message of every partition.
+	OffsetNewest int64 = -1
+	// OffsetOldest starts from the first retained message of every partition.
+	OffsetOldest int64 = -2
+)
+
+// Config represents Kafka connection settings.
+type Config struct {
+	// Brokers are bootstrap broker addresses in host:port form.
+	Brokers  []string `validate:"required,min=1,dive,required"`
+	Username string
+	Password string `validate:"required_with=Username"`
+	// Mechanism is the SASL mechanism, SCRAM-SHA-512 by default. Authentication is skipped without a username.
+	Mechanism string `validate:"omitempty,oneof=PLAIN SCRAM-SHA-256 SCRAM-SHA-512"`
+	// TLS enables TLS connections. Plain TCP is used when it is nil.
+	TLS         *tls.Config
+	ClientID    string
+	DialTimeout time.Duration
+}
+
+// LaaSOpts represents options used to connect to the Kafka hosts of LaaS.
+type LaaSOpts struct {
+	// User is the LaaS user. When it is nil the user is regenerated with laas.RegenerateUser,
+	// which invalidates the previous password of the namespace.
+	User *laas.User
+	// TLS defaults to a TLS configuration with system roots.
+	TLS       *tls.Config
+	Mechanism string `validate:"omitempty,oneof=PLAIN SCRAM-SHA-256 SCRAM-SHA-512"`
+}
+
+// LaaSUser returns the LaaS user of the options, regenerating it when it is not set.
+func LaaSUser(c *gcorecloud.ServiceClient, user *laas.User) (*laas.User, error) {
+	if user != nil {
+		return user, nil
+	}
+	user, err := laas.RegenerateUser(c).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("cannot regenerate LaaS user: %w", err)
+	}
+	return user, nil
+}
+
+// LaaSConfig builds the connection settings of the LaaS Kafka hosts of the client region.
+func LaaSConfig(c *gcorecloud.ServiceClient, opts LaaSOpts) (*Config, error) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	hosts, err := laas.ListKafkaHosts(c).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("cannot list LaaS kafka hosts: %w", err)
+	}
+	if len(*hosts) == 0 {
+		return nil, fmt.Errorf("no LaaS kafka hosts in region %d", c.RegionID)
+	}
+	user, err := LaaSUser(c, opts.User)
+	if err != nil {
+		return nil, err
+	}
+	config := &Config{
+		Username:  user.Username,
+		Password:  user.Password,
+		Mechanism: opts.Mechanism,
+		TLS:       opts.TLS,
+	}
+	if config.TLS == nil {
+		config.TLS = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	for _, host := range *hosts {
+		config.Brokers = append(config.Brokers, withPort(host, DefaultKafkaPort))
+	}
+	return config, nil
+}
+
+// NewLaaS returns a consumer of the LaaS Kafka hosts of the client region.
+func NewLaaS(c *gcorecloud.ServiceClient, opts LaaSOpts) (*Consumer, error) {
+	config, err := LaaSConfig(c, opts)
+	if err != nil {
+		return nil, err
+	}
+	return New(*config)
+}
+
+func withPort(host string, port int) string {
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return host
+	}
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+// Header is a header of a Kafka record.
+type Header struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// Message is a record read from a topic partition.
+type Message struct {
+	Topic     string    `json:"topic"`
+	Partition int32     `json:"partition"`
+	Offset    int64     `json:"offset"`
+	Timestamp time.Time `json:"timestamp"`
+	Key       []byte    `json:"key,omitempty"`
+	Value     []byte    `json:"value"`
+	Headers   []Header  `json:"headers,omitempty"`
+}
+
+func newMessage(r *kgo.Record) Message {
+	m := Message{
+		Topic:     r.Topic,
+		Partition: r.Partition,
+		Offset:    r.Offset,
+		Timestamp: r.Timestamp.UTC(),
+		Key:       r.Key,
+		Value:     r.Value,
+	}
+	for _, h := range r.Headers {
+		m.Headers = append(m.Headers, Header{Key: h.Key, Value: h.Value})
+	}
+	return m
+}
+
+// Consumer reads topics of a Kafka cluster without a consumer group, so reading does not commit offsets.
+// Record batches of every compression codec are supported.
+type Consumer struct {
+	opts   []kgo.Opt
+	client *kgo.Client
+	admin  *kadm.Client
+}
+
+// New returns a consumer. Brokers are connected on the first request.
+func New(config Config) (*Consumer, error) {
+	if err := gcorecloud.ValidateStruct(config); err != nil {
+		return nil, err
+	}
+	if config.ClientID == "" {
+		config.ClientID = DefaultClientID
+	}
+	if config.DialTimeout == 0 {
+		config.DialTimeout = DefaultDialTimeout
+	}
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(config.Brokers...),
+		kgo.ClientID(config.ClientID),
+		kgo.DialTimeout(config.DialTimeout),
+	}
+	if config.TLS != nil {
+		opts = append(opts, kgo.DialTLSConfig(config.TLS))
+	}
+	if config.Username != "" {
+		switch config.Mechanism {
+		case MechanismPlain:
+			opts = append(opts, kgo.SASL(plain.Auth{User: config.Username, Pass: config.Password}.AsMechanism()))
+		case MechanismSCRAMSHA256:
+			opts = append(opts, kgo.SASL(scram.Auth{User: config.Username, Pass: config.Password}.AsSha256Mechanism()))
+		default:
+			opts = append(opts, kgo.SASL(scram.Auth{User: config.Username, Pass: config.Password}.AsSha512Mechanism()))
+		}
+	}
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Consumer{opts: opts, client: client, admin: kadm.NewClient(client)}, nil
+}
+
+// Close closes the broker connections.
+func (c *Consumer) Close() error {
+	c.client.Close()
+	return nil
+}
+
+// TailOpts represents options used to read a topic.
+type TailOpts struct {
+	Topic string `validate:"required"`
+	// Offset is OffsetNewest, OffsetOldest, or a positive offset of every partition. OffsetNewest is used when it is zero.
+	Offset int64 `validate:"gte=-2"`
+	// Since starts from messages produced after the time instead of the offset.
+	Since time.Time
+	// Last starts that many messages before the newest one of every partition instead of the offset.
+	Last int `validate:"gte=0"`
+	// Follow waits for new messages until the context is done. Otherwise reading stops at the messages present on start.
+	Follow bool
+	// PollInterval is the longest time a broker waits for new messages before answering a fetch, at least 10ms.
+	PollInterval time.Duration
+	MaxBytes     int32 `validate:"gte=0"`
+}
+
+// offsets returns the offsets every partition of the topic is read from and the end offsets of the partitions.
+func (c *Consumer) offsets(ctx context.Context, opts TailOpts) (map[int32]int64, map[int32]int64, error) {
+	ends, err := c.admin.ListEndOffsets(ctx, opts.Topic)
+	if err == nil {
+		err = ends.Error()
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot list offsets of topic %s: %w", opts.Topic, err)
+	}
+	var listed kadm.ListedOffsets
+	switch {
+	case !opts.Since.IsZero():
+		listed, err = c.admin.ListOffsetsAfterMilli(ctx, opts.Since.UnixMilli(), opts.Topic)
+	case opts.Last > 0 || opts.Offset == OffsetOldest:
+		listed, err = c.admin.ListStartOffsets(ctx, opts.Topic)
+	}
+	if err == nil && listed != nil {
+		err = listed.Error()
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot list offsets of topic %s: %w", opts.Topic, err)
+	}
+
+	starts, endOffsets := make(map[int32]int64), make(map[int32]int64)
+	ends.Each(func(end kadm.ListedOffset) {
+		endOffsets[end.Partition] = end.Offset
+		start := end.Offset
+		if o, ok := listed.Lookup(opts.Topic, end.Partition); ok && o.Offset >= 0 {
+			start = o.Offset
+		}
+		switch {
+		case !opts.Since.IsZero(), opts.Offset == OffsetOldest:
+		case opts.Last > 0:
+			start = max(end.Offset-int64(opts.Last), start)
+		case opts.Offset > 0:
+			start = opts.Offset
+		default:
+			start = end.Offset
+		}
+		starts[end.Partition] = start
+	})
+	return starts, endOffsets, nil
+}
+
+// Tail reads messages of every partition of a topic and passes them to the handler.
+// It stops on the first handler or fetch error. While following it returns the context error when the context is done.
+// Leader changes and dropped connections are retried by the client, and partitions with an offset out of range
+// are read from the oldest offset.
+func (c *Consumer) Tail(ctx context.Context, opts TailOpts, handler func(Message) error) error {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return err
+	}
+	if opts.PollInterval == 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+	if opts.MaxBytes == 0 {
+		opts.MaxBytes = DefaultMaxBytes
+	}
+
+	starts, ends, err := c.offsets(ctx, opts)
+	if err != nil {
+		return err
+	}
+	partitions := make(map[int32]kgo.Offset)
+	// pending are the end offsets of partitions not read yet when not following.
+	pending := make(map[int32]int64)
+	for p, start := range starts {
+		if !opts.Follow {
+			if start >= ends[p] {
+				continue
+			}
+			pending[p] = ends[p]
+		}
+		partitions[p] = kgo.NewOffset().At(start)
+	}
+	if len(partitions) == 0 {
+		return nil
+	}
+
+	client, err := kgo.NewClient(append(c.opts[:len(c.opts):len(c.opts)],
+		kgo.ConsumePartitions(map[string]map[int32]kgo.Offset{opts.Topic: partitions}),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+		kgo.FetchMaxWait(opts.PollInterval),
+		kgo.FetchMaxBytes(opts.MaxBytes),
+		kgo.FetchMaxPartitionBytes(opts.MaxBytes),
+		// Control records mark the end of transactions, they are needed to know when a partition is read up to its end.
+		kgo.KeepControlRecords(),
+	)...)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	for {
+		fetches := client.PollFetches(ctx)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		for _, e := range fetches.Errors() {
+			// Data loss is reported when an offset is out of range, consuming continues from the oldest offset.
+			var dataLoss *kgo.ErrDataLoss
+			if !errors.As(e.Err, &dataLoss) {
+				return fmt.Errorf("partition %d: %w", e.Partition, e.Err)
+			}
+		}
+		for records := fetches.RecordIter(); !records.Done(); {
+			r := records.Next()
+			if !opts.Follow {
+				end, ok := pending[r.Partition]
+				if !ok || r.Offset >= end {
+					continue
+				}
+				if r.Offset+1 >= end {
+					delete(pending, r.Partition)
+				}
+			}
+			if r.Attrs.IsControl() {
+				continue
+			}
+			if err := handler(newMessage(r)); err != nil {
+				return err
+			}
+		}
+		if !opts.Follow && len(pending) == 0 {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,38 @@
+/*
+Package consumer reads LaaS logs from the Kafka hosts of a region and searches them in the OpenSearch hosts
+
+The Kafka consumer is built on the franz-go client and reads topics without a consumer group, so reading does not
+commit offsets. It supports SASL PLAIN and SCRAM authentication and record batches of every compression codec.
+
+Example to follow the log topic of an inference deployment
+
+	c, err := consumer.NewLaaS(laasClient, consumer.LaaSOpts{User: &laas.User{Username: username, Password: password}})
+	if err != nil {
+		panic(err)
+	}
+	defer c.Close()
+
+	err = c.Tail(ctx, consumer.TailOpts{Topic: "inference-logs", Last: 10, Follow: true}, func(m consumer.Message) error {
+		fmt.Println(m.Timestamp, string(m.Value))
+		return nil
+	})
+	if err != nil && !errors.Is(err, context.Canceled) {
+		panic(err)
+	}
+
+Example to search errors of the last hour
+
+	s, err := consumer.NewSearchClient(laasClient, &laas.User{Username: username, Password: password})
+	if err != nil {
+		panic(err)
+	}
+	result, err := s.Search(ctx, consumer.SearchOpts{
+		Index: "inference-logs*",
+		Query: "level:error",
+		Since: time.Now().Add(-time.Hour),
+	})
+	if err != nil {
+		panic(err)
+	}
+*/
+package consumer
@@ -0,0 +1,205 @@
+package consumer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/laas/v1/laas"
+)
+
+const (
+	// DefaultSearchSize is the number of hits returned by a search.
+	DefaultSearchSize = 100
+	// DefaultTimestampField is the field hits are sorted and filtered by.
+	DefaultTimestampField = "@timestamp"
+)
+
+// SearchClient queries LaaS OpenSearch hosts. Hosts are tried in order until one responds.
+type SearchClient struct {
+	// Hosts are base URLs, hosts without a scheme use https.
+	Hosts      []string `validate:"required,min=1,dive,required"`
+	Username   string
+	Password   string
+	HTTPClient *http.Client
+}
+
+// NewSearchClient returns a client of the LaaS OpenSearch hosts of the client region.
+// When the user is nil it is regenerated with laas.RegenerateUser.
+func NewSearchClient(c *gcorecloud.ServiceClient, user *laas.User) (*SearchClient, error) {
+	hosts, err := laas.ListOpenSearchHosts(c).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("cannot list LaaS opensearch hosts: %w", err)
+	}
+	if len(*hosts) == 0 {
+		return nil, fmt.Errorf("no LaaS opensearch hosts in region %d", c.RegionID)
+	}
+	user, err = LaaSUser(c, user)
+	if err != nil {
+		return nil, err
+	}
+	return &SearchClient{Hosts: *hosts, Username: user.Username, Password: user.Password}, nil
+}
+
+// SearchOpts represents options of a search.
+type SearchOpts struct {
+	// Index is an index name or pattern, e.g. namespace.topic-*.
+	Index string `validate:"required"`
+	// Query uses the query string syntax, every document matches when it is empty.
+	Query string
+	Since time.Time
+	Until time.Time
+	Size  int `validate:"omitempty,gt=0,lte=10000"`
+	// Ascending sorts the oldest hits first. The newest hits are returned first by default.
+	Ascending      bool
+	TimestampField string
+}
+
+// Hit is a document matching a search.
+type Hit struct {
+	Index  string          `json:"_index"`
+	ID     string          `json:"_id"`
+	Source json.RawMessage `json:"_source"`
+}
+
+// SearchResult holds hits of a search.
+type SearchResult struct {
+	// Total is the number of matching documents, it may be a lower bound for large results.
+	Total int   `json:"total"`
+	Hits  []Hit `json:"hits"`
+}
+
+// Body builds the search request body of the options.
+func (opts SearchOpts) Body() map[string]interface{} {
+	field := opts.TimestampField
+	if field == "" {
+		field = DefaultTimestampField
+	}
+	size := opts.Size
+	if size == 0 {
+		size = DefaultSearchSize
+	}
+	order := "desc"
+	if opts.Ascending {
+		order = "asc"
+	}
+
+	var must []interface{}
+	if opts.Query != "" {
+		must = append(must, map[string]interface{}{"query_string": map[string]interface{}{"query": opts.Query}})
+	}
+	if !opts.Since.IsZero() || !opts.Until.IsZero() {
+		bounds := map[string]interface{}{}
+		if !opts.Since.IsZero() {
+			bounds["gte"] = opts.Since.UTC().Format(time.RFC3339Nano)
+		}
+		if !opts.Until.IsZero() {
+			bounds["lte"] = opts.Until.UTC().Format(time.RFC3339Nano)
+		}
+		must = append(must, map[string]interface{}{"range": map[string]interface{}{field: bounds}})
+	}
+	query := map[string]interface{}{"match_all": map[string]interface{}{}}
+	if len(must) != 0 {
+		query = map[string]interface{}{"bool": map[string]interface{}{"filter": must}}
+	}
+	return map[string]interface{}{
+		"size":  size,
+		"sort":  []interface{}{map[string]interface{}{field: map[string]interface{}{"order": order}}},
+		"query": query,
+	}
+}
+
+// Search runs a search of the options.
+func (s *SearchClient) Search(ctx context.Context, opts SearchOpts) (*SearchResult, error) {
+	if err := gcorecloud.ValidateStruct(s); err != nil {
+		return nil, err
+	}
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(opts.Body())
+	if err != nil {
+		return nil, err
+	}
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	var lastErr error
+	for _, host := range s.Hosts {
+		if !strings.Contains(host, "://") {
+			host = "https://" + host
+		}
+		u := strings.TrimRight(host, "/") + "/" + url.PathEscape(opts.Index) + "/_search"
+		result, retry, err := s.search(ctx, client, u, body)
+		if err == nil || !retry {
+			return result, err
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// search returns whether another host should be tried on error.
+func (s *SearchClient) search(ctx context.Context, client *http.Client, u string, body []byte) (*SearchResult, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.Username != "" {
+		req.SetBasicAuth(s.Username, s.Password)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		var e struct {
+			Error struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(data, &e) == nil && e.Error.Reason != "" {
+			err = fmt.Errorf("opensearch: %s: %s", e.Error.Type, e.Error.Reason)
+		} else {
+			err = fmt.Errorf("opensearch: unexpected status %s", resp.Status)
+		}
+		return nil, resp.StatusCode >= http.StatusInternalServerError, err
+	}
+
+	var response struct {
+		Hits struct {
+			Total json.RawMessage `json:"total"`
+			Hits  []Hit           `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, false, fmt.Errorf("opensearch: cannot decode response: %w", err)
+	}
+	result := &SearchResult{Hits: response.Hits.Hits}
+	// The total is an object since Elasticsearch 7, a number before.
+	var total struct {
+		Value int `json:"value"`
+	}
+	if json.Unmarshal(response.Hits.Total, &total) == nil {
+		result.Total = total.Value
+	} else {
+		_ = json.Unmarshal(response.Hits.Total, &result.Total)
+	}
+	return result, false, nil
+}
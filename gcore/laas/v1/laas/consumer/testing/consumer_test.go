@@ -0,0 +1,290 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/laas/v1/laas"
+	"github.com/G-Core/gcorelabscloud-go/gcore/laas/v1/laas/consumer"
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+)
+
+const baseTimestamp = int64(1750840962000)
+
+// newCluster starts a fake Kafka cluster with the topic, the user authenticates with PLAIN and SCRAM-SHA-512.
+func newCluster(t *testing.T, partitions int32, opts ...kfake.Opt) *kfake.Cluster {
+	cluster, err := kfake.NewCluster(append([]kfake.Opt{
+		kfake.SeedTopics(partitions, Topic),
+		kfake.EnableSASL(),
+		kfake.Superuser(consumer.MechanismPlain, Username, Password),
+		kfake.Superuser(consumer.MechanismSCRAMSHA512, Username, Password),
+	}, opts...)...)
+	require.NoError(t, err)
+	t.Cleanup(cluster.Close)
+	return cluster
+}
+
+func newClient(t *testing.T, cluster *kfake.Cluster, opts ...kgo.Opt) *kgo.Client {
+	client, err := kgo.NewClient(append([]kgo.Opt{
+		kgo.SeedBrokers(cluster.ListenAddrs()...),
+		kgo.SASL(plain.Auth{User: Username, Pass: Password}.AsMechanism()),
+	}, opts...)...)
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+	return client
+}
+
+func newConsumer(t *testing.T, cluster *kfake.Cluster, mechanism string) *consumer.Consumer {
+	c, err := consumer.New(consumer.Config{
+		Brokers:   cluster.ListenAddrs(),
+		Username:  Username,
+		Password:  Password,
+		Mechanism: mechanism,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+// produce writes every record in a batch of its own compressed with the codec.
+func produce(t *testing.T, cluster *kfake.Cluster, codec kgo.CompressionCodec, records ...*kgo.Record) {
+	client := newClient(t, cluster, kgo.RecordPartitioner(kgo.ManualPartitioner()), kgo.ProducerBatchCompression(codec))
+	for _, r := range records {
+		require.NoError(t, client.ProduceSync(context.Background(), r).FirstErr())
+	}
+}
+
+func record(partition int32, value string, timestamp int64) *kgo.Record {
+	return &kgo.Record{
+		Topic:     Topic,
+		Partition: partition,
+		Value:     []byte(value),
+		Timestamp: time.UnixMilli(timestamp),
+		Headers:   []kgo.RecordHeader{{Key: "host", Value: []byte("gpu")}},
+	}
+}
+
+func seed(t *testing.T, cluster *kfake.Cluster) {
+	produce(t, cluster, kgo.NoCompression(),
+		record(0, "loading model", baseTimestamp),
+		record(0, "model loaded", baseTimestamp+1000),
+		record(0, "serving", baseTimestamp+2000))
+	produce(t, cluster, kgo.GzipCompression(),
+		record(1, "request 1", baseTimestamp+500),
+		record(1, "request 2", baseTimestamp+1500))
+}
+
+// values formats messages sorted by partition, the order of partitions within a poll is not defined.
+func values(messages []consumer.Message) []string {
+	sorted := append([]consumer.Message(nil), messages...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Partition < sorted[j].Partition })
+	var result []string
+	for _, m := range sorted {
+		result = append(result, fmt.Sprintf("%d/%d %s", m.Partition, m.Offset, m.Value))
+	}
+	return result
+}
+
+func tail(t *testing.T, c *consumer.Consumer, opts consumer.TailOpts) []consumer.Message {
+	var messages []consumer.Message
+	err := c.Tail(context.Background(), opts, func(m consumer.Message) error {
+		messages = append(messages, m)
+		return nil
+	})
+	require.NoError(t, err)
+	return messages
+}
+
+func TestTailOldest(t *testing.T) {
+	cluster := newCluster(t, 2)
+	seed(t, cluster)
+	c := newConsumer(t, cluster, consumer.MechanismPlain)
+
+	messages := tail(t, c, consumer.TailOpts{Topic: Topic, Offset: consumer.OffsetOldest})
+	require.Equal(t, []string{
+		"0/0 loading model", "0/1 model loaded", "0/2 serving",
+		"1/0 request 1", "1/1 request 2",
+	}, values(messages))
+	for _, m := range messages {
+		require.Equal(t, Topic, m.Topic)
+		require.Equal(t, []consumer.Header{{Key: "host", Value: []byte("gpu")}}, m.Headers)
+		require.Nil(t, m.Key)
+		if m.Partition == 0 && m.Offset == 1 {
+			require.Equal(t, time.UnixMilli(baseTimestamp+1000).UTC(), m.Timestamp)
+		}
+	}
+}
+
+func TestTailCompression(t *testing.T) {
+	codecs := []kgo.CompressionCodec{kgo.GzipCompression(), kgo.SnappyCompression(), kgo.Lz4Compression(), kgo.ZstdCompression()}
+	cluster := newCluster(t, int32(len(codecs)))
+	for p, codec := range codecs {
+		produce(t, cluster, codec, record(int32(p), "model loaded", baseTimestamp), record(int32(p), "serving", baseTimestamp+1000))
+	}
+	c := newConsumer(t, cluster, consumer.MechanismPlain)
+
+	require.Equal(t, []string{
+		"0/0 model loaded", "0/1 serving",
+		"1/0 model loaded", "1/1 serving",
+		"2/0 model loaded", "2/1 serving",
+		"3/0 model loaded", "3/1 serving",
+	}, values(tail(t, c, consumer.TailOpts{Topic: Topic, Offset: consumer.OffsetOldest})))
+}
+
+func TestTailPositions(t *testing.T) {
+	cluster := newCluster(t, 2)
+	seed(t, cluster)
+	c := newConsumer(t, cluster, consumer.MechanismPlain)
+
+	require.Equal(t, []string{"0/2 serving", "1/1 request 2"},
+		values(tail(t, c, consumer.TailOpts{Topic: Topic, Last: 1})))
+	require.Equal(t, []string{"0/2 serving", "1/1 request 2"},
+		values(tail(t, c, consumer.TailOpts{Topic: Topic, Since: time.UnixMilli(baseTimestamp + 1200)})))
+	require.Equal(t, []string{"0/2 serving"},
+		values(tail(t, c, consumer.TailOpts{Topic: Topic, Offset: 2})))
+	require.Empty(t, tail(t, c, consumer.TailOpts{Topic: Topic}))
+}
+
+// follow tails the topic until count messages are read.
+func follow(t *testing.T, c *consumer.Consumer, opts consumer.TailOpts, count int, ready func()) []consumer.Message {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+	opts.Follow = true
+	opts.PollInterval = 50 * time.Millisecond
+
+	var mu sync.Mutex
+	var messages []consumer.Message
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Tail(ctx, opts, func(m consumer.Message) error {
+			mu.Lock()
+			defer mu.Unlock()
+			messages = append(messages, m)
+			if len(messages) == count {
+				cancel()
+			}
+			return nil
+		})
+	}()
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(messages) == count-1
+	}, 10*time.Second, 10*time.Millisecond)
+	ready()
+
+	err := <-done
+	require.True(t, errors.Is(err, context.Canceled), err)
+	return messages
+}
+
+func TestTailFollow(t *testing.T) {
+	cluster := newCluster(t, 2)
+	seed(t, cluster)
+	c := newConsumer(t, cluster, consumer.MechanismSCRAMSHA512)
+
+	messages := follow(t, c, consumer.TailOpts{Topic: Topic, Last: 1}, 3, func() {
+		produce(t, cluster, kgo.NoCompression(), record(1, "request 3", baseTimestamp+3000))
+	})
+	require.Equal(t, []string{"0/2 serving", "1/1 request 2", "1/2 request 3"}, values(messages))
+}
+
+func TestTailFollowRecovers(t *testing.T) {
+	cluster := newCluster(t, 1, kfake.NumBrokers(2))
+	produce(t, cluster, kgo.NoCompression(), record(0, "loading model", baseTimestamp))
+	c := newConsumer(t, cluster, consumer.MechanismPlain)
+
+	// The first fetch drops the connection.
+	cluster.ControlKey(kmsg.Fetch.Int16(), func(kmsg.Request) (kmsg.Response, error, bool) {
+		return nil, errors.New("connection dropped"), true
+	})
+	messages := follow(t, c, consumer.TailOpts{Topic: Topic, Offset: consumer.OffsetOldest}, 2, func() {
+		leader := cluster.LeaderFor(Topic, 0)
+		for _, node := range []int32{0, 1} {
+			if node != leader {
+				require.NoError(t, cluster.MoveTopicPartition(Topic, 0, node))
+			}
+		}
+		produce(t, cluster, kgo.NoCompression(), record(0, "serving", baseTimestamp+1000))
+	})
+	require.Equal(t, []string{"0/0 loading model", "0/1 serving"}, values(messages))
+}
+
+func TestTailOffsetOutOfRange(t *testing.T) {
+	cluster := newCluster(t, 2)
+	seed(t, cluster)
+	deleted, err := kadm.NewClient(newClient(t, cluster)).DeleteRecords(context.Background(), kadm.Offsets{
+		Topic: {0: {At: 2}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, deleted.Error())
+	c := newConsumer(t, cluster, consumer.MechanismPlain)
+
+	require.Equal(t, []string{"0/2 serving", "1/1 request 2"},
+		values(tail(t, c, consumer.TailOpts{Topic: Topic, Offset: 1})))
+}
+
+func TestTailHandlerError(t *testing.T) {
+	cluster := newCluster(t, 2)
+	seed(t, cluster)
+	c := newConsumer(t, cluster, consumer.MechanismPlain)
+
+	err := c.Tail(context.Background(), consumer.TailOpts{Topic: Topic, Offset: consumer.OffsetOldest}, func(m consumer.Message) error {
+		return errors.New("stop")
+	})
+	require.EqualError(t, err, "stop")
+}
+
+func TestTailErrors(t *testing.T) {
+	cluster := newCluster(t, 1)
+	c, err := consumer.New(consumer.Config{Brokers: cluster.ListenAddrs(), Username: Username, Password: "other"})
+	require.NoError(t, err)
+	defer c.Close()
+	// The broker closes the connection of a failed authentication and the client keeps retrying.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err = c.Tail(ctx, consumer.TailOpts{Topic: Topic}, func(consumer.Message) error { return nil })
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cannot list offsets of topic "+Topic)
+
+	c = newConsumer(t, cluster, consumer.MechanismPlain)
+	err = c.Tail(context.Background(), consumer.TailOpts{Topic: "missing"}, func(consumer.Message) error { return nil })
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cannot list offsets of topic missing")
+	require.Contains(t, err.Error(), "UNKNOWN_TOPIC_OR_PARTITION")
+}
+
+func TestLaaSConfig(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	th.Mux.HandleFunc(fmt.Sprintf("/v1/laas/%d/kafka_hosts", fake.RegionID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, KafkaHostsResponse)
+	})
+
+	config, err := consumer.LaaSConfig(fake.ServiceTokenClient("laas", "v1"), consumer.LaaSOpts{
+		User: &laas.User{Username: Username, Password: Password},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"kafka.example.com:9093", "kafka2.example.com:9094"}, config.Brokers)
+	require.Equal(t, Username, config.Username)
+	require.NotNil(t, config.TLS)
+}
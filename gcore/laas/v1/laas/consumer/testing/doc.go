@@ -0,0 +1,2 @@
+// consumer unit tests
+package testing
@@ -0,0 +1,53 @@
+package testing
+
+const (
+	Topic    = "inference-logs"
+	Username = "2ehwccnfzytsnt576dkmvs"
+	Password = "LnHyPmVor6dAufMtR8GC5WNcNg5NjjAIksjIlFNbaEQ"
+	Index    = "inference-logs*"
+)
+
+const KafkaHostsResponse = `
+{
+  "hosts": [
+    "kafka.example.com",
+    "kafka2.example.com:9094"
+  ]
+}
+`
+
+const SearchRequest = `
+{
+  "size": 2,
+  "sort": [{"@timestamp": {"order": "desc"}}],
+  "query": {
+    "bool": {
+      "filter": [
+        {"query_string": {"query": "level:error"}},
+        {"range": {"@timestamp": {"gte": "2025-06-25T08:00:00Z"}}}
+      ]
+    }
+  }
+}
+`
+
+const SearchResponse = `
+{
+  "took": 3,
+  "timed_out": false,
+  "hits": {
+    "total": {"value": 7, "relation": "eq"},
+    "hits": [
+      {"_index": "inference-logs-2025.06.25", "_id": "a1", "_source": {"level": "error", "message": "out of memory"}},
+      {"_index": "inference-logs-2025.06.25", "_id": "a2", "_source": {"level": "error", "message": "timeout"}}
+    ]
+  }
+}
+`
+
+const SearchErrorResponse = `
+{
+  "error": {"type": "index_not_found_exception", "reason": "no such index [missing]"},
+  "status": 404
+}
+`
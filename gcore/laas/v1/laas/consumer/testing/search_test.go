@@ -0,0 +1,85 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/laas/v1/laas/consumer"
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+)
+
+func searchOpts() consumer.SearchOpts {
+	return consumer.SearchOpts{
+		Index: Index,
+		Query: "level:error",
+		Since: time.Date(2025, 6, 25, 8, 0, 0, 0, time.UTC),
+		Size:  2,
+	}
+}
+
+func TestSearch(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	th.Mux.HandleFunc("/inference-logs*/_search", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		username, password, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, Username, username)
+		require.Equal(t, Password, password)
+		th.TestJSONRequest(t, r, SearchRequest)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, SearchResponse)
+	})
+	unavailable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unavailable.Close()
+
+	client := consumer.SearchClient{
+		Hosts:    []string{unavailable.URL, th.Server.URL},
+		Username: Username,
+		Password: Password,
+	}
+	result, err := client.Search(context.Background(), searchOpts())
+	require.NoError(t, err)
+	require.Equal(t, 7, result.Total)
+	require.Len(t, result.Hits, 2)
+	require.Equal(t, "a1", result.Hits[0].ID)
+	require.JSONEq(t, `{"level": "error", "message": "out of memory"}`, string(result.Hits[0].Source))
+}
+
+func TestSearchError(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	th.Mux.HandleFunc("/missing/_search", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprint(w, SearchErrorResponse)
+	})
+
+	client := consumer.SearchClient{Hosts: []string{th.Server.URL, "http://127.0.0.1:1"}}
+	opts := searchOpts()
+	opts.Index = "missing"
+	_, err := client.Search(context.Background(), opts)
+	require.EqualError(t, err, "opensearch: index_not_found_exception: no such index [missing]")
+
+	opts.Index = ""
+	_, err = client.Search(context.Background(), opts)
+	require.Error(t, err)
+}
+
+func TestSearchBody(t *testing.T) {
+	body := consumer.SearchOpts{Index: Index, Ascending: true, TimestampField: "time"}.Body()
+	require.Equal(t, map[string]interface{}{
+		"size":  consumer.DefaultSearchSize,
+		"sort":  []interface{}{map[string]interface{}{"time": map[string]interface{}{"order": "asc"}}},
+		"query": map[string]interface{}{"match_all": map[string]interface{}{}},
+	}, body)
+}
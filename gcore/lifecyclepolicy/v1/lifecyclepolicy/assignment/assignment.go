@@ -0,0 +1,254 @@
+package assignment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/lifecyclepolicy/v1/lifecyclepolicy"
+	"github.com/G-Core/gcorelabscloud-go/gcore/volume/v1/volumes"
+)
+
+// DefaultInterval is how often Run reconciles the policies.
+const DefaultInterval = 10 * time.Minute
+
+// EventType is the kind of an Event.
+type EventType string
+
+const (
+	// EventDrift is reported for every policy whose volumes differ from the volumes matching its rules.
+	EventDrift EventType = "drift"
+	// EventAdded is reported when matching volumes were added to a policy.
+	EventAdded EventType = "added"
+	// EventRemoved is reported when volumes which match no rule were removed from a policy.
+	EventRemoved EventType = "removed"
+	// EventFailed is reported when a policy could not be checked or changed.
+	EventFailed EventType = "failed"
+)
+
+// Event describes something the reconciler found or did.
+type Event struct {
+	Time      time.Time `json:"time"`
+	Type      EventType `json:"type"`
+	PolicyID  int       `json:"policy_id,omitempty"`
+	VolumeIDs []string  `json:"volume_ids,omitempty"`
+	Err       error     `json:"-"`
+}
+
+// Rule assigns the volumes having all metadata of the selector to a lifecycle policy.
+type Rule struct {
+	Selector map[string]string `json:"selector" validate:"required,min=1"`
+	PolicyID int               `json:"policy_id" validate:"required,gt=0"`
+}
+
+// String formats the rule like ParseRule expects it.
+func (r Rule) String() string {
+	pairs := make([]string, 0, len(r.Selector))
+	for k, v := range r.Selector {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return fmt.Sprintf("%d:%s", r.PolicyID, strings.Join(pairs, ","))
+}
+
+// ParseRule parses a rule written as policy_id:key=value[,key=value...], e.g. 12:backup=daily.
+func ParseRule(s string) (Rule, error) {
+	id, selector, found := strings.Cut(s, ":")
+	policyID, err := strconv.Atoi(id)
+	if err != nil || !found || policyID <= 0 {
+		return Rule{}, fmt.Errorf("invalid rule %q, expected policy_id:key=value[,key=value...]", s)
+	}
+	rule := Rule{PolicyID: policyID, Selector: map[string]string{}}
+	for _, pair := range strings.Split(selector, ",") {
+		k, v, found := strings.Cut(pair, "=")
+		if !found || k == "" {
+			return Rule{}, fmt.Errorf("invalid rule %q, expected policy_id:key=value[,key=value...]", s)
+		}
+		rule.Selector[k] = v
+	}
+	return rule, nil
+}
+
+// Clients groups service clients used by a reconciler.
+type Clients struct {
+	// LifecyclePolicy is a lifecycle policy v1 client.
+	LifecyclePolicy *gcorecloud.ServiceClient `validate:"required"`
+	// Volumes is a volumes v1 client of the same project and region.
+	Volumes *gcorecloud.ServiceClient `validate:"required"`
+}
+
+// Opts represents options of a reconciler.
+type Opts struct {
+	Rules []Rule `validate:"required,min=1,dive"`
+	// DryRun only reports the drift, the policies are not changed.
+	DryRun bool
+	// Interval defaults to DefaultInterval.
+	Interval time.Duration `validate:"omitempty,gt=0"`
+	// OnEvent is called for every event.
+	OnEvent func(Event)
+}
+
+// Drift is the difference between the volumes of a policy and the volumes matching its rules.
+type Drift struct {
+	PolicyID   int    `json:"policy_id"`
+	PolicyName string `json:"policy_name"`
+	// Missing are matching volumes which are not in the policy.
+	Missing []lifecyclepolicy.Volume `json:"missing"`
+	// Unexpected are volumes of the policy which match none of its rules.
+	Unexpected []lifecyclepolicy.Volume `json:"unexpected"`
+}
+
+// InSync reports whether the policy has exactly the matching volumes.
+func (d Drift) InSync() bool {
+	return len(d.Missing) == 0 && len(d.Unexpected) == 0
+}
+
+// Reconciler keeps the volumes of lifecycle policies in line with metadata selectors.
+// Volumes of a policy are the union of the volumes matching its rules, so volumes added to the policy
+// by hand are removed unless they match one of them.
+type Reconciler struct {
+	clients Clients
+	opts    Opts
+}
+
+// New creates a Reconciler.
+func New(clients Clients, opts Opts) (*Reconciler, error) {
+	if err := gcorecloud.ValidateStruct(clients); err != nil {
+		return nil, err
+	}
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	if opts.Interval == 0 {
+		opts.Interval = DefaultInterval
+	}
+	return &Reconciler{clients: clients, opts: opts}, nil
+}
+
+// Run reconciles the policies every interval until the context is done.
+// Failures are reported as events and do not stop it.
+func (r *Reconciler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.opts.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := r.Reconcile(); err != nil {
+			r.report(Event{Type: EventFailed, Err: err})
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Drift compares the volumes of every policy of the rules with the volumes matching the rules, ordered by policy ID.
+func (r *Reconciler) Drift() ([]Drift, error) {
+	matching := map[int]map[string]volumes.Volume{}
+	var ids []int
+	for _, rule := range r.opts.Rules {
+		list, err := volumes.ListAll(r.clients.Volumes, volumes.ListOpts{MetadataKV: rule.Selector})
+		if err != nil {
+			return nil, fmt.Errorf("cannot list volumes of rule %s: %w", rule, err)
+		}
+		if matching[rule.PolicyID] == nil {
+			matching[rule.PolicyID] = map[string]volumes.Volume{}
+			ids = append(ids, rule.PolicyID)
+		}
+		for _, v := range list {
+			matching[rule.PolicyID][v.ID] = v
+		}
+	}
+	slices.Sort(ids)
+
+	drifts := make([]Drift, 0, len(ids))
+	for _, id := range ids {
+		policy, err := lifecyclepolicy.Get(r.clients.LifecyclePolicy, id, lifecyclepolicy.GetOpts{NeedVolumes: true}).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("cannot get lifecycle policy %d: %w", id, err)
+		}
+		drift := Drift{PolicyID: id, PolicyName: policy.Name, Missing: []lifecyclepolicy.Volume{}, Unexpected: []lifecyclepolicy.Volume{}}
+		assigned := map[string]bool{}
+		for _, v := range policy.Volumes {
+			assigned[v.ID] = true
+			if _, ok := matching[id][v.ID]; !ok {
+				drift.Unexpected = append(drift.Unexpected, v)
+			}
+		}
+		for _, v := range matching[id] {
+			if !assigned[v.ID] {
+				drift.Missing = append(drift.Missing, lifecyclepolicy.Volume{ID: v.ID, Name: v.Name})
+			}
+		}
+		slices.SortFunc(drift.Missing, func(a, b lifecyclepolicy.Volume) int { return strings.Compare(a.ID, b.ID) })
+		slices.SortFunc(drift.Unexpected, func(a, b lifecyclepolicy.Volume) int { return strings.Compare(a.ID, b.ID) })
+		drifts = append(drifts, drift)
+	}
+	return drifts, nil
+}
+
+// Reconcile adds the missing volumes to the policies and removes the unexpected ones, unless DryRun is set.
+// It returns the drift found before the changes. A failed policy does not stop the others, their errors are joined.
+func (r *Reconciler) Reconcile() ([]Drift, error) {
+	drifts, err := r.Drift()
+	if err != nil {
+		return nil, err
+	}
+	var errs []error
+	for _, d := range drifts {
+		if d.InSync() {
+			continue
+		}
+		r.report(Event{Type: EventDrift, PolicyID: d.PolicyID, VolumeIDs: volumeIDs(append(d.Missing, d.Unexpected...))})
+		if r.opts.DryRun {
+			continue
+		}
+		if err := r.apply(d); err != nil {
+			r.report(Event{Type: EventFailed, PolicyID: d.PolicyID, Err: err})
+			errs = append(errs, err)
+		}
+	}
+	return drifts, errors.Join(errs...)
+}
+
+func (r *Reconciler) apply(d Drift) error {
+	if len(d.Missing) > 0 {
+		ids := volumeIDs(d.Missing)
+		opts := lifecyclepolicy.AddVolumesOpts{VolumeIds: ids}
+		if _, err := lifecyclepolicy.AddVolumes(r.clients.LifecyclePolicy, d.PolicyID, opts).Extract(); err != nil {
+			return fmt.Errorf("cannot add volumes to lifecycle policy %d: %w", d.PolicyID, err)
+		}
+		r.report(Event{Type: EventAdded, PolicyID: d.PolicyID, VolumeIDs: ids})
+	}
+	if len(d.Unexpected) > 0 {
+		ids := volumeIDs(d.Unexpected)
+		opts := lifecyclepolicy.RemoveVolumesOpts{VolumeIds: ids}
+		if _, err := lifecyclepolicy.RemoveVolumes(r.clients.LifecyclePolicy, d.PolicyID, opts).Extract(); err != nil {
+			return fmt.Errorf("cannot remove volumes from lifecycle policy %d: %w", d.PolicyID, err)
+		}
+		r.report(Event{Type: EventRemoved, PolicyID: d.PolicyID, VolumeIDs: ids})
+	}
+	return nil
+}
+
+func volumeIDs(list []lifecyclepolicy.Volume) []string {
+	ids := make([]string, 0, len(list))
+	for _, v := range list {
+		ids = append(ids, v.ID)
+	}
+	return ids
+}
+
+func (r *Reconciler) report(e Event) {
+	if r.opts.OnEvent == nil {
+		return
+	}
+	e.Time = time.Now()
+	r.opts.OnEvent(e)
+}
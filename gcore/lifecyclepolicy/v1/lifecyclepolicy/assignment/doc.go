@@ -0,0 +1,40 @@
+/*
+Package assignment assigns volumes to lifecycle policies by metadata selectors
+
+Every rule maps a metadata selector, such as backup=daily, to a lifecycle policy. Volumes having all metadata
+of a selector are added to the policy and volumes of the policy matching none of its rules are removed, so that
+new volumes are protected without adding them by hand.
+
+Example to report the drift without changing the policies
+
+	r, err := assignment.New(assignment.Clients{LifecyclePolicy: policyClient, Volumes: volumeClient}, assignment.Opts{
+		Rules:  []assignment.Rule{{Selector: map[string]string{"backup": "daily"}, PolicyID: 12}},
+		DryRun: true,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	drifts, err := r.Reconcile()
+	if err != nil {
+		panic(err)
+	}
+
+Example to reconcile the policies every five minutes until the context is canceled
+
+	r, err := assignment.New(assignment.Clients{LifecyclePolicy: policyClient, Volumes: volumeClient}, assignment.Opts{
+		Rules:    []assignment.Rule{{Selector: map[string]string{"backup": "daily"}, PolicyID: 12}},
+		Interval: 5 * time.Minute,
+		OnEvent: func(e assignment.Event) {
+			log.Printf("%s policy %d %v: %v", e.Type, e.PolicyID, e.VolumeIDs, e.Err)
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	if err := r.Run(ctx); err != nil {
+		panic(err)
+	}
+*/
+package assignment
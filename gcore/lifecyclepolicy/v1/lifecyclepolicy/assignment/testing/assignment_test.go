@@ -0,0 +1,191 @@
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/lifecyclepolicy/v1/lifecyclepolicy"
+	"github.com/G-Core/gcorelabscloud-go/gcore/lifecyclepolicy/v1/lifecyclepolicy/assignment"
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+var rules = []assignment.Rule{
+	{Selector: map[string]string{"backup": "daily"}, PolicyID: 12},
+	{Selector: map[string]string{"backup": "hourly"}, PolicyID: 12},
+	{Selector: map[string]string{"tier": "prod"}, PolicyID: 13},
+}
+
+func policyURL(id int, action ...string) string {
+	url := fmt.Sprintf("/v1/lifecycle_policy/%d/%d/%d", fake.ProjectID, fake.RegionID, id)
+	if len(action) > 0 {
+		url += "/" + action[0]
+	}
+	return url
+}
+
+func respond(w http.ResponseWriter, response string) {
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, err := fmt.Fprint(w, response)
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+func handleDrift(t *testing.T) {
+	th.Mux.HandleFunc(fmt.Sprintf("/v1/volumes/%d/%d", fake.ProjectID, fake.RegionID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodGet)
+		var selector map[string]string
+		require.NoError(t, json.Unmarshal([]byte(r.URL.Query().Get("metadata_kv")), &selector))
+		switch {
+		case selector["backup"] == "daily":
+			respond(w, DailyVolumesResponse)
+		case selector["backup"] == "hourly":
+			respond(w, HourlyVolumesResponse)
+		case selector["tier"] == "prod":
+			respond(w, ProdVolumesResponse)
+		default:
+			t.Errorf("unexpected selector %v", selector)
+		}
+	})
+	for id, response := range map[int]string{12: BackupPolicyResponse, 13: ProdPolicyResponse} {
+		response := response
+		th.Mux.HandleFunc(policyURL(id), func(w http.ResponseWriter, r *http.Request) {
+			th.TestMethod(t, r, http.MethodGet)
+			require.Equal(t, "true", r.URL.Query().Get("need_volumes"))
+			respond(w, response)
+		})
+	}
+}
+
+func newReconciler(t *testing.T, dryRun bool, events *[]assignment.Event) *assignment.Reconciler {
+	clients := assignment.Clients{
+		LifecyclePolicy: fake.ServiceTokenClient("lifecycle_policy", "v1"),
+		Volumes:         fake.ServiceTokenClient("volumes", "v1"),
+	}
+	r, err := assignment.New(clients, assignment.Opts{Rules: rules, DryRun: dryRun, OnEvent: func(e assignment.Event) {
+		*events = append(*events, e)
+	}})
+	require.NoError(t, err)
+	return r
+}
+
+var expectedDrifts = []assignment.Drift{
+	{
+		PolicyID:   12,
+		PolicyName: "backup",
+		Missing:    []lifecyclepolicy.Volume{{ID: "vol-a", Name: "db-data"}, {ID: "vol-c", Name: "queue-data"}},
+		Unexpected: []lifecyclepolicy.Volume{{ID: "vol-x", Name: "manual"}},
+	},
+	{
+		PolicyID:   13,
+		PolicyName: "prod",
+		Missing:    []lifecyclepolicy.Volume{},
+		Unexpected: []lifecyclepolicy.Volume{},
+	},
+}
+
+func TestReconcile(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	handleDrift(t)
+	th.Mux.HandleFunc(policyURL(12, "add_volumes_to_policy"), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodPut)
+		th.TestJSONRequest(t, r, AddVolumesRequest)
+		respond(w, BackupPolicyResponse)
+	})
+	th.Mux.HandleFunc(policyURL(12, "remove_volumes_from_policy"), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, http.MethodPut)
+		th.TestJSONRequest(t, r, RemoveVolumesRequest)
+		respond(w, BackupPolicyResponse)
+	})
+
+	var events []assignment.Event
+	drifts, err := newReconciler(t, false, &events).Reconcile()
+	require.NoError(t, err)
+	require.Equal(t, expectedDrifts, drifts)
+	require.False(t, drifts[0].InSync())
+	require.True(t, drifts[1].InSync())
+
+	require.Len(t, events, 3)
+	require.Equal(t, assignment.EventDrift, events[0].Type)
+	require.Equal(t, []string{"vol-a", "vol-c", "vol-x"}, events[0].VolumeIDs)
+	require.Equal(t, assignment.EventAdded, events[1].Type)
+	require.Equal(t, []string{"vol-a", "vol-c"}, events[1].VolumeIDs)
+	require.Equal(t, assignment.EventRemoved, events[2].Type)
+	require.Equal(t, []string{"vol-x"}, events[2].VolumeIDs)
+}
+
+func TestReconcileDryRun(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	handleDrift(t)
+
+	var events []assignment.Event
+	drifts, err := newReconciler(t, true, &events).Reconcile()
+	require.NoError(t, err)
+	require.Equal(t, expectedDrifts, drifts)
+	require.Len(t, events, 1)
+	require.Equal(t, assignment.EventDrift, events[0].Type)
+	require.Equal(t, 12, events[0].PolicyID)
+}
+
+func TestReconcileFailed(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	handleDrift(t)
+	th.Mux.HandleFunc(policyURL(12, "add_volumes_to_policy"), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	var events []assignment.Event
+	drifts, err := newReconciler(t, false, &events).Reconcile()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cannot add volumes to lifecycle policy 12")
+	require.Len(t, drifts, 2)
+	require.Equal(t, assignment.EventFailed, events[len(events)-1].Type)
+}
+
+func TestRun(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	handleDrift(t)
+
+	var events []assignment.Event
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// A canceled context still reconciles once.
+	require.NoError(t, newReconciler(t, true, &events).Run(ctx))
+	require.Len(t, events, 1)
+}
+
+func TestNew(t *testing.T) {
+	clients := assignment.Clients{
+		LifecyclePolicy: fake.ServiceTokenClient("lifecycle_policy", "v1"),
+		Volumes:         fake.ServiceTokenClient("volumes", "v1"),
+	}
+	_, err := assignment.New(clients, assignment.Opts{})
+	require.Error(t, err)
+	_, err = assignment.New(clients, assignment.Opts{Rules: []assignment.Rule{{PolicyID: 12, Selector: map[string]string{}}}})
+	require.Error(t, err)
+	_, err = assignment.New(assignment.Clients{}, assignment.Opts{Rules: rules})
+	require.Error(t, err)
+}
+
+func TestParseRule(t *testing.T) {
+	rule, err := assignment.ParseRule("12:backup=daily,tier=prod")
+	require.NoError(t, err)
+	require.Equal(t, assignment.Rule{PolicyID: 12, Selector: map[string]string{"backup": "daily", "tier": "prod"}}, rule)
+	require.Equal(t, "12:backup=daily,tier=prod", rule.String())
+
+	for _, s := range []string{"backup=daily", "x:backup=daily", "0:backup=daily", "12:", "12:backup", "12:=daily"} {
+		_, err := assignment.ParseRule(s)
+		require.Error(t, err, s)
+	}
+}
@@ -0,0 +1,2 @@
+// assignment unit tests
+package testing
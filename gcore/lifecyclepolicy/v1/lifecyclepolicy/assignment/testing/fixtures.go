@@ -0,0 +1,68 @@
+package testing
+
+const DailyVolumesResponse = `
+{
+  "count": 2,
+  "results": [
+    {"id": "vol-a", "name": "db-data", "size": 10},
+    {"id": "vol-b", "name": "web-data", "size": 5}
+  ]
+}
+`
+
+const HourlyVolumesResponse = `
+{
+  "count": 1,
+  "results": [
+    {"id": "vol-c", "name": "queue-data", "size": 20}
+  ]
+}
+`
+
+const ProdVolumesResponse = `
+{
+  "count": 1,
+  "results": [
+    {"id": "vol-a", "name": "db-data", "size": 10}
+  ]
+}
+`
+
+const BackupPolicyResponse = `
+{
+  "id": 12,
+  "name": "backup",
+  "status": "active",
+  "action": "volume_snapshot",
+  "schedules": [],
+  "volumes": [
+    {"volume_id": "vol-b", "volume_name": "web-data"},
+    {"volume_id": "vol-x", "volume_name": "manual"}
+  ]
+}
+`
+
+const ProdPolicyResponse = `
+{
+  "id": 13,
+  "name": "prod",
+  "status": "active",
+  "action": "volume_snapshot",
+  "schedules": [],
+  "volumes": [
+    {"volume_id": "vol-a", "volume_name": "db-data"}
+  ]
+}
+`
+
+const AddVolumesRequest = `
+{
+  "volume_ids": ["vol-a", "vol-c"]
+}
+`
+
+const RemoveVolumesRequest = `
+{
+  "volume_ids": ["vol-x"]
+}
+`
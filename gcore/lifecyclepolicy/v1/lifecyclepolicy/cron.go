@@ -0,0 +1,204 @@
+package lifecyclepolicy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchYears bounds the search for the next run of a cron schedule, so that schedules which never run end.
+const cronSearchYears = 8
+
+var weekdayNames = []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+
+// trigger computes the runs of a schedule.
+type trigger interface {
+	// next returns the first run at or after the time, false when the schedule does not run any more.
+	next(t time.Time) (time.Time, bool)
+}
+
+// intervalTrigger runs every period, the first time one period after the start.
+type intervalTrigger struct {
+	start  time.Time
+	period time.Duration
+}
+
+func (i intervalTrigger) next(t time.Time) (time.Time, bool) {
+	if !t.After(i.start) {
+		return i.start.Add(i.period), true
+	}
+	n := (t.Sub(i.start) + i.period - 1) / i.period
+	return i.start.Add(n * i.period), true
+}
+
+// cronTrigger runs at every minute matched by all fields, like the cron trigger of APScheduler.
+type cronTrigger struct {
+	location  *time.Location
+	minute    []bool
+	hour      []bool
+	day       []bool
+	lastDay   bool
+	month     []bool
+	dayOfWeek []bool
+	week      []bool
+}
+
+func parseCronSchedule(s CronSchedule) (*cronTrigger, error) {
+	location := time.UTC
+	if s.Timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(s.Timezone); err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", s.Timezone, err)
+		}
+	}
+	c := &cronTrigger{location: location}
+	fields := []struct {
+		name     string
+		value    string
+		min, max int
+		names    []string
+		set      *[]bool
+	}{
+		{"minute", s.Minute, 0, 59, nil, &c.minute},
+		{"hour", s.Hour, 0, 23, nil, &c.hour},
+		{"day", s.Day, 1, 31, nil, &c.day},
+		{"month", s.Month, 1, 12, nil, &c.month},
+		{"day_of_week", s.DayOfWeek, 0, 6, weekdayNames, &c.dayOfWeek},
+		{"week", s.Week, 1, 53, nil, &c.week},
+	}
+	for _, f := range fields {
+		value := strings.TrimSpace(f.value)
+		if f.name == "day" {
+			value, c.lastDay = parseLastDay(value)
+		}
+		set, err := parseCronField(value, f.min, f.max, f.names)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron %s %q: %w", f.name, f.value, err)
+		}
+		*f.set = set
+	}
+	return c, nil
+}
+
+// parseLastDay removes the last item of a day field, which matches the last day of the month.
+func parseLastDay(value string) (string, bool) {
+	items := strings.Split(value, ",")
+	for i, item := range items {
+		if strings.TrimSpace(item) == "last" {
+			rest := strings.Join(append(items[:i:i], items[i+1:]...), ",")
+			if rest == "" {
+				// Only the last day matches, an empty field would match every day.
+				rest = "-"
+			}
+			return rest, true
+		}
+	}
+	return value, false
+}
+
+// parseCronField parses a comma separated list of *, values, a-b ranges and /step suffixes.
+// An empty field matches every value. Names may be used for values, indexed from min.
+func parseCronField(value string, min, max int, names []string) ([]bool, error) {
+	set := make([]bool, max+1)
+	if value == "" {
+		value = "*"
+	}
+	if value == "-" {
+		return set, nil
+	}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		step := 1
+		if i := strings.Index(item, "/"); i != -1 {
+			var err error
+			if step, err = strconv.Atoi(item[i+1:]); err != nil || step < 1 {
+				return nil, fmt.Errorf("invalid step in %q", item)
+			}
+			item = item[:i]
+		}
+		first, last := min, max
+		switch {
+		case item == "*":
+		case strings.Contains(item, "-"):
+			parts := strings.SplitN(item, "-", 2)
+			var err error
+			if first, err = parseCronValue(parts[0], min, max, names); err != nil {
+				return nil, err
+			}
+			if last, err = parseCronValue(parts[1], min, max, names); err != nil {
+				return nil, err
+			}
+			if first > last {
+				return nil, fmt.Errorf("invalid range %q", item)
+			}
+		default:
+			var err error
+			if first, err = parseCronValue(item, min, max, names); err != nil {
+				return nil, err
+			}
+			if step == 1 {
+				last = first
+			}
+		}
+		for v := first; v <= last; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
+
+func parseCronValue(value string, min, max int, names []string) (int, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for i, name := range names {
+		if value == name {
+			return min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+	if v < min || v > max {
+		return 0, fmt.Errorf("%d is out of range %d-%d", v, min, max)
+	}
+	return v, nil
+}
+
+func (c *cronTrigger) matchesDay(t time.Time) bool {
+	_, week := t.ISOWeek()
+	lastDay := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, c.location).Day()
+	return (c.day[t.Day()] || c.lastDay && t.Day() == lastDay) &&
+		// day_of_week counts from Monday.
+		c.dayOfWeek[(int(t.Weekday())+6)%7] &&
+		c.week[week]
+}
+
+func (c *cronTrigger) next(t time.Time) (time.Time, bool) {
+	t = t.In(c.location)
+	if t.Truncate(time.Minute) != t {
+		t = t.Truncate(time.Minute).Add(time.Minute)
+	}
+	limit := t.AddDate(cronSearchYears, 0, 0)
+	for !t.After(limit) {
+		var skipped time.Time
+		switch {
+		case !c.month[t.Month()]:
+			skipped = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, c.location)
+		case !c.matchesDay(t):
+			skipped = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, c.location)
+		case !c.hour[t.Hour()]:
+			skipped = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, c.location)
+		case !c.minute[t.Minute()]:
+			skipped = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+		// Daylight saving changes may map the wall clock back, the search must always move forward.
+		if !skipped.After(t) {
+			skipped = t.Add(time.Minute)
+		}
+		t = skipped
+	}
+	return time.Time{}, false
+}
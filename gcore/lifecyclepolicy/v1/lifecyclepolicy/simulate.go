@@ -0,0 +1,245 @@
+package lifecyclepolicy
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+)
+
+type SimulationAction string
+
+const (
+	SimulationActionCreate SimulationAction = "create"
+	SimulationActionDelete SimulationAction = "delete"
+
+	// DeletionReasonRetention marks snapshots deleted when their retention time is over.
+	DeletionReasonRetention = "retention"
+	// DeletionReasonMaxQuantity marks the oldest snapshot deleted to keep at most max_quantity snapshots.
+	DeletionReasonMaxQuantity = "max_quantity"
+)
+
+// steadyStateYears is the period searched for the largest number of snapshots kept by a schedule with retention.
+const steadyStateYears = 1
+
+// SimulateOpts represents options for Simulate.
+type SimulateOpts struct {
+	Start time.Time `validate:"required"`
+	End   time.Time `validate:"required,gtfield=Start"`
+	// VolumeSizes are sizes in GiB by volume ID. Volumes missing from the policy are simulated as well,
+	// volumes of the policy missing from the map count as 0 GiB.
+	VolumeSizes map[string]int
+}
+
+// SimulationEvent is a snapshot creation or deletion.
+type SimulationEvent struct {
+	Time       time.Time        `json:"time"`
+	Action     SimulationAction `json:"action"`
+	VolumeID   string           `json:"volume_id"`
+	ScheduleID string           `json:"schedule_id"`
+	// Created is the creation time of the snapshot, which identifies deleted snapshots.
+	Created time.Time `json:"created"`
+	Reason  string    `json:"reason,omitempty"`
+	// Snapshots is the number of snapshots of the volume after the event.
+	Snapshots int `json:"snapshots"`
+}
+
+// VolumeSimulation summarizes the snapshots of a volume.
+type VolumeSimulation struct {
+	VolumeID string `json:"volume_id"`
+	SizeGiB  int    `json:"size_gib"`
+	Created  int    `json:"created"`
+	Deleted  int    `json:"deleted"`
+	// Snapshots is the number of snapshots at the end of the simulation.
+	Snapshots    int `json:"snapshots"`
+	MaxSnapshots int `json:"max_snapshots"`
+	// SteadyStateSnapshots is the largest number of snapshots kept once the policy has run long enough.
+	SteadyStateSnapshots int `json:"steady_state_snapshots"`
+	SteadyStateGiB       int `json:"steady_state_gib"`
+}
+
+// Simulation is the timeline of the snapshots of a lifecycle policy.
+type Simulation struct {
+	Start                time.Time          `json:"start"`
+	End                  time.Time          `json:"end"`
+	Events               []SimulationEvent  `json:"events,omitempty"`
+	Volumes              []VolumeSimulation `json:"volumes"`
+	MaxSnapshots         int                `json:"max_snapshots"`
+	MaxGiB               int                `json:"max_gib"`
+	SteadyStateSnapshots int                `json:"steady_state_snapshots"`
+	SteadyStateGiB       int                `json:"steady_state_gib"`
+}
+
+// Simulate computes the snapshots which the schedules of the policy create and delete between the start and end
+// of the options, as if the policy was active and its volumes had no snapshots at the start.
+// Every schedule keeps up to max_quantity snapshots per volume and deletes them after the retention time.
+// Snapshots are counted with the full size of their volume.
+func Simulate(policy LifecyclePolicy, opts SimulateOpts) (*Simulation, error) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	volumeIDs := make([]string, 0, len(policy.Volumes)+len(opts.VolumeSizes))
+	for _, v := range policy.Volumes {
+		volumeIDs = append(volumeIDs, v.ID)
+	}
+	for id := range opts.VolumeSizes {
+		if !slices.Contains(volumeIDs, id) {
+			volumeIDs = append(volumeIDs, id)
+		}
+	}
+	slices.Sort(volumeIDs[len(policy.Volumes):])
+	if len(volumeIDs) == 0 {
+		return nil, fmt.Errorf("lifecycle policy %s has no volumes to simulate", policy.Name)
+	}
+
+	result := &Simulation{Start: opts.Start, End: opts.End}
+	steadyState := 0
+	for i, s := range policy.Schedules {
+		common := s.GetCommonSchedule()
+		t, err := scheduleTrigger(s, opts.Start)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %s: %w", scheduleName(common, i), err)
+		}
+		if common.MaxQuantity < 1 {
+			return nil, fmt.Errorf("schedule %s: max_quantity must be positive", scheduleName(common, i))
+		}
+		events := simulateSchedule(t, common, opts.Start, opts.End)
+		for _, id := range volumeIDs {
+			for _, e := range events {
+				e.VolumeID = id
+				e.ScheduleID = common.ID
+				result.Events = append(result.Events, e)
+			}
+		}
+		steadyState += scheduleSteadyState(t, common, opts.Start)
+	}
+	// Events at the same time delete expired snapshots first.
+	slices.SortStableFunc(result.Events, func(a, b SimulationEvent) int {
+		if c := a.Time.Compare(b.Time); c != 0 {
+			return c
+		}
+		return actionOrder(a.Action) - actionOrder(b.Action)
+	})
+
+	volumes := make(map[string]*VolumeSimulation, len(volumeIDs))
+	for _, id := range volumeIDs {
+		result.Volumes = append(result.Volumes, VolumeSimulation{
+			VolumeID:             id,
+			SizeGiB:              opts.VolumeSizes[id],
+			SteadyStateSnapshots: steadyState,
+			SteadyStateGiB:       steadyState * opts.VolumeSizes[id],
+		})
+	}
+	for i := range result.Volumes {
+		v := &result.Volumes[i]
+		volumes[v.VolumeID] = v
+		result.SteadyStateSnapshots += v.SteadyStateSnapshots
+		result.SteadyStateGiB += v.SteadyStateGiB
+	}
+	snapshots, size := 0, 0
+	for i := range result.Events {
+		e := &result.Events[i]
+		v := volumes[e.VolumeID]
+		if e.Action == SimulationActionCreate {
+			v.Created++
+			v.Snapshots++
+			snapshots++
+			size += v.SizeGiB
+		} else {
+			v.Deleted++
+			v.Snapshots--
+			snapshots--
+			size -= v.SizeGiB
+		}
+		e.Snapshots = v.Snapshots
+		v.MaxSnapshots = max(v.MaxSnapshots, v.Snapshots)
+		result.MaxSnapshots = max(result.MaxSnapshots, snapshots)
+		result.MaxGiB = max(result.MaxGiB, size)
+	}
+	return result, nil
+}
+
+func actionOrder(a SimulationAction) int {
+	if a == SimulationActionDelete {
+		return 0
+	}
+	return 1
+}
+
+func scheduleName(s CommonSchedule, index int) string {
+	if s.ID != "" {
+		return s.ID
+	}
+	return fmt.Sprintf("#%d", index)
+}
+
+func scheduleTrigger(s Schedule, start time.Time) (trigger, error) {
+	switch s := s.(type) {
+	case CronSchedule:
+		return parseCronSchedule(s)
+	case IntervalSchedule:
+		period := RetentionTimer{Weeks: s.Weeks, Days: s.Days, Hours: s.Hours, Minutes: s.Minutes}.Duration()
+		if period <= 0 {
+			return nil, fmt.Errorf("interval must be positive")
+		}
+		return intervalTrigger{start: start, period: period}, nil
+	}
+	return nil, fmt.Errorf("unexpected schedule type %T", s)
+}
+
+func retention(s CommonSchedule) time.Duration {
+	if s.RetentionTime == nil {
+		return 0
+	}
+	return s.RetentionTime.Duration()
+}
+
+// simulateSchedule returns the events of a schedule for a single volume, in time order.
+func simulateSchedule(t trigger, s CommonSchedule, start, end time.Time) []SimulationEvent {
+	keep := retention(s)
+	var events []SimulationEvent
+	var kept []time.Time
+	expire := func(until time.Time) {
+		for keep > 0 && len(kept) > 0 && !kept[0].Add(keep).After(until) {
+			events = append(events, SimulationEvent{Time: kept[0].Add(keep), Action: SimulationActionDelete, Created: kept[0], Reason: DeletionReasonRetention})
+			kept = kept[1:]
+		}
+	}
+	for run, ok := t.next(start); ok && !run.After(end); run, ok = t.next(run.Add(time.Nanosecond)) {
+		expire(run)
+		if len(kept) >= s.MaxQuantity {
+			events = append(events, SimulationEvent{Time: run, Action: SimulationActionDelete, Created: kept[0], Reason: DeletionReasonMaxQuantity})
+			kept = kept[1:]
+		}
+		kept = append(kept, run)
+		events = append(events, SimulationEvent{Time: run, Action: SimulationActionCreate, Created: run})
+	}
+	expire(end)
+	return events
+}
+
+// scheduleSteadyState returns the largest number of snapshots of a volume kept by the schedule in the long run.
+// Without retention it is max_quantity unless the schedule stops running earlier.
+// With retention it is the largest number of runs within the retention time found in a year, at most max_quantity.
+func scheduleSteadyState(t trigger, s CommonSchedule, start time.Time) int {
+	keep := retention(s)
+	if keep <= 0 {
+		runs := 0
+		for run, ok := t.next(start); ok && runs < s.MaxQuantity; run, ok = t.next(run.Add(time.Nanosecond)) {
+			runs++
+		}
+		return runs
+	}
+	end := start.Add(keep).AddDate(steadyStateYears, 0, 0)
+	var kept []time.Time
+	result := 0
+	for run, ok := t.next(start); ok && !run.After(end) && result < s.MaxQuantity; run, ok = t.next(run.Add(time.Nanosecond)) {
+		for len(kept) > 0 && !kept[0].Add(keep).After(run) {
+			kept = kept[1:]
+		}
+		kept = append(kept, run)
+		result = max(result, len(kept))
+	}
+	return min(result, s.MaxQuantity)
+}
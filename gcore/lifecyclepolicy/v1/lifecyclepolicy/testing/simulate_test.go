@@ -0,0 +1,174 @@
+package testing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/lifecyclepolicy/v1/lifecyclepolicy"
+
+	"github.com/stretchr/testify/require"
+)
+
+var simulationStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func at(hours int) time.Time {
+	return simulationStart.Add(time.Duration(hours) * time.Hour)
+}
+
+func TestSimulateRetention(t *testing.T) {
+	policy := lifecyclepolicy.LifecyclePolicy{
+		Name:    "hourly",
+		Volumes: []lifecyclepolicy.Volume{{ID: "vol-1"}},
+		Schedules: []lifecyclepolicy.Schedule{lifecyclepolicy.IntervalSchedule{
+			CommonSchedule: lifecyclepolicy.CommonSchedule{
+				ID:            "hourly",
+				Type:          lifecyclepolicy.ScheduleTypeInterval,
+				MaxQuantity:   10,
+				RetentionTime: &lifecyclepolicy.RetentionTimer{Hours: 3},
+			},
+			Hours: 1,
+		}},
+	}
+	opts := lifecyclepolicy.SimulateOpts{Start: simulationStart, End: at(6), VolumeSizes: map[string]int{"vol-1": 10}}
+	result, err := lifecyclepolicy.Simulate(policy, opts)
+	require.NoError(t, err)
+
+	create := func(hour, snapshots int) lifecyclepolicy.SimulationEvent {
+		return lifecyclepolicy.SimulationEvent{Time: at(hour), Action: lifecyclepolicy.SimulationActionCreate,
+			VolumeID: "vol-1", ScheduleID: "hourly", Created: at(hour), Snapshots: snapshots}
+	}
+	expire := func(hour int) lifecyclepolicy.SimulationEvent {
+		return lifecyclepolicy.SimulationEvent{Time: at(hour + 3), Action: lifecyclepolicy.SimulationActionDelete,
+			VolumeID: "vol-1", ScheduleID: "hourly", Created: at(hour), Reason: lifecyclepolicy.DeletionReasonRetention, Snapshots: 2}
+	}
+	require.Equal(t, []lifecyclepolicy.SimulationEvent{
+		create(1, 1), create(2, 2), create(3, 3),
+		expire(1), create(4, 3),
+		expire(2), create(5, 3),
+		expire(3), create(6, 3),
+	}, result.Events)
+	require.Equal(t, []lifecyclepolicy.VolumeSimulation{{
+		VolumeID: "vol-1", SizeGiB: 10, Created: 6, Deleted: 3, Snapshots: 3, MaxSnapshots: 3,
+		SteadyStateSnapshots: 3, SteadyStateGiB: 30,
+	}}, result.Volumes)
+	require.Equal(t, 3, result.MaxSnapshots)
+	require.Equal(t, 30, result.MaxGiB)
+	require.Equal(t, 30, result.SteadyStateGiB)
+}
+
+func TestSimulateMaxQuantity(t *testing.T) {
+	policy := lifecyclepolicy.LifecyclePolicy{
+		Name:    "daily",
+		Volumes: []lifecyclepolicy.Volume{{ID: "vol-1"}},
+		Schedules: []lifecyclepolicy.Schedule{lifecyclepolicy.IntervalSchedule{
+			CommonSchedule: lifecyclepolicy.CommonSchedule{ID: "daily", Type: lifecyclepolicy.ScheduleTypeInterval, MaxQuantity: 2},
+			Days:           1,
+		}},
+	}
+	opts := lifecyclepolicy.SimulateOpts{Start: simulationStart, End: at(72), VolumeSizes: map[string]int{"vol-1": 10, "vol-2": 5}}
+	result, err := lifecyclepolicy.Simulate(policy, opts)
+	require.NoError(t, err)
+
+	require.Len(t, result.Events, 8)
+	require.Equal(t, lifecyclepolicy.SimulationEvent{
+		Time: at(72), Action: lifecyclepolicy.SimulationActionDelete, VolumeID: "vol-1", ScheduleID: "daily",
+		Created: at(24), Reason: lifecyclepolicy.DeletionReasonMaxQuantity, Snapshots: 1,
+	}, result.Events[4])
+	require.Equal(t, "vol-2", result.Events[5].VolumeID)
+	require.Equal(t, lifecyclepolicy.SimulationActionDelete, result.Events[5].Action)
+
+	require.Equal(t, []lifecyclepolicy.VolumeSimulation{
+		{VolumeID: "vol-1", SizeGiB: 10, Created: 3, Deleted: 1, Snapshots: 2, MaxSnapshots: 2, SteadyStateSnapshots: 2, SteadyStateGiB: 20},
+		{VolumeID: "vol-2", SizeGiB: 5, Created: 3, Deleted: 1, Snapshots: 2, MaxSnapshots: 2, SteadyStateSnapshots: 2, SteadyStateGiB: 10},
+	}, result.Volumes)
+	require.Equal(t, 4, result.MaxSnapshots)
+	require.Equal(t, 30, result.MaxGiB)
+	require.Equal(t, 4, result.SteadyStateSnapshots)
+	require.Equal(t, 30, result.SteadyStateGiB)
+}
+
+func TestSimulateCron(t *testing.T) {
+	workdays := lifecyclepolicy.CronSchedule{
+		CommonSchedule: lifecyclepolicy.CommonSchedule{
+			ID:            "workdays",
+			Type:          lifecyclepolicy.ScheduleTypeCron,
+			MaxQuantity:   30,
+			RetentionTime: &lifecyclepolicy.RetentionTimer{Weeks: 1},
+		},
+		Timezone:  "UTC",
+		DayOfWeek: "mon-fri",
+		Hour:      "2",
+		Minute:    "0",
+	}
+	monthEnd := lifecyclepolicy.CronSchedule{
+		CommonSchedule: lifecyclepolicy.CommonSchedule{ID: "month-end", Type: lifecyclepolicy.ScheduleTypeCron, MaxQuantity: 12},
+		Day:            "last",
+		Hour:           "23",
+		Minute:         "30",
+	}
+	policy := lifecyclepolicy.LifecyclePolicy{
+		Name:      "cron",
+		Volumes:   []lifecyclepolicy.Volume{{ID: "vol-1"}},
+		Schedules: []lifecyclepolicy.Schedule{workdays, monthEnd},
+	}
+	opts := lifecyclepolicy.SimulateOpts{Start: simulationStart, End: simulationStart.AddDate(0, 0, 31), VolumeSizes: map[string]int{"vol-1": 1}}
+	result, err := lifecyclepolicy.Simulate(policy, opts)
+	require.NoError(t, err)
+
+	var workdayRuns, monthEndRuns []time.Time
+	for _, e := range result.Events {
+		if e.Action != lifecyclepolicy.SimulationActionCreate {
+			continue
+		}
+		if e.ScheduleID == "workdays" {
+			workdayRuns = append(workdayRuns, e.Time)
+		} else {
+			monthEndRuns = append(monthEndRuns, e.Time)
+		}
+	}
+	// January 2024 starts on Monday and has 23 working days.
+	require.Len(t, workdayRuns, 23)
+	require.Equal(t, at(2), workdayRuns[0])
+	require.Equal(t, simulationStart.AddDate(0, 0, 7).Add(2*time.Hour), workdayRuns[5])
+	require.Equal(t, []time.Time{time.Date(2024, 1, 31, 23, 30, 0, 0, time.UTC)}, monthEndRuns)
+	// Five workday snapshots are kept for a week, month-end snapshots up to max_quantity.
+	require.Equal(t, 5+12, result.Volumes[0].SteadyStateSnapshots)
+	require.Equal(t, 6, result.Volumes[0].MaxSnapshots)
+}
+
+func TestSimulateErrors(t *testing.T) {
+	interval := func(minutes int) lifecyclepolicy.Schedule {
+		return lifecyclepolicy.IntervalSchedule{
+			CommonSchedule: lifecyclepolicy.CommonSchedule{Type: lifecyclepolicy.ScheduleTypeInterval, MaxQuantity: 1},
+			Minutes:        minutes,
+		}
+	}
+	cron := func(hour string) lifecyclepolicy.Schedule {
+		return lifecyclepolicy.CronSchedule{
+			CommonSchedule: lifecyclepolicy.CommonSchedule{Type: lifecyclepolicy.ScheduleTypeCron, MaxQuantity: 1},
+			Hour:           hour,
+		}
+	}
+	opts := lifecyclepolicy.SimulateOpts{Start: simulationStart, End: at(1), VolumeSizes: map[string]int{"vol-1": 1}}
+	cases := []struct {
+		name     string
+		schedule lifecyclepolicy.Schedule
+		opts     lifecyclepolicy.SimulateOpts
+		err      string
+	}{
+		{"no volumes", interval(5), lifecyclepolicy.SimulateOpts{Start: simulationStart, End: at(1)}, "no volumes"},
+		{"end before start", interval(5), lifecyclepolicy.SimulateOpts{Start: at(1), End: simulationStart}, "End"},
+		{"empty interval", interval(0), opts, "interval must be positive"},
+		{"hour out of range", cron("24"), opts, "invalid cron hour"},
+		{"invalid range", cron("5-3"), opts, "invalid range"},
+		{"invalid step", cron("*/0"), opts, "invalid step"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			policy := lifecyclepolicy.LifecyclePolicy{Name: "policy", Schedules: []lifecyclepolicy.Schedule{c.schedule}}
+			_, err := lifecyclepolicy.Simulate(policy, c.opts)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), c.err)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package lifecyclepolicy
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	gcorecloud "github.com/G-Core/gcorelabscloud-go"
 	"github.com/shopspring/decimal"
@@ -109,6 +110,12 @@ type RetentionTimer struct {
 	Minutes int `json:"minutes,omitempty"`
 }
 
+// Duration returns the retention time, zero when snapshots are kept until max_quantity is reached.
+func (t RetentionTimer) Duration() time.Duration {
+	return time.Duration(t.Weeks)*7*24*time.Hour + time.Duration(t.Days)*24*time.Hour +
+		time.Duration(t.Hours)*time.Hour + time.Duration(t.Minutes)*time.Minute
+}
+
 type CommonSchedule struct {
 	Type                 ScheduleType    `json:"type"`
 	ID                   string          `json:"id"`
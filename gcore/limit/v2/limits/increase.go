@@ -0,0 +1,182 @@
+package limits
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/limit/v2/types"
+	"github.com/G-Core/gcorelabscloud-go/gcore/quota/v2/quotas"
+)
+
+const (
+	// DefaultIncreaseThreshold is the used percent of a quota an increase is requested from.
+	DefaultIncreaseThreshold = 80
+	// DefaultIncreaseFactor multiplies the limit of a quota to get the requested one.
+	DefaultIncreaseFactor = 2
+)
+
+// IncreaseOpts represents options used to request an increase of quotas near exhaustion.
+type IncreaseOpts struct {
+	ClientID int `validate:"required"`
+	// RegionIDs defaults to every region the account has quotas in.
+	RegionIDs []int `validate:"omitempty,dive,gt=0"`
+	// Threshold is the used percent of a quota, DefaultIncreaseThreshold by default.
+	Threshold float64 `validate:"omitempty,gt=0,lte=100"`
+	// Factor multiplies the limit of a quota, DefaultIncreaseFactor by default. The requested limit is always above the usage.
+	Factor float64 `validate:"omitempty,gt=1"`
+	// Justification starts the description of the request, the requested quotas are listed after it.
+	Justification string `validate:"required"`
+}
+
+// IncreaseRequest is a quota in an increase request.
+type IncreaseRequest struct {
+	// RegionID is 0 for global quotas.
+	RegionID  int          `json:"region_id,omitempty"`
+	Name      string       `json:"name"`
+	Usage     quotas.Usage `json:"usage"`
+	Requested int          `json:"requested"`
+}
+
+// String describes the requested quota.
+func (r IncreaseRequest) String() string {
+	scope := "global"
+	if r.RegionID != 0 {
+		scope = fmt.Sprintf("region %d", r.RegionID)
+	}
+	return fmt.Sprintf("%s %s: %s, requested %d", scope, r.Name, r.Usage, r.Requested)
+}
+
+// NewRegionalLimits returns regional limits of the region with every limit set to Sentinel, so only limits set afterwards are requested.
+func NewRegionalLimits(regionID int) RegionalLimits {
+	r := RegionalLimits{}
+	setLimits(&r, Sentinel)
+	r.RegionID = regionID
+	return r
+}
+
+func setLimits(v interface{}, value int) {
+	el := reflect.ValueOf(v).Elem()
+	for i := 0; i < el.NumField(); i++ {
+		el.Field(i).SetInt(int64(value))
+	}
+}
+
+// setLimit sets the limit field of the quota name, e.g. CPUCountLimit for cpu_count.
+func setLimit(v interface{}, name string, value int) bool {
+	el := reflect.ValueOf(v).Elem()
+	for i := 0; i < el.NumField(); i++ {
+		if strings.Split(el.Type().Field(i).Tag.Get("json"), ",")[0] == name+"_limit" {
+			el.Field(i).SetInt(int64(value))
+			return true
+		}
+	}
+	return false
+}
+
+func increaseLimit(u quotas.Usage, factor float64) int {
+	limit := int(math.Ceil(float64(u.Limit) * factor))
+	if limit <= u.Usage {
+		limit = u.Usage + 1
+	}
+	return limit
+}
+
+// BuildIncrease builds a limit request raising the quotas used above the threshold. It returns nil options when no quota reaches it.
+func BuildIncrease(combined quotas.CombinedQuota, opts IncreaseOpts) (*CreateOpts, []IncreaseRequest, error) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, nil, err
+	}
+	threshold, factor := opts.Threshold, opts.Factor
+	if threshold == 0 {
+		threshold = DefaultIncreaseThreshold
+	}
+	if factor == 0 {
+		factor = DefaultIncreaseFactor
+	}
+
+	var requests []IncreaseRequest
+	limit := NewLimit()
+	global := combined.Global()
+	for _, name := range global.Above(threshold) {
+		u := global.Usages()[name]
+		requested := increaseLimit(u, factor)
+		if setLimit(&limit.GlobalLimits, name, requested) {
+			requests = append(requests, IncreaseRequest{Name: name, Usage: u, Requested: requested})
+		}
+	}
+
+	var regional []quotas.RegionalQuota
+	if len(opts.RegionIDs) == 0 {
+		regional = combined.Regional()
+	} else {
+		for _, regionID := range opts.RegionIDs {
+			q := combined.Region(regionID)
+			if q == nil {
+				return nil, nil, fmt.Errorf("no quotas for region %d", regionID)
+			}
+			regional = append(regional, *q)
+		}
+	}
+	for _, q := range regional {
+		names := q.Above(threshold)
+		if len(names) == 0 {
+			continue
+		}
+		r := NewRegionalLimits(q.RegionID)
+		for _, name := range names {
+			u := q.Usages()[name]
+			requested := increaseLimit(u, factor)
+			if setLimit(&r, name, requested) {
+				requests = append(requests, IncreaseRequest{RegionID: q.RegionID, Name: name, Usage: u, Requested: requested})
+			}
+		}
+		limit.RegionalLimits = append(limit.RegionalLimits, r)
+	}
+	if len(requests) == 0 {
+		return nil, nil, nil
+	}
+
+	lines := make([]string, 0, len(requests)+2)
+	lines = append(lines, strings.TrimSpace(opts.Justification), "")
+	for _, r := range requests {
+		lines = append(lines, r.String())
+	}
+	return &CreateOpts{Description: strings.Join(lines, "\n"), RequestedQuotas: limit}, requests, nil
+}
+
+// RequestIncrease creates a limit request raising the quotas of the account used above the threshold.
+// It returns nil when no quota reaches the threshold. Use WaitRequest to track the request.
+func RequestIncrease(limitsClient, quotasClient *gcorecloud.ServiceClient, opts IncreaseOpts) (*LimitResponse, error) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	combined, err := quotas.ListCombined(quotasClient, quotas.ListCombinedOpts{ClientID: opts.ClientID}).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get quotas: %w", err)
+	}
+	createOpts, _, err := BuildIncrease(*combined, opts)
+	if err != nil || createOpts == nil {
+		return nil, err
+	}
+	return Create(limitsClient, createOpts).Extract()
+}
+
+// WaitRequest polls a limit request until it is done or rejected. A negative timeout waits forever.
+func WaitRequest(c *gcorecloud.ServiceClient, id int, timeout int) (*LimitResponse, error) {
+	var request *LimitResponse
+	err := gcorecloud.WaitFor(timeout, func() (bool, error) {
+		r, err := Get(c, id).Extract()
+		if err != nil {
+			return false, err
+		}
+		request = r
+		return r.Status != types.LimitRequestInProgress, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot wait for limit request %d: %w", id, err)
+	}
+	return request, nil
+}
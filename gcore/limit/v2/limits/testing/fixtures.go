@@ -1,6 +1,7 @@
 package testing
 
 import (
+	"fmt"
 	"time"
 
 	gcorecloud "github.com/G-Core/gcorelabscloud-go"
@@ -95,3 +96,57 @@ var (
 
 	ExpectedLimitRequestSlice = []limits.LimitResponse{LimitRequest1}
 )
+
+const IncreaseQuotasResponse = `
+{
+  "global_quotas": {
+    "keypair_count_limit": 10,
+    "keypair_count_usage": 9,
+    "project_count_limit": 2,
+    "project_count_usage": 1
+  },
+  "regional_quotas": [
+    {
+      "region_id": 1,
+      "cpu_count_limit": 20,
+      "cpu_count_usage": 17,
+      "ram_limit": 40960,
+      "ram_usage": 10240,
+      "floating_count_limit": 0,
+      "floating_count_usage": 1
+    },
+    {
+      "region_id": 2,
+      "vm_count_limit": 100,
+      "vm_count_usage": 0
+    }
+  ]
+}
+`
+
+const IncreaseRequest = `
+{
+  "description": "Scaling the web tier\n\nglobal keypair_count: 9 of 10 used (90%), requested 20\nregion 1 cpu_count: 17 of 20 used (85%), requested 40\nregion 1 floating_count: 1 of 0 used (100%), requested 2",
+  "requested_limits": {
+    "global_limits": {"keypair_count_limit": 20},
+    "regional_limits": [
+      {"region_id": 1, "cpu_count_limit": 40, "floating_count_limit": 2}
+    ]
+  }
+}
+`
+
+func LimitResponse(status string) string {
+	return fmt.Sprintf(`
+{
+  "id": 1,
+  "client_id": 3,
+  "requested_limits": {
+    "global_limits": {"keypair_count_limit": 20},
+    "regional_limits": [{"region_id": 1, "cpu_count_limit": 40, "floating_count_limit": 2}]
+  },
+  "status": %q,
+  "created_at": "2019-07-26T13:25:03"
+}
+`, status)
+}
@@ -0,0 +1,113 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/limit/v2/limits"
+	"github.com/G-Core/gcorelabscloud-go/gcore/limit/v2/types"
+	"github.com/G-Core/gcorelabscloud-go/gcore/quota/v2/quotas"
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+)
+
+func increaseOpts() limits.IncreaseOpts {
+	return limits.IncreaseOpts{ClientID: 3, Justification: "Scaling the web tier"}
+}
+
+func handleIncreaseQuotas(t *testing.T) {
+	th.Mux.HandleFunc("/v2/client_quotas", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		require.Equal(t, "3", r.URL.Query().Get("client_id"))
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, IncreaseQuotasResponse)
+	})
+}
+
+func TestRequestIncrease(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	handleIncreaseQuotas(t)
+
+	th.Mux.HandleFunc(prepareListTestURL(), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		th.TestJSONRequest(t, r, IncreaseRequest)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(w, LimitResponse("in progress"))
+	})
+
+	client := fake.ServiceTokenClient("", "v2")
+	request, err := limits.RequestIncrease(client, client, increaseOpts())
+	require.NoError(t, err)
+	require.Equal(t, 1, request.ID)
+	require.Equal(t, types.LimitRequestInProgress, request.Status)
+}
+
+func TestBuildIncrease(t *testing.T) {
+	combined := quotas.CombinedQuota{
+		GlobalQuotas: quotas.Quota{"keypair_count_limit": 10, "keypair_count_usage": 5},
+		RegionalQuotas: []quotas.Quota{
+			{"region_id": 1, "cpu_count_limit": 20, "cpu_count_usage": 17},
+			{"region_id": 2, "ram_limit": 1000, "ram_usage": 600},
+		},
+	}
+	opts := increaseOpts()
+	opts.RegionIDs = []int{2}
+	opts.Threshold = 50
+	opts.Factor = 1.5
+
+	createOpts, requests, err := limits.BuildIncrease(combined, opts)
+	require.NoError(t, err)
+	require.Equal(t, []limits.IncreaseRequest{
+		{Name: "keypair_count", Usage: quotas.Usage{Limit: 10, Usage: 5}, Requested: 15},
+		{RegionID: 2, Name: "ram", Usage: quotas.Usage{Limit: 1000, Usage: 600}, Requested: 1500},
+	}, requests)
+	require.Equal(t, map[string]interface{}{
+		"global_limits":   map[string]interface{}{"keypair_count_limit": 15},
+		"regional_limits": []map[string]interface{}{{"region_id": 2, "ram_limit": 1500}},
+	}, createOpts.RequestedQuotas.ToRequestMap())
+
+	opts.Threshold = 100
+	createOpts, requests, err = limits.BuildIncrease(combined, opts)
+	require.NoError(t, err)
+	require.Nil(t, createOpts)
+	require.Empty(t, requests)
+
+	opts.RegionIDs = []int{5}
+	_, _, err = limits.BuildIncrease(combined, opts)
+	require.EqualError(t, err, "no quotas for region 5")
+}
+
+func TestWaitRequest(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var mu sync.Mutex
+	calls := 0
+	th.Mux.HandleFunc(prepareItemTestURL(limitRequestID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		mu.Lock()
+		calls++
+		status := "in progress"
+		if calls > 1 {
+			status = "done"
+		}
+		mu.Unlock()
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, LimitResponse(status))
+	})
+
+	request, err := limits.WaitRequest(fake.ServiceTokenClient("", "v2"), limitRequestID, 10)
+	require.NoError(t, err)
+	require.Equal(t, types.LimitRequestDone, request.Status)
+	require.Equal(t, 2, calls)
+}
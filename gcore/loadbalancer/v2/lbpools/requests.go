@@ -0,0 +1,295 @@
+package lbpools
+
+import (
+	"net"
+	"net/http"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/types"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+	"github.com/G-Core/gcorelabscloud-go/pagination"
+)
+
+func List(c *gcorecloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
+	url := listURL(c)
+	if opts != nil {
+		query, err := opts.ToLBPoolListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(c, url, func(r pagination.PageResult) pagination.Page {
+		return PoolPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// Get retrieves a specific lbpool based on its unique ID.
+func Get(c *gcorecloud.ServiceClient, id string) (r GetResult) {
+	url := getURL(c, id)
+	_, r.Err = c.Get(url, &r.Body, nil)
+	return
+}
+
+// ListOptsBuilder allows extensions to add additional parameters to the List request.
+type ListOptsBuilder interface {
+	ToLBPoolListQuery() (string, error)
+}
+
+// ListOpts allows the filtering and sorting of paginated collections through the API.
+type ListOpts struct {
+	LoadBalancerID *string `q:"loadbalancer_id"`
+	ListenerID     *string `q:"listener_id"`
+	MemberDetails  *bool   `q:"details"`
+}
+
+// ToLBPoolListQuery formats a ListOpts into a query string.
+func (opts ListOpts) ToLBPoolListQuery() (string, error) {
+	q, err := gcorecloud.BuildQueryString(opts)
+	if err != nil {
+		return "", err
+	}
+	return q.String(), err
+}
+
+// CreateOptsBuilder allows extensions to add parameters to the Create request.
+type CreateOptsBuilder interface {
+	ToLBPoolCreateMap() (map[string]interface{}, error)
+}
+
+// CreateMemberOptsBuilder allows extensions to add parameters to the CreateMember request.
+type CreateMemberOptsBuilder interface {
+	ToLBPoolMemberCreateMap() (map[string]interface{}, error)
+}
+
+// CreateHealthMonitorOptsBuilder allows extensions to add parameters to the CreateHealthMonitor request.
+type CreateHealthMonitorOptsBuilder interface {
+	ToHealthMonitorCreateMap() (map[string]interface{}, error)
+}
+
+// CreateSessionPersistenceOpts represents options used to create a lbpool session persistence rules.
+type CreateSessionPersistenceOpts struct {
+	PersistenceGranularity string                `json:"persistence_granularity,omitempty"`
+	PersistenceTimeout     int                   `json:"persistence_timeout,omitempty"`
+	Type                   types.PersistenceType `json:"type" required:"true"`
+	CookieName             string                `json:"cookie_name,omitempty"`
+}
+
+// CreateHealthMonitorOpts represents options used to create a lbpool health monitor.
+type CreateHealthMonitorOpts struct {
+	Type           types.HealthMonitorType `json:"type" required:"true"`
+	Delay          int                     `json:"delay" required:"true"`
+	MaxRetries     int                     `json:"max_retries" required:"true"`
+	Timeout        int                     `json:"timeout" required:"true"`
+	MaxRetriesDown int                     `json:"max_retries_down,omitempty"`
+	HTTPMethod     *types.HTTPMethod       `json:"http_method,omitempty"`
+	URLPath        string                  `json:"url_path,omitempty"`
+	ExpectedCodes  string                  `json:"expected_codes,omitempty"`
+}
+
+// CreatePoolMemberOpts represents options used to create a lbpool member.
+type CreatePoolMemberOpts struct {
+	Address        net.IP `json:"address" required:"true"`
+	ProtocolPort   int    `json:"protocol_port" required:"true"`
+	Weight         int    `json:"weight,omitempty"`
+	SubnetID       string `json:"subnet_id,omitempty"`
+	InstanceID     string `json:"instance_id,omitempty"`
+	MonitorAddress net.IP `json:"monitor_address,omitempty"`
+	MonitorPort    *int   `json:"monitor_port,omitempty"`
+	AdminStateUp   *bool  `json:"admin_state_up,omitempty"`
+	Backup         bool   `json:"backup,omitempty"`
+}
+
+// CreateOpts represents options used to create a lbpool.
+type CreateOpts struct {
+	Name                 string                        `json:"name" required:"true" validate:"required,name"`
+	Protocol             types.ProtocolType            `json:"protocol" required:"true"`
+	LBPoolAlgorithm      types.LoadBalancerAlgorithm   `json:"lb_algorithm" required:"true"`
+	Members              []CreatePoolMemberOpts        `json:"members,omitempty"`
+	LoadBalancerID       string                        `json:"loadbalancer_id,omitempty"`
+	ListenerID           string                        `json:"listener_id,omitempty"`
+	HealthMonitor        *CreateHealthMonitorOpts      `json:"healthmonitor,omitempty"`
+	SessionPersistence   *CreateSessionPersistenceOpts `json:"session_persistence,omitempty"`
+	TimeoutClientData    *int                          `json:"timeout_client_data,omitempty"`
+	TimeoutMemberData    *int                          `json:"timeout_member_data,omitempty"`
+	TimeoutMemberConnect *int                          `json:"timeout_member_connect,omitempty"`
+	CASecretID           string                        `json:"ca_secret_id,omitempty"`
+	CrlSecretID          string                        `json:"crl_secret_id,omitempty"`
+	SecretID             string                        `json:"secret_id,omitempty"`
+}
+
+// ToLBPoolCreateMap builds a request body from CreateOpts.
+func (opts CreateOpts) ToLBPoolCreateMap() (map[string]interface{}, error) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	return gcorecloud.BuildRequestBody(opts, "")
+}
+
+// ToHealthMonitorCreateMap builds a request body from CreateHealthMonitorOpts.
+func (opts CreateHealthMonitorOpts) ToHealthMonitorCreateMap() (map[string]interface{}, error) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	return gcorecloud.BuildRequestBody(opts, "")
+}
+
+// ToLBPoolMemberCreateMap builds a request body from CreatePoolMemberOpts.
+func (opts CreatePoolMemberOpts) ToLBPoolMemberCreateMap() (map[string]interface{}, error) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	return gcorecloud.BuildRequestBody(opts, "")
+}
+
+// Create accepts a CreateOpts struct and creates a new lbpool using the values provided.
+func Create(c *gcorecloud.ServiceClient, opts CreateOptsBuilder, reqOpts *gcorecloud.RequestOpts) (r tasks.Result) {
+	b, err := opts.ToLBPoolCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = c.Post(createURL(c), b, &r.Body, reqOpts)
+	return
+}
+
+// UpdateOptsBuilder allows extensions to add additional parameters to the Update request.
+type UpdateOptsBuilder interface {
+	ToLBPoolUpdateMap() (map[string]interface{}, error)
+}
+
+// UpdateOpts represents options used to update a lbpool.
+// Members, when set, replace the whole member list of the pool.
+type UpdateOpts struct {
+	Name                 string                        `json:"name,omitempty"`
+	Members              []CreatePoolMemberOpts        `json:"members,omitempty"`
+	Protocol             types.ProtocolType            `json:"protocol,omitempty"`
+	LBPoolAlgorithm      types.LoadBalancerAlgorithm   `json:"lb_algorithm,omitempty"`
+	HealthMonitor        *CreateHealthMonitorOpts      `json:"healthmonitor,omitempty"`
+	SessionPersistence   *CreateSessionPersistenceOpts `json:"session_persistence,omitempty"`
+	TimeoutClientData    *int                          `json:"timeout_client_data,omitempty"`
+	TimeoutMemberData    *int                          `json:"timeout_member_data,omitempty"`
+	TimeoutMemberConnect *int                          `json:"timeout_member_connect,omitempty"`
+	CASecretID           string                        `json:"ca_secret_id,omitempty"`
+	CrlSecretID          string                        `json:"crl_secret_id,omitempty"`
+	SecretID             string                        `json:"secret_id,omitempty"`
+}
+
+// ToLBPoolUpdateMap builds a request body from UpdateOpts.
+func (opts UpdateOpts) ToLBPoolUpdateMap() (map[string]interface{}, error) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	return gcorecloud.BuildRequestBody(opts, "")
+}
+
+// Update accepts a UpdateOpts struct and updates an existing lbpool using the
+// values provided. For more information, see the Create function.
+func Update(c *gcorecloud.ServiceClient, lbpoolID string, opts UpdateOptsBuilder, reqOpts *gcorecloud.RequestOpts) (r tasks.Result) {
+	b, err := opts.ToLBPoolUpdateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	if reqOpts == nil {
+		reqOpts = &gcorecloud.RequestOpts{}
+	}
+	reqOpts.OkCodes = []int{200, 201}
+	_, r.Err = c.Patch(updateURL(c, lbpoolID), b, &r.Body, reqOpts)
+	return
+}
+
+// UnsetOptsBuilder allows extensions to add additional parameters to the Unset request.
+type UnsetOptsBuilder interface {
+	ToLBPoolUnsetMap() (map[string]interface{}, error)
+}
+
+// UnsetOpts represents options used to unset lbpool fields.
+type UnsetOpts struct {
+	SessionPersistence bool `json:"session_persistence"`
+	HealthMonitor      bool `json:"healthmonitor"`
+}
+
+// ToLBPoolUnsetMap builds a request body from UnsetOpts.
+func (opts UnsetOpts) ToLBPoolUnsetMap() (map[string]interface{}, error) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	return gcorecloud.BuildRequestBody(opts, "")
+}
+
+// Unset accepts a UnsetOpts struct and unsets an existing lbpool fields using the
+// values provided.
+func Unset(c *gcorecloud.ServiceClient, lbpoolID string, opts UnsetOptsBuilder, reqOpts *gcorecloud.RequestOpts) (r tasks.Result) {
+	b, err := opts.ToLBPoolUnsetMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	for _, field := range []string{"session_persistence", "healthmonitor"} {
+		if unset, ok := b[field]; ok && unset.(bool) {
+			b[field] = nil
+		} else {
+			delete(b, field)
+		}
+	}
+	if reqOpts == nil {
+		reqOpts = &gcorecloud.RequestOpts{}
+	}
+	reqOpts.OkCodes = []int{200, 201}
+	_, r.Err = c.Patch(updateURL(c, lbpoolID), b, &r.Body, reqOpts)
+	return
+}
+
+// Delete accepts a unique ID and deletes the lbpool associated with it.
+func Delete(c *gcorecloud.ServiceClient, lbpoolID string, reqOpts *gcorecloud.RequestOpts) (r tasks.Result) {
+	_, r.Err = c.DeleteWithResponse(deleteURL(c, lbpoolID), &r.Body, reqOpts)
+	return
+}
+
+// ListAll returns all LB pools
+func ListAll(c *gcorecloud.ServiceClient, opts ListOptsBuilder) ([]Pool, error) {
+	page, err := List(c, opts).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return ExtractPools(page)
+}
+
+// CreateMember creates LB pool member
+func CreateMember(c *gcorecloud.ServiceClient, lbpoolID string, opts CreateMemberOptsBuilder, reqOpts *gcorecloud.RequestOpts) (r tasks.Result) {
+	b, err := opts.ToLBPoolMemberCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = c.Post(createMemberURL(c, lbpoolID), b, &r.Body, reqOpts)
+	return
+}
+
+// DeleteMember accepts a unique pool and member ID and deletes pool member.
+func DeleteMember(c *gcorecloud.ServiceClient, lbpoolID string, memberID string, reqOpts *gcorecloud.RequestOpts) (r tasks.Result) {
+	_, r.Err = c.DeleteWithResponse(deleteMemberURL(c, lbpoolID, memberID), &r.Body, reqOpts)
+	return
+}
+
+// CreateHealthMonitor creates LB pool healthmonitor
+func CreateHealthMonitor(c *gcorecloud.ServiceClient, lbpoolID string, opts CreateHealthMonitorOptsBuilder, reqOpts *gcorecloud.RequestOpts) (r tasks.Result) {
+	b, err := opts.ToHealthMonitorCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = c.Post(healthMonitorURL(c, lbpoolID), b, &r.Body, reqOpts)
+	return
+}
+
+// DeleteHealthMonitor accepts a unique ID and deletes the lbpool's healthmonitor associated with it.
+func DeleteHealthMonitor(c *gcorecloud.ServiceClient, lbpoolID string, reqOpts *gcorecloud.RequestOpts) (r DeleteHealthMonitorResult) {
+	if reqOpts == nil {
+		reqOpts = &gcorecloud.RequestOpts{}
+	}
+	reqOpts.OkCodes = []int{http.StatusNoContent}
+	_, r.Err = c.Delete(healthMonitorURL(c, lbpoolID), reqOpts)
+	return
+}
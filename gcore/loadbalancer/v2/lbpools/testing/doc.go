@@ -0,0 +1,2 @@
+// lbpools unit tests
+package testing
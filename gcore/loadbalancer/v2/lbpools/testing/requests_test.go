@@ -0,0 +1,383 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/types"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v2/lbpools"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+
+	"github.com/stretchr/testify/require"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Core/gcorelabscloud-go/pagination"
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+)
+
+func prepareListTestURLParams(projectID int, regionID int) string {
+	return fmt.Sprintf("/v2/lbpools/%d/%d", projectID, regionID)
+}
+
+func prepareGetTestURLParams(projectID int, regionID int, id string) string {
+	return fmt.Sprintf("/v2/lbpools/%d/%d/%s", projectID, regionID, id)
+}
+
+func prepareListTestURL() string {
+	return prepareListTestURLParams(fake.ProjectID, fake.RegionID)
+}
+
+func prepareGetTestURL(id string) string {
+	return prepareGetTestURLParams(fake.ProjectID, fake.RegionID, id)
+}
+
+func prepareActionTestURL(projectID int, regionID int, id string, action string) string {
+	return fmt.Sprintf("/v2/lbpools/%d/%d/%s/%s", projectID, regionID, id, action)
+}
+
+func prepareActionDetailTestURL(projectID int, regionID int, id string, action string, actionID string) string {
+	return fmt.Sprintf("/v2/lbpools/%d/%d/%s/%s/%s", projectID, regionID, id, action, actionID)
+}
+
+func prepareCreateMemberURL(id string) string {
+	return prepareActionTestURL(fake.ProjectID, fake.RegionID, id, "member")
+}
+
+func prepareHealthMonitorURL(id string) string {
+	return prepareActionTestURL(fake.ProjectID, fake.RegionID, id, "healthmonitor")
+}
+
+func prepareDeleteMemberURL(id string, memberID string) string {
+	return prepareActionDetailTestURL(fake.ProjectID, fake.RegionID, id, "member", memberID)
+}
+
+func TestList(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareListTestURL(), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := fmt.Fprint(w, ListResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	client := fake.ServiceTokenClient("lbpools", "v2")
+	count := 0
+
+	opts := lbpools.ListOpts{LoadBalancerID: &LoadBalancerID}
+
+	err := lbpools.List(client, opts).EachPage(func(page pagination.Page) (bool, error) {
+		count++
+		pools, err := lbpools.ExtractPools(page)
+		require.NoError(t, err)
+		pool := pools[0]
+		require.Equal(t, LBPool1, pool)
+		require.Equal(t, ExpectedLBPoolsSlice, pools)
+		return true, nil
+	})
+
+	th.AssertNoErr(t, err)
+
+	if count != 1 {
+		t.Errorf("Expected 1 page, got %d", count)
+	}
+}
+
+func TestListAll(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareListTestURL(), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := fmt.Fprint(w, ListResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	client := fake.ServiceTokenClient("lbpools", "v2")
+
+	pools, err := lbpools.ListAll(client, nil)
+	require.NoError(t, err)
+	require.Equal(t, ExpectedLBPoolsSlice, pools)
+}
+
+func TestGet(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareGetTestURL(LBPool1.ID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		_, err := fmt.Fprint(w, GetResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	client := fake.ServiceTokenClient("lbpools", "v2")
+
+	ct, err := lbpools.Get(client, LBPool1.ID).Extract()
+
+	require.NoError(t, err)
+	require.Equal(t, LBPool1, *ct)
+}
+
+func TestCreate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareListTestURL(), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, CreateRequest)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		_, err := fmt.Fprint(w, TasksResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	options := lbpools.CreateOpts{
+		Name:            LBPool1.Name,
+		Protocol:        LBPool1.Protocol,
+		LBPoolAlgorithm: LBPool1.LoadBalancerAlgorithm,
+		Members: []lbpools.CreatePoolMemberOpts{
+			{
+				Address:      *Member1.Address,
+				ProtocolPort: Member1.ProtocolPort,
+				Weight:       Member1.Weight,
+				SubnetID:     Member1.SubnetID,
+			},
+			{
+				Address:      *Member2.Address,
+				ProtocolPort: Member2.ProtocolPort,
+				Weight:       Member2.Weight,
+				SubnetID:     Member2.SubnetID,
+				Backup:       true,
+			},
+		},
+		LoadBalancerID:    LoadBalancerID,
+		ListenerID:        ListenerID,
+		TimeoutClientData: &timeoutClientData,
+	}
+
+	client := fake.ServiceTokenClient("lbpools", "v2")
+	tasks, err := lbpools.Create(client, options, nil).Extract()
+	require.NoError(t, err)
+	require.Equal(t, Tasks1, *tasks)
+}
+
+func TestDelete(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareGetTestURL(LBPool1.ID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		w.WriteHeader(http.StatusOK)
+		_, err := fmt.Fprint(w, TasksResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	client := fake.ServiceTokenClient("lbpools", "v2")
+	tasks, err := lbpools.Delete(client, LBPool1.ID, nil).Extract()
+	require.NoError(t, err)
+	require.Equal(t, Tasks1, *tasks)
+}
+
+func TestUpdate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareGetTestURL(LBPool1.ID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PATCH")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, UpdateRequest)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		_, err := fmt.Fprint(w, TasksResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	client := fake.ServiceTokenClient("lbpools", "v2")
+
+	opts := lbpools.UpdateOpts{
+		Name: LBPool1.Name,
+		Members: []lbpools.CreatePoolMemberOpts{{
+			Address:      *Member1.Address,
+			ProtocolPort: Member1.ProtocolPort,
+			Weight:       Member1.Weight,
+			SubnetID:     Member1.SubnetID,
+		}},
+	}
+
+	tasks, err := lbpools.Update(client, LBPool1.ID, opts, nil).Extract()
+	require.NoError(t, err)
+	require.Equal(t, Tasks1, *tasks)
+}
+
+func TestUnset(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareGetTestURL(LBPool1.ID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PATCH")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, UnsetRequest)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		_, err := fmt.Fprint(w, TasksResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	client := fake.ServiceTokenClient("lbpools", "v2")
+
+	opts := lbpools.UnsetOpts{
+		SessionPersistence: true,
+		HealthMonitor:      true,
+	}
+
+	tasks, err := lbpools.Unset(client, LBPool1.ID, opts, nil).Extract()
+	require.NoError(t, err)
+	require.Equal(t, Tasks1, *tasks)
+}
+
+func TestCreateMember(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareCreateMemberURL(LBPool1.ID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, CreatePoolMemberRequest)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		_, err := fmt.Fprint(w, TasksResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	adminStateUp := true
+	options := lbpools.CreatePoolMemberOpts{
+		Address:      *Member1.Address,
+		ProtocolPort: Member1.ProtocolPort,
+		Weight:       Member1.Weight,
+		SubnetID:     Member1.SubnetID,
+		AdminStateUp: &adminStateUp,
+	}
+
+	client := fake.ServiceTokenClient("lbpools", "v2")
+	tasks, err := lbpools.CreateMember(client, LBPool1.ID, options, nil).Extract()
+	require.NoError(t, err)
+	require.Equal(t, Tasks1, *tasks)
+}
+
+func TestDeleteMember(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	memberID := LBPool1.Members[0].ID
+
+	th.Mux.HandleFunc(prepareDeleteMemberURL(LBPool1.ID, memberID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		w.WriteHeader(http.StatusOK)
+		_, err := fmt.Fprint(w, TasksResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	client := fake.ServiceTokenClient("lbpools", "v2")
+	tasks, err := lbpools.DeleteMember(client, LBPool1.ID, memberID, nil).Extract()
+	require.NoError(t, err)
+	require.Equal(t, Tasks1, *tasks)
+}
+
+func TestCreateHealthMonitor(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareHealthMonitorURL(LBPool1.ID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, CreateHealthMonitorRequest)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		_, err := fmt.Fprint(w, TasksResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	opts := lbpools.CreateHealthMonitorOpts{
+		Type:           types.HealthMonitorTypeHTTP,
+		Delay:          5,
+		MaxRetries:     1,
+		Timeout:        30,
+		MaxRetriesDown: 3,
+		HTTPMethod:     types.HTTPMethodPointer(types.HTTPMethodGET),
+		URLPath:        "/",
+		ExpectedCodes:  "200,301,302",
+	}
+
+	client := fake.ServiceTokenClient("lbpools", "v2")
+	tasks, err := lbpools.CreateHealthMonitor(client, LBPool1.ID, opts, nil).Extract()
+	require.NoError(t, err)
+	require.Equal(t, Tasks1, *tasks)
+}
+
+func TestDeleteHealthMonitor(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareHealthMonitorURL(LBPool1.ID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	client := fake.ServiceTokenClient("lbpools", "v2")
+	err := lbpools.DeleteHealthMonitor(client, LBPool1.ID, nil).ExtractErr()
+	require.NoError(t, err)
+}
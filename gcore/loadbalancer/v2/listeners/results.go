@@ -0,0 +1,129 @@
+package listeners
+
+import (
+	"fmt"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/types"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+	"github.com/G-Core/gcorelabscloud-go/pagination"
+)
+
+type commonResult struct {
+	gcorecloud.Result
+}
+
+// Extract is a function that accepts a result and extracts a listener resource.
+func (r commonResult) Extract() (*Listener, error) {
+	var s Listener
+	err := r.ExtractInto(&s)
+	return &s, err
+}
+
+func (r commonResult) ExtractInto(v interface{}) error {
+	return r.Result.ExtractIntoStructPtr(v, "")
+}
+
+// GetResult represents the result of a get operation. Call its Extract
+// method to interpret it as a Listener.
+type GetResult struct {
+	commonResult
+}
+
+// UserList represents a user list structure.
+type UserList struct {
+	Username          string `json:"username"`
+	EncryptedPassword string `json:"encrypted_password"`
+}
+
+// ListenerStats represents listener traffic statistics.
+type ListenerStats struct {
+	ActiveConnections int   `json:"active_connections"`
+	BytesIn           int64 `json:"bytes_in"`
+	BytesOut          int64 `json:"bytes_out"`
+	RequestErrors     int64 `json:"request_errors"`
+	TotalConnections  int64 `json:"total_connections"`
+}
+
+// Listener represents a listener structure.
+type Listener struct {
+	PoolCount            int                      `json:"pool_count"`
+	ProtocolPort         int                      `json:"protocol_port"`
+	Protocol             types.ProtocolType       `json:"protocol"`
+	Name                 string                   `json:"name"`
+	ID                   string                   `json:"id"`
+	LoadBalancerID       string                   `json:"loadbalancer_id"`
+	ProvisioningStatus   types.ProvisioningStatus `json:"provisioning_status"`
+	OperationStatus      types.OperatingStatus    `json:"operating_status"`
+	CreatorTaskID        *string                  `json:"creator_task_id"`
+	TaskID               *string                  `json:"task_id"`
+	InsertXForwarded     bool                     `json:"insert_x_forwarded"`
+	SecretID             *string                  `json:"secret_id"`
+	SNISecretID          []string                 `json:"sni_secret_id,omitempty"`
+	AllowedCIDRS         []string                 `json:"allowed_cidrs,omitempty"`
+	TimeoutClientData    *int                     `json:"timeout_client_data,omitempty"`
+	TimeoutMemberData    *int                     `json:"timeout_member_data,omitempty"`
+	TimeoutMemberConnect *int                     `json:"timeout_member_connect,omitempty"`
+	ConnectionLimit      *int                     `json:"connection_limit"`
+	UserList             []UserList               `json:"user_list"`
+	Stats                *ListenerStats           `json:"stats"`
+}
+
+func (l Listener) IsDeleted() bool {
+	return l.ProvisioningStatus == types.ProvisioningStatusDeleted
+}
+
+// ListenerPage is the page returned by a pager when traversing over a
+// collection of listeners.
+type ListenerPage struct {
+	pagination.LinkedPageBase
+}
+
+// NextPageURL is invoked when a paginated collection of listeners has reached
+// the end of a page and the pager seeks to traverse over a new one. In order
+// to do this, it needs to construct the next page's URL.
+func (r ListenerPage) NextPageURL() (string, error) {
+	var s struct {
+		Links []gcorecloud.Link `json:"links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return gcorecloud.ExtractNextURL(s.Links)
+}
+
+// IsEmpty checks whether a ListenerPage struct is empty.
+func (r ListenerPage) IsEmpty() (bool, error) {
+	is, err := ExtractListeners(r)
+	return len(is) == 0, err
+}
+
+// ExtractListeners accepts a Page struct, specifically a ListenerPage struct,
+// and extracts the elements into a slice of Listener structs. In other words,
+// a generic collection is mapped into a relevant slice.
+func ExtractListeners(r pagination.Page) ([]Listener, error) {
+	var s []Listener
+	err := ExtractListenersInto(r, &s)
+	return s, err
+}
+
+func ExtractListenersInto(r pagination.Page, v interface{}) error {
+	return r.(ListenerPage).Result.ExtractIntoSlicePtr(v, "results")
+}
+
+type ListenerTaskResult struct {
+	Listeners []string `json:"listeners"`
+}
+
+func ExtractListenerIDFromTask(task *tasks.Task) (string, error) {
+	var result ListenerTaskResult
+	err := gcorecloud.NativeMapToStruct(task.CreatedResources, &result)
+	if err != nil {
+		return "", fmt.Errorf("cannot decode listener information in task structure: %w", err)
+	}
+	if len(result.Listeners) == 0 {
+		return "", fmt.Errorf("cannot decode listener information in task structure: %w", err)
+	}
+	return result.Listeners[0], nil
+}
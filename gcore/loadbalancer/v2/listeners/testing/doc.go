@@ -0,0 +1,2 @@
+// listeners unit tests
+package testing
@@ -0,0 +1,168 @@
+package testing
+
+import (
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/types"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v2/listeners"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+)
+
+const ListResponse = `
+{
+  "count": 1,
+  "results": [
+    {
+      "creator_task_id": "9f3ec11e-bcd4-4fe6-924a-a4439a56ad22",
+      "name": "lbaas_test_listener",
+      "task_id": "9f3ec11e-bcd4-4fe6-924a-a4439a56ad22",
+      "pool_count": 1,
+      "operating_status": "ONLINE",
+      "protocol_port": 80,
+      "id": "43658ea9-54bd-4807-90b1-925921c9a0d1",
+      "loadbalancer_id": "e8ab1be4-1521-4266-be69-28dad4148a30",
+      "protocol": "TCP",
+      "provisioning_status": "ACTIVE",
+      "insert_x_forwarded": false,
+      "allowed_cidrs": ["10.10.0.0/24"],
+      "timeout_client_data": 50000,
+      "timeout_member_data": 50000,
+      "timeout_member_connect": 5000,
+      "connection_limit": 100000,
+      "user_list": [
+        {
+          "username": "admin",
+          "encrypted_password": "$5$isRr.HJ1IrQP38.m$oViu3DJOpUG2ZsjCBtbITV3mqpxxbZfyWJojLPNSPO5"
+        }
+      ],
+      "stats": {
+        "active_connections": 2,
+        "bytes_in": 1024,
+        "bytes_out": 4096,
+        "request_errors": 0,
+        "total_connections": 12
+      }
+    }
+  ]
+}
+`
+
+const GetResponse = `
+{
+  "creator_task_id": "9f3ec11e-bcd4-4fe6-924a-a4439a56ad22",
+  "name": "lbaas_test_listener",
+  "task_id": "9f3ec11e-bcd4-4fe6-924a-a4439a56ad22",
+  "pool_count": 1,
+  "operating_status": "ONLINE",
+  "protocol_port": 80,
+  "id": "43658ea9-54bd-4807-90b1-925921c9a0d1",
+  "loadbalancer_id": "e8ab1be4-1521-4266-be69-28dad4148a30",
+  "protocol": "TCP",
+  "provisioning_status": "ACTIVE",
+  "insert_x_forwarded": false,
+  "allowed_cidrs": ["10.10.0.0/24"],
+  "timeout_client_data": 50000,
+  "timeout_member_data": 50000,
+  "timeout_member_connect": 5000,
+  "connection_limit": 100000,
+  "user_list": [
+    {
+      "username": "admin",
+      "encrypted_password": "$5$isRr.HJ1IrQP38.m$oViu3DJOpUG2ZsjCBtbITV3mqpxxbZfyWJojLPNSPO5"
+    }
+  ],
+  "stats": {
+    "active_connections": 2,
+    "bytes_in": 1024,
+    "bytes_out": 4096,
+    "request_errors": 0,
+    "total_connections": 12
+  }
+}
+`
+
+const CreateRequest = `
+{
+  "name": "lbaas_test_listener",
+  "protocol_port": 80,
+  "protocol": "TCP",
+  "loadbalancer_id": "e8ab1be4-1521-4266-be69-28dad4148a30",
+  "insert_x_forwarded": false,
+  "allowed_cidrs": ["10.10.0.0/24"],
+  "connection_limit": 100000,
+  "user_list": [
+    {
+      "username": "admin",
+      "encrypted_password": "$5$isRr.HJ1IrQP38.m$oViu3DJOpUG2ZsjCBtbITV3mqpxxbZfyWJojLPNSPO5"
+    }
+  ]
+}
+`
+
+const UpdateRequest = `
+{
+  "name": "lbaas_test_listener",
+  "secret_id": "f2e734d0-fa2b-42c2-ad33-4c6db5101e00",
+  "sni_secret_id": ["f2e734d0-fa2b-42c2-ad33-4c6db5101e00"]
+}
+`
+
+const UnsetRequest = `
+{
+  "allowed_cidrs": null,
+  "user_list": [],
+  "sni_secret_id": []
+}
+`
+
+const TasksResponse = `
+{
+  "tasks": [
+    "50f53a35-42ed-40c4-82b2-5a37fb3e00bc"
+  ]
+}
+`
+
+var (
+	creatorTaskID        = "9f3ec11e-bcd4-4fe6-924a-a4439a56ad22"
+	taskID               = "9f3ec11e-bcd4-4fe6-924a-a4439a56ad22"
+	secretID             = "f2e734d0-fa2b-42c2-ad33-4c6db5101e00"
+	LoadBalancerID       = "e8ab1be4-1521-4266-be69-28dad4148a30"
+	timeoutClientData    = 50000
+	timeoutMemberData    = 50000
+	timeoutMemberConnect = 5000
+	connectionLimit      = 100000
+	userList             = listeners.UserList{
+		Username:          "admin",
+		EncryptedPassword: "$5$isRr.HJ1IrQP38.m$oViu3DJOpUG2ZsjCBtbITV3mqpxxbZfyWJojLPNSPO5",
+	}
+
+	Listener1 = listeners.Listener{
+		PoolCount:            1,
+		ProtocolPort:         80,
+		Protocol:             types.ProtocolTypeTCP,
+		Name:                 "lbaas_test_listener",
+		ID:                   "43658ea9-54bd-4807-90b1-925921c9a0d1",
+		LoadBalancerID:       LoadBalancerID,
+		ProvisioningStatus:   types.ProvisioningStatusActive,
+		OperationStatus:      types.OperatingStatusOnline,
+		CreatorTaskID:        &creatorTaskID,
+		TaskID:               &taskID,
+		AllowedCIDRS:         []string{"10.10.0.0/24"},
+		TimeoutClientData:    &timeoutClientData,
+		TimeoutMemberData:    &timeoutMemberData,
+		TimeoutMemberConnect: &timeoutMemberConnect,
+		ConnectionLimit:      &connectionLimit,
+		UserList:             []listeners.UserList{userList},
+		Stats: &listeners.ListenerStats{
+			ActiveConnections: 2,
+			BytesIn:           1024,
+			BytesOut:          4096,
+			RequestErrors:     0,
+			TotalConnections:  12,
+		},
+	}
+	Tasks1 = tasks.TaskResults{
+		Tasks: []tasks.TaskID{"50f53a35-42ed-40c4-82b2-5a37fb3e00bc"},
+	}
+
+	ExpectedListenersSlice = []listeners.Listener{Listener1}
+)
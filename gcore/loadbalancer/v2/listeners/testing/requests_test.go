@@ -0,0 +1,244 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/types"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v2/listeners"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+
+	"github.com/stretchr/testify/require"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Core/gcorelabscloud-go/pagination"
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+)
+
+func prepareListTestURLParams(projectID int, regionID int) string {
+	return fmt.Sprintf("/v2/lblisteners/%d/%d", projectID, regionID)
+}
+
+func prepareGetTestURLParams(projectID int, regionID int, id string) string {
+	return fmt.Sprintf("/v2/lblisteners/%d/%d/%s", projectID, regionID, id)
+}
+
+func prepareListTestURL() string {
+	return prepareListTestURLParams(fake.ProjectID, fake.RegionID)
+}
+
+func prepareGetTestURL(id string) string {
+	return prepareGetTestURLParams(fake.ProjectID, fake.RegionID, id)
+}
+
+func TestList(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareListTestURL(), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		require.Equal(t, "true", r.URL.Query().Get("show_stats"))
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := fmt.Fprint(w, ListResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	client := fake.ServiceTokenClient("lblisteners", "v2")
+	count := 0
+
+	opts := listeners.ListOpts{LoadBalancerID: &LoadBalancerID, ShowStats: true}
+
+	err := listeners.List(client, opts).EachPage(func(page pagination.Page) (bool, error) {
+		count++
+		actual, err := listeners.ExtractListeners(page)
+		require.NoError(t, err)
+		ct := actual[0]
+		require.Equal(t, Listener1, ct)
+		require.Equal(t, ExpectedListenersSlice, actual)
+		return true, nil
+	})
+
+	th.AssertNoErr(t, err)
+
+	if count != 1 {
+		t.Errorf("Expected 1 page, got %d", count)
+	}
+}
+
+func TestListAll(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareListTestURL(), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := fmt.Fprint(w, ListResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	client := fake.ServiceTokenClient("lblisteners", "v2")
+
+	results, err := listeners.ListAll(client, nil)
+	require.NoError(t, err)
+	require.Equal(t, ExpectedListenersSlice, results)
+}
+
+func TestGet(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareGetTestURL(Listener1.ID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		require.Equal(t, "true", r.URL.Query().Get("show_stats"))
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		_, err := fmt.Fprint(w, GetResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	client := fake.ServiceTokenClient("lblisteners", "v2")
+
+	ct, err := listeners.Get(client, Listener1.ID, listeners.GetOpts{ShowStats: true}).Extract()
+
+	require.NoError(t, err)
+	require.Equal(t, Listener1, *ct)
+}
+
+func TestCreate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareListTestURL(), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, CreateRequest)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		_, err := fmt.Fprint(w, TasksResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	options := listeners.CreateOpts{
+		Name:            Listener1.Name,
+		Protocol:        types.ProtocolTypeTCP,
+		ProtocolPort:    80,
+		LoadBalancerID:  LoadBalancerID,
+		AllowedCIDRS:    []string{"10.10.0.0/24"},
+		ConnectionLimit: &connectionLimit,
+		UserList: []listeners.CreateUserListOpts{{
+			Username:          userList.Username,
+			EncryptedPassword: userList.EncryptedPassword,
+		}},
+	}
+
+	client := fake.ServiceTokenClient("lblisteners", "v2")
+	tasks, err := listeners.Create(client, options, nil).Extract()
+	require.NoError(t, err)
+	require.Equal(t, Tasks1, *tasks)
+}
+
+func TestDelete(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareGetTestURL(Listener1.ID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		w.WriteHeader(http.StatusOK)
+		_, err := fmt.Fprint(w, TasksResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	client := fake.ServiceTokenClient("lblisteners", "v2")
+	tasks, err := listeners.Delete(client, Listener1.ID, nil).Extract()
+	require.NoError(t, err)
+	require.Equal(t, Tasks1, *tasks)
+}
+
+func TestUpdate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareGetTestURL(Listener1.ID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PATCH")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, UpdateRequest)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		_, err := fmt.Fprint(w, TasksResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	client := fake.ServiceTokenClient("lblisteners", "v2")
+
+	opts := listeners.UpdateOpts{
+		Name:        Listener1.Name,
+		SecretID:    secretID,
+		SNISecretID: []string{secretID},
+	}
+
+	tasks, err := listeners.Update(client, Listener1.ID, opts, nil).Extract()
+	require.NoError(t, err)
+	require.Equal(t, Tasks1, *tasks)
+}
+
+func TestUnset(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareGetTestURL(Listener1.ID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PATCH")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, UnsetRequest)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		_, err := fmt.Fprint(w, TasksResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	client := fake.ServiceTokenClient("lblisteners", "v2")
+
+	opts := listeners.UnsetOpts{
+		AllowedCIDRS: true,
+		UserList:     true,
+		SNISecretID:  true,
+	}
+
+	tasks, err := listeners.Unset(client, Listener1.ID, opts, nil).Extract()
+	require.NoError(t, err)
+	require.Equal(t, Tasks1, *tasks)
+}
@@ -0,0 +1,31 @@
+package listeners
+
+import gcorecloud "github.com/G-Core/gcorelabscloud-go"
+
+func resourceURL(c *gcorecloud.ServiceClient, id string) string {
+	return c.ServiceURL(id)
+}
+
+func rootURL(c *gcorecloud.ServiceClient) string {
+	return c.ServiceURL()
+}
+
+func getURL(c *gcorecloud.ServiceClient, id string) string {
+	return resourceURL(c, id)
+}
+
+func listURL(c *gcorecloud.ServiceClient) string {
+	return rootURL(c)
+}
+
+func createURL(c *gcorecloud.ServiceClient) string {
+	return rootURL(c)
+}
+
+func updateURL(c *gcorecloud.ServiceClient, id string) string {
+	return resourceURL(c, id)
+}
+
+func deleteURL(c *gcorecloud.ServiceClient, id string) string {
+	return resourceURL(c, id)
+}
@@ -0,0 +1,45 @@
+/*
+Package loadbalancers contains functionality for working GCLoud loadbalancers v2 API resources
+
+Example to Create a LoadBalancer with listeners, pools and members in a single call
+
+	createOpts := loadbalancers.CreateOpts{
+		Name: "lb",
+		Listeners: []loadbalancers.CreateListenerOpts{{
+			Name:         "http",
+			Protocol:     types.ProtocolTypeHTTP,
+			ProtocolPort: 80,
+			Pools: []loadbalancers.CreatePoolOpts{{
+				Name:                  "backend",
+				Protocol:              types.ProtocolTypeHTTP,
+				LoadBalancerAlgorithm: types.LoadBalancerAlgorithmRoundRobin,
+				Members: []lbpools.CreatePoolMemberOpts{{
+					Address:      net.ParseIP("10.0.0.10"),
+					ProtocolPort: 8080,
+				}},
+			}},
+		}},
+	}
+
+	results, err := loadbalancers.Create(loadbalancerClient, createOpts, nil).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Get a LoadBalancer with statistics
+
+	loadbalancerID := "484cda0e-106f-4f4b-bb3f-d413710bbe78"
+	lb, err := loadbalancers.Get(loadbalancerClient, loadbalancerID, loadbalancers.GetOpts{ShowStats: true}).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Delete a LoadBalancer
+
+	loadbalancerID := "484cda0e-106f-4f4b-bb3f-d413710bbe78"
+	results, err := loadbalancers.Delete(loadbalancerClient, loadbalancerID, nil).Extract()
+	if err != nil {
+		panic(err)
+	}
+*/
+package loadbalancers
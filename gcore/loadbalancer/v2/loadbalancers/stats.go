@@ -0,0 +1,488 @@
+package loadbalancers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/types"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v2/lbpools"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v2/listeners"
+)
+
+const (
+	defaultStatsInterval   = 30 * time.Second
+	defaultStatsBufferSize = 100
+	statsMetricsNamespace  = "gcore"
+	statsMetricsSubsystem  = "loadbalancer"
+)
+
+// StatsEventType describes the kind of change reported by a StatsWatcher.
+type StatsEventType string
+
+const (
+	StatsEventLoadBalancer  StatsEventType = "loadbalancer"
+	StatsEventListener      StatsEventType = "listener"
+	StatsEventMemberStatus  StatsEventType = "member_status"
+	StatsEventMemberRemoved StatsEventType = "member_removed"
+)
+
+// ErrStatsWatcherStarted is returned by Run when the watcher has already been run.
+var ErrStatsWatcherStarted = errors.New("stats watcher has already been started")
+
+// StatsEvent represents a change observed between two consecutive polls.
+// Traffic events carry rates in bytes per second computed from the cumulative
+// counters returned by the API; member status events carry the status transition.
+// Member removed events carry the last known status of a member which is no longer
+// returned by the API.
+type StatsEvent struct {
+	Type                    StatsEventType
+	Time                    time.Time
+	LoadBalancerID          string
+	ListenerID              string
+	PoolID                  string
+	MemberID                string
+	BytesInRate             float64
+	BytesOutRate            float64
+	ActiveConnections       int
+	ActiveConnectionsDelta  int
+	RequestErrorsDelta      int64
+	OperatingStatus         types.OperatingStatus
+	PreviousOperatingStatus types.OperatingStatus
+}
+
+// IsMemberFailure reports whether the event is a pool member going OFFLINE or ERROR.
+func (e StatsEvent) IsMemberFailure() bool {
+	return e.Type == StatsEventMemberStatus &&
+		(e.OperatingStatus == types.OperatingStatusOffline || e.OperatingStatus == types.OperatingStatusOperatingError)
+}
+
+// StatsWatcherOpts represents options used to configure a StatsWatcher.
+type StatsWatcherOpts struct {
+	// LoadBalancerIDs limits the watcher to the given loadbalancers. All loadbalancers are watched when empty.
+	LoadBalancerIDs []string
+	// Interval between polls, 30 seconds by default.
+	Interval time.Duration
+	// BufferSize of the events channel, 100 by default.
+	BufferSize int
+}
+
+type trafficSnapshot struct {
+	name              string
+	loadBalancerID    string
+	activeConnections int
+	bytesIn           int64
+	bytesOut          int64
+	requestErrors     int64
+	totalConnections  int64
+	operatingStatus   types.OperatingStatus
+	bytesInRate       float64
+	bytesOutRate      float64
+}
+
+type memberSnapshot struct {
+	loadBalancerID  string
+	poolID          string
+	address         string
+	protocolPort    int
+	operatingStatus types.OperatingStatus
+}
+
+type statsSnapshot struct {
+	time          time.Time
+	loadBalancers map[string]trafficSnapshot
+	listeners     map[string]trafficSnapshot
+	members       map[string]memberSnapshot
+}
+
+// StatsWatcher polls loadbalancers, listeners and pool members status and statistics
+// at an interval, emits deltas on the Events channel and exposes the latest values
+// as a prometheus.Collector.
+type StatsWatcher struct {
+	lbClient       *gcorecloud.ServiceClient
+	listenerClient *gcorecloud.ServiceClient
+	poolClient     *gcorecloud.ServiceClient
+	opts           StatsWatcherOpts
+	events         chan StatsEvent
+	started        sync.Once
+
+	mu   sync.RWMutex
+	last *statsSnapshot
+}
+
+// NewStatsWatcher creates a StatsWatcher using loadbalancers, lblisteners and lbpools v2 service clients.
+func NewStatsWatcher(lbClient, listenerClient, poolClient *gcorecloud.ServiceClient, opts StatsWatcherOpts) *StatsWatcher {
+	if opts.Interval <= 0 {
+		opts.Interval = defaultStatsInterval
+	}
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = defaultStatsBufferSize
+	}
+	return &StatsWatcher{
+		lbClient:       lbClient,
+		listenerClient: listenerClient,
+		poolClient:     poolClient,
+		opts:           opts,
+		events:         make(chan StatsEvent, opts.BufferSize),
+	}
+}
+
+// Events returns the channel deltas are sent to while Run is active.
+// The channel is closed when Run returns.
+func (w *StatsWatcher) Events() <-chan StatsEvent {
+	return w.events
+}
+
+// Run polls until the context is cancelled. Polling errors are returned through
+// the errFunc callback when it is not nil and do not stop the watcher.
+// A watcher can only be run once, later calls return ErrStatsWatcherStarted.
+func (w *StatsWatcher) Run(ctx context.Context, errFunc func(error)) error {
+	first := false
+	w.started.Do(func() { first = true })
+	if !first {
+		return ErrStatsWatcherStarted
+	}
+	defer close(w.events)
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+	for {
+		events, err := w.Poll()
+		if err != nil && errFunc != nil {
+			errFunc(err)
+		}
+		for _, event := range events {
+			select {
+			case w.events <- event:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Poll fetches the current status and statistics once, stores them as the latest
+// snapshot and returns the changes since the previous poll.
+// The first poll only establishes a baseline and returns no events.
+func (w *StatsWatcher) Poll() ([]StatsEvent, error) {
+	current, err := w.fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	previous := w.last
+	var events []StatsEvent
+	if previous != nil {
+		events = diffStatsSnapshots(previous, current)
+	}
+	w.last = current
+	return events, nil
+}
+
+func (w *StatsWatcher) fetch() (*statsSnapshot, error) {
+	snapshot := &statsSnapshot{
+		time:          time.Now(),
+		loadBalancers: make(map[string]trafficSnapshot),
+		listeners:     make(map[string]trafficSnapshot),
+		members:       make(map[string]memberSnapshot),
+	}
+
+	lbs, err := w.loadBalancers()
+	if err != nil {
+		return nil, err
+	}
+
+	details := true
+	for _, lb := range lbs {
+		item := trafficSnapshot{name: lb.Name, loadBalancerID: lb.ID, operatingStatus: lb.OperationStatus}
+		if lb.Stats != nil {
+			item.activeConnections = lb.Stats.ActiveConnections
+			item.bytesIn = lb.Stats.BytesIn
+			item.bytesOut = lb.Stats.BytesOut
+			item.requestErrors = lb.Stats.RequestErrors
+			item.totalConnections = lb.Stats.TotalConnections
+		}
+		snapshot.loadBalancers[lb.ID] = item
+
+		lbID := lb.ID
+		lbListeners, err := listeners.ListAll(w.listenerClient, listeners.ListOpts{LoadBalancerID: &lbID, ShowStats: true})
+		if err != nil {
+			return nil, fmt.Errorf("cannot list listeners of loadbalancer %s: %w", lb.ID, err)
+		}
+		for _, l := range lbListeners {
+			item := trafficSnapshot{name: l.Name, loadBalancerID: lb.ID, operatingStatus: l.OperationStatus}
+			if l.Stats != nil {
+				item.activeConnections = l.Stats.ActiveConnections
+				item.bytesIn = l.Stats.BytesIn
+				item.bytesOut = l.Stats.BytesOut
+				item.requestErrors = l.Stats.RequestErrors
+				item.totalConnections = l.Stats.TotalConnections
+			}
+			snapshot.listeners[l.ID] = item
+		}
+
+		pools, err := lbpools.ListAll(w.poolClient, lbpools.ListOpts{LoadBalancerID: &lbID, MemberDetails: &details})
+		if err != nil {
+			return nil, fmt.Errorf("cannot list pools of loadbalancer %s: %w", lb.ID, err)
+		}
+		for _, p := range pools {
+			for _, m := range p.Members {
+				item := memberSnapshot{
+					loadBalancerID:  lb.ID,
+					poolID:          p.ID,
+					protocolPort:    m.ProtocolPort,
+					operatingStatus: m.OperatingStatus,
+				}
+				if m.Address != nil {
+					item.address = m.Address.String()
+				}
+				snapshot.members[m.ID] = item
+			}
+		}
+	}
+	return snapshot, nil
+}
+
+func (w *StatsWatcher) loadBalancers() ([]LoadBalancer, error) {
+	if len(w.opts.LoadBalancerIDs) == 0 {
+		lbs, err := ListAll(w.lbClient, ListOpts{ShowStats: true})
+		if err != nil {
+			return nil, fmt.Errorf("cannot list loadbalancers: %w", err)
+		}
+		return lbs, nil
+	}
+	lbs := make([]LoadBalancer, 0, len(w.opts.LoadBalancerIDs))
+	for _, id := range w.opts.LoadBalancerIDs {
+		lb, err := Get(w.lbClient, id, GetOpts{ShowStats: true}).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("cannot get loadbalancer %s: %w", id, err)
+		}
+		lbs = append(lbs, *lb)
+	}
+	return lbs, nil
+}
+
+func diffStatsSnapshots(previous, current *statsSnapshot) []StatsEvent {
+	var events []StatsEvent
+	seconds := current.time.Sub(previous.time).Seconds()
+
+	rate := func(prev, cur int64) float64 {
+		// counters are reset when amphorae are recreated
+		if seconds <= 0 || cur < prev {
+			return 0
+		}
+		return float64(cur-prev) / seconds
+	}
+
+	for id, cur := range current.loadBalancers {
+		prev, ok := previous.loadBalancers[id]
+		if !ok {
+			continue
+		}
+		cur.bytesInRate = rate(prev.bytesIn, cur.bytesIn)
+		cur.bytesOutRate = rate(prev.bytesOut, cur.bytesOut)
+		current.loadBalancers[id] = cur
+		events = append(events, StatsEvent{
+			Type:                    StatsEventLoadBalancer,
+			Time:                    current.time,
+			LoadBalancerID:          id,
+			BytesInRate:             cur.bytesInRate,
+			BytesOutRate:            cur.bytesOutRate,
+			ActiveConnections:       cur.activeConnections,
+			ActiveConnectionsDelta:  cur.activeConnections - prev.activeConnections,
+			RequestErrorsDelta:      cur.requestErrors - prev.requestErrors,
+			OperatingStatus:         cur.operatingStatus,
+			PreviousOperatingStatus: prev.operatingStatus,
+		})
+	}
+
+	for id, cur := range current.listeners {
+		prev, ok := previous.listeners[id]
+		if !ok {
+			continue
+		}
+		cur.bytesInRate = rate(prev.bytesIn, cur.bytesIn)
+		cur.bytesOutRate = rate(prev.bytesOut, cur.bytesOut)
+		current.listeners[id] = cur
+		events = append(events, StatsEvent{
+			Type:                    StatsEventListener,
+			Time:                    current.time,
+			LoadBalancerID:          cur.loadBalancerID,
+			ListenerID:              id,
+			BytesInRate:             cur.bytesInRate,
+			BytesOutRate:            cur.bytesOutRate,
+			ActiveConnections:       cur.activeConnections,
+			ActiveConnectionsDelta:  cur.activeConnections - prev.activeConnections,
+			RequestErrorsDelta:      cur.requestErrors - prev.requestErrors,
+			OperatingStatus:         cur.operatingStatus,
+			PreviousOperatingStatus: prev.operatingStatus,
+		})
+	}
+
+	for id, cur := range current.members {
+		prev, ok := previous.members[id]
+		if ok && prev.operatingStatus == cur.operatingStatus {
+			continue
+		}
+		event := StatsEvent{
+			Type:            StatsEventMemberStatus,
+			Time:            current.time,
+			LoadBalancerID:  cur.loadBalancerID,
+			PoolID:          cur.poolID,
+			MemberID:        id,
+			OperatingStatus: cur.operatingStatus,
+		}
+		if ok {
+			event.PreviousOperatingStatus = prev.operatingStatus
+		}
+		events = append(events, event)
+	}
+
+	for id, prev := range previous.members {
+		if _, ok := current.members[id]; ok {
+			continue
+		}
+		events = append(events, StatsEvent{
+			Type:                    StatsEventMemberRemoved,
+			Time:                    current.time,
+			LoadBalancerID:          prev.loadBalancerID,
+			PoolID:                  prev.poolID,
+			MemberID:                id,
+			PreviousOperatingStatus: prev.operatingStatus,
+		})
+	}
+
+	return events
+}
+
+var (
+	lbLabels       = []string{"loadbalancer_id", "name"}
+	listenerLabels = []string{"loadbalancer_id", "listener_id", "name"}
+	memberLabels   = []string{"loadbalancer_id", "pool_id", "member_id", "address", "protocol_port"}
+
+	lbActiveConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(statsMetricsNamespace, statsMetricsSubsystem, "active_connections"),
+		"Number of active connections of the loadbalancer.", lbLabels, nil)
+	lbBytesInDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(statsMetricsNamespace, statsMetricsSubsystem, "bytes_in_total"),
+		"Total bytes received by the loadbalancer.", lbLabels, nil)
+	lbBytesOutDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(statsMetricsNamespace, statsMetricsSubsystem, "bytes_out_total"),
+		"Total bytes sent by the loadbalancer.", lbLabels, nil)
+	lbBytesInRateDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(statsMetricsNamespace, statsMetricsSubsystem, "bytes_in_rate"),
+		"Bytes per second received by the loadbalancer between the last two polls.", lbLabels, nil)
+	lbBytesOutRateDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(statsMetricsNamespace, statsMetricsSubsystem, "bytes_out_rate"),
+		"Bytes per second sent by the loadbalancer between the last two polls.", lbLabels, nil)
+	lbRequestErrorsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(statsMetricsNamespace, statsMetricsSubsystem, "request_errors_total"),
+		"Total request errors of the loadbalancer.", lbLabels, nil)
+	lbConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(statsMetricsNamespace, statsMetricsSubsystem, "connections_total"),
+		"Total connections handled by the loadbalancer.", lbLabels, nil)
+	lbOnlineDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(statsMetricsNamespace, statsMetricsSubsystem, "online"),
+		"Whether the loadbalancer operating status is ONLINE.", lbLabels, nil)
+
+	listenerActiveConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(statsMetricsNamespace, statsMetricsSubsystem, "listener_active_connections"),
+		"Number of active connections of the listener.", listenerLabels, nil)
+	listenerBytesInDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(statsMetricsNamespace, statsMetricsSubsystem, "listener_bytes_in_total"),
+		"Total bytes received by the listener.", listenerLabels, nil)
+	listenerBytesOutDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(statsMetricsNamespace, statsMetricsSubsystem, "listener_bytes_out_total"),
+		"Total bytes sent by the listener.", listenerLabels, nil)
+	listenerBytesInRateDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(statsMetricsNamespace, statsMetricsSubsystem, "listener_bytes_in_rate"),
+		"Bytes per second received by the listener between the last two polls.", listenerLabels, nil)
+	listenerBytesOutRateDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(statsMetricsNamespace, statsMetricsSubsystem, "listener_bytes_out_rate"),
+		"Bytes per second sent by the listener between the last two polls.", listenerLabels, nil)
+
+	memberUpDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(statsMetricsNamespace, statsMetricsSubsystem, "pool_member_up"),
+		"Whether the pool member operating status is ONLINE.", memberLabels, nil)
+	memberStatusDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(statsMetricsNamespace, statsMetricsSubsystem, "pool_member_operating_status"),
+		"Operating status of the pool member: 0 ONLINE, 1 DRAINING, 2 ERROR, 3 OFFLINE, 4 DEGRADED, 5 NO_MONITOR, -1 unknown.",
+		memberLabels, nil)
+)
+
+// operatingStatusValue maps an operating status to the memberStatusDesc gauge value.
+func operatingStatusValue(status types.OperatingStatus) float64 {
+	for i, s := range status.List() {
+		if s == status {
+			return float64(i)
+		}
+	}
+	return -1
+}
+
+// Describe implements prometheus.Collector.
+func (w *StatsWatcher) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{
+		lbActiveConnectionsDesc, lbBytesInDesc, lbBytesOutDesc, lbBytesInRateDesc, lbBytesOutRateDesc,
+		lbRequestErrorsDesc, lbConnectionsDesc, lbOnlineDesc,
+		listenerActiveConnectionsDesc, listenerBytesInDesc, listenerBytesOutDesc,
+		listenerBytesInRateDesc, listenerBytesOutRateDesc,
+		memberUpDesc, memberStatusDesc,
+	} {
+		ch <- desc
+	}
+}
+
+// Collect implements prometheus.Collector. It exposes the values of the latest poll.
+func (w *StatsWatcher) Collect(ch chan<- prometheus.Metric) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.last == nil {
+		return
+	}
+
+	boolValue := func(b bool) float64 {
+		if b {
+			return 1
+		}
+		return 0
+	}
+
+	for id, lb := range w.last.loadBalancers {
+		labels := []string{id, lb.name}
+		ch <- prometheus.MustNewConstMetric(lbActiveConnectionsDesc, prometheus.GaugeValue, float64(lb.activeConnections), labels...)
+		ch <- prometheus.MustNewConstMetric(lbBytesInDesc, prometheus.CounterValue, float64(lb.bytesIn), labels...)
+		ch <- prometheus.MustNewConstMetric(lbBytesOutDesc, prometheus.CounterValue, float64(lb.bytesOut), labels...)
+		ch <- prometheus.MustNewConstMetric(lbBytesInRateDesc, prometheus.GaugeValue, lb.bytesInRate, labels...)
+		ch <- prometheus.MustNewConstMetric(lbBytesOutRateDesc, prometheus.GaugeValue, lb.bytesOutRate, labels...)
+		ch <- prometheus.MustNewConstMetric(lbRequestErrorsDesc, prometheus.CounterValue, float64(lb.requestErrors), labels...)
+		ch <- prometheus.MustNewConstMetric(lbConnectionsDesc, prometheus.CounterValue, float64(lb.totalConnections), labels...)
+		ch <- prometheus.MustNewConstMetric(lbOnlineDesc, prometheus.GaugeValue, boolValue(lb.operatingStatus == types.OperatingStatusOnline), labels...)
+	}
+
+	for id, l := range w.last.listeners {
+		labels := []string{l.loadBalancerID, id, l.name}
+		ch <- prometheus.MustNewConstMetric(listenerActiveConnectionsDesc, prometheus.GaugeValue, float64(l.activeConnections), labels...)
+		ch <- prometheus.MustNewConstMetric(listenerBytesInDesc, prometheus.CounterValue, float64(l.bytesIn), labels...)
+		ch <- prometheus.MustNewConstMetric(listenerBytesOutDesc, prometheus.CounterValue, float64(l.bytesOut), labels...)
+		ch <- prometheus.MustNewConstMetric(listenerBytesInRateDesc, prometheus.GaugeValue, l.bytesInRate, labels...)
+		ch <- prometheus.MustNewConstMetric(listenerBytesOutRateDesc, prometheus.GaugeValue, l.bytesOutRate, labels...)
+	}
+
+	for id, m := range w.last.members {
+		labels := []string{m.loadBalancerID, m.poolID, id, m.address, fmt.Sprint(m.protocolPort)}
+		ch <- prometheus.MustNewConstMetric(memberUpDesc, prometheus.GaugeValue, boolValue(m.operatingStatus == types.OperatingStatusOnline), labels...)
+		ch <- prometheus.MustNewConstMetric(memberStatusDesc, prometheus.GaugeValue, operatingStatusValue(m.operatingStatus), labels...)
+	}
+}
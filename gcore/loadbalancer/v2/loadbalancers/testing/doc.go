@@ -0,0 +1,2 @@
+// loadbalancers unit tests
+package testing
@@ -0,0 +1,175 @@
+package testing
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	loadbalancers1 "github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/loadbalancers"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/types"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v2/loadbalancers"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+	"github.com/G-Core/gcorelabscloud-go/gcore/utils/metadata"
+	"github.com/G-Core/gcorelabscloud-go/gcore/utils/metadata/v1/metadata/testing"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+)
+
+var GetResponse = fmt.Sprintf(`
+{
+  "region": "RegionOne",
+  "created_at": "2020-01-24T13:57:12+0000",
+  "name": "lbname",
+  "id": "e8ab1be4-1521-4266-be69-28dad4148a30",
+  "provisioning_status": "ACTIVE",
+  "updated_at": "2020-01-24T13:57:35+0000",
+  "listeners": [
+    {
+      "id": "43658ea9-54bd-4807-90b1-925921c9a0d1"
+    }
+  ],
+  "task_id": null,
+  "creator_task_id": "9f3ec11e-bcd4-4fe6-924a-a4439a56ad22",
+  "vip_address": "5.5.5.5",
+  "operating_status": "ONLINE",
+  "project_id": 1,
+  "region_id": 1,
+  "metadata": [%s],
+  "vrrp_ips": [
+    {
+      "ip_address": "10.94.79.54",
+      "subnet_id": "db5ebada-a86a-4702-8a19-00b23a1acb05"
+    }
+  ],
+  "vip_ip_family": "ipv4",
+  "stats": {
+    "active_connections": 5,
+    "bytes_in": 2048,
+    "bytes_out": 8192,
+    "request_errors": 1,
+    "total_connections": 42
+  }
+}
+`, testing.MetadataResponse)
+
+var ListResponse = fmt.Sprintf(`
+{
+  "count": 1,
+  "results": [%s]
+}
+`, GetResponse)
+
+const CreateRequest = `
+{
+  "name": "lbname",
+  "vip_network_id": "b0ef5a5c-a6c4-4c1a-9e6a-62f4f5bcb2d8",
+  "flavor": "lb1-1-2",
+  "tag": ["web"],
+  "metadata": {"env": "prod"},
+  "listeners": [
+    {
+      "name": "listener_name",
+      "insert_x_forwarded": true,
+      "protocol": "HTTP",
+      "protocol_port": 80,
+      "allowed_cidrs": ["10.0.0.0/8"],
+      "connection_limit": 10000,
+      "pools": [
+        {
+          "name": "pool_name",
+          "protocol": "HTTP",
+          "lb_algorithm": "LEAST_CONNECTIONS",
+          "timeout_member_connect": 5000,
+          "members": [
+            {
+              "instance_id": "a7e7e8d6-0bf7-4ac9-8170-831b47ee2ba9",
+              "address": "192.168.1.101",
+              "weight": 2,
+              "protocol_port": 8000
+            },
+            {
+              "instance_id": "169942e0-9b53-42df-95ef-1a8b6525c2bd",
+              "address": "192.168.1.102",
+              "weight": 1,
+              "protocol_port": 8000,
+              "backup": true
+            }
+          ],
+          "healthmonitor": {
+            "type": "HTTP",
+            "delay": 10,
+            "max_retries": 3,
+            "timeout": 5,
+            "http_method": "GET",
+            "url_path": "/healthz",
+            "expected_codes": "200"
+          },
+          "session_persistence": {
+            "type": "HTTP_COOKIE"
+          }
+        }
+      ]
+    }
+  ]
+}
+`
+
+const UpdateRequest = `
+{
+  "name": "lbname"
+}
+`
+
+const TasksResponse = `
+{
+  "tasks": [
+    "50f53a35-42ed-40c4-82b2-5a37fb3e00bc"
+  ]
+}
+`
+
+var (
+	createdTimeString    = "2020-01-24T13:57:12+0000"
+	updatedTimeString    = "2020-01-24T13:57:35+0000"
+	createdTimeParsed, _ = time.Parse(gcorecloud.RFC3339Z, createdTimeString)
+	createdTime          = gcorecloud.JSONRFC3339Z{Time: createdTimeParsed}
+	updatedTimeParsed, _ = time.Parse(gcorecloud.RFC3339Z, updatedTimeString)
+	updatedTime          = gcorecloud.JSONRFC3339Z{Time: updatedTimeParsed}
+	creatorTaskID        = "9f3ec11e-bcd4-4fe6-924a-a4439a56ad22"
+	ipFamily             = types.IPv4IPFamilyType
+
+	LoadBalancer1 = loadbalancers.LoadBalancer{
+		Name:               "lbname",
+		ID:                 "e8ab1be4-1521-4266-be69-28dad4148a30",
+		ProvisioningStatus: types.ProvisioningStatusActive,
+		OperationStatus:    types.OperatingStatusOnline,
+		VipAddress:         net.ParseIP("5.5.5.5"),
+		Listeners: []gcorecloud.ItemID{{
+			ID: "43658ea9-54bd-4807-90b1-925921c9a0d1",
+		}},
+		CreatorTaskID: &creatorTaskID,
+		TaskID:        nil,
+		CreatedAt:     createdTime,
+		UpdatedAt:     &updatedTime,
+		ProjectID:     fake.ProjectID,
+		RegionID:      fake.RegionID,
+		Region:        "RegionOne",
+		Metadata:      []metadata.Metadata{testing.ResourceMetadataReadOnly},
+		VrrpIPs: []loadbalancers1.NetworkPortFixedIP{
+			{IpAddress: net.ParseIP("10.94.79.54"), SubnetID: "db5ebada-a86a-4702-8a19-00b23a1acb05"},
+		},
+		VipIPFamilyType: &ipFamily,
+		Stats: &loadbalancers.LoadBalancerStats{
+			ActiveConnections: 5,
+			BytesIn:           2048,
+			BytesOut:          8192,
+			RequestErrors:     1,
+			TotalConnections:  42,
+		},
+	}
+	Tasks1 = tasks.TaskResults{
+		Tasks: []tasks.TaskID{"50f53a35-42ed-40c4-82b2-5a37fb3e00bc"},
+	}
+
+	ExpectedLoadBalancerSlice = []loadbalancers.LoadBalancer{LoadBalancer1}
+)
@@ -0,0 +1,305 @@
+package testing
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/types"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v2/lbpools"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v2/loadbalancers"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+	metadataV2Testing "github.com/G-Core/gcorelabscloud-go/gcore/utils/metadata/v2/metadata/testing"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Core/gcorelabscloud-go/pagination"
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+)
+
+func prepareListTestURLParams(projectID int, regionID int) string {
+	return fmt.Sprintf("/v2/loadbalancers/%d/%d", projectID, regionID)
+}
+
+func prepareGetTestURLParams(projectID int, regionID int, id string) string {
+	return fmt.Sprintf("/v2/loadbalancers/%d/%d/%s", projectID, regionID, id)
+}
+
+func prepareListTestURL() string {
+	return prepareListTestURLParams(fake.ProjectID, fake.RegionID)
+}
+
+func prepareGetTestURL(id string) string {
+	return prepareGetTestURLParams(fake.ProjectID, fake.RegionID, id)
+}
+
+func TestList(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareListTestURL(), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		require.Equal(t, "true", r.URL.Query().Get("show_stats"))
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := fmt.Fprint(w, ListResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	client := fake.ServiceTokenClient("loadbalancers", "v2")
+	count := 0
+
+	err := loadbalancers.List(client, loadbalancers.ListOpts{ShowStats: true}).EachPage(func(page pagination.Page) (bool, error) {
+		count++
+		actual, err := loadbalancers.ExtractLoadBalancers(page)
+		require.NoError(t, err)
+		ct := actual[0]
+		require.Equal(t, LoadBalancer1, ct)
+		require.Equal(t, ExpectedLoadBalancerSlice, actual)
+		return true, nil
+	})
+
+	th.AssertNoErr(t, err)
+
+	if count != 1 {
+		t.Errorf("Expected 1 page, got %d", count)
+	}
+}
+
+func TestListAll(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareListTestURL(), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := fmt.Fprint(w, ListResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	client := fake.ServiceTokenClient("loadbalancers", "v2")
+
+	lbs, err := loadbalancers.ListAll(client, nil)
+	require.NoError(t, err)
+	require.Equal(t, ExpectedLoadBalancerSlice, lbs)
+}
+
+func TestGet(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareGetTestURL(LoadBalancer1.ID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		require.Equal(t, "true", r.URL.Query().Get("show_stats"))
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		_, err := fmt.Fprint(w, GetResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	client := fake.ServiceTokenClient("loadbalancers", "v2")
+
+	ct, err := loadbalancers.Get(client, LoadBalancer1.ID, loadbalancers.GetOpts{ShowStats: true}).Extract()
+
+	require.NoError(t, err)
+	require.Equal(t, LoadBalancer1, *ct)
+	require.Equal(t, createdTime, ct.CreatedAt)
+	require.Equal(t, updatedTime, *ct.UpdatedAt)
+}
+
+func TestCreate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareListTestURL(), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, CreateRequest)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		_, err := fmt.Fprint(w, TasksResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	flavor := "lb1-1-2"
+	connectionLimit := 10000
+	timeoutMemberConnect := 5000
+
+	options := loadbalancers.CreateOpts{
+		Name:         LoadBalancer1.Name,
+		VipNetworkID: "b0ef5a5c-a6c4-4c1a-9e6a-62f4f5bcb2d8",
+		Flavor:       &flavor,
+		Tags:         []string{"web"},
+		Metadata:     map[string]string{"env": "prod"},
+		Listeners: []loadbalancers.CreateListenerOpts{{
+			Name:             "listener_name",
+			ProtocolPort:     80,
+			Protocol:         types.ProtocolTypeHTTP,
+			InsertXForwarded: true,
+			AllowedCIDRS:     []string{"10.0.0.0/8"},
+			ConnectionLimit:  &connectionLimit,
+			Pools: []loadbalancers.CreatePoolOpts{{
+				Name:                  "pool_name",
+				Protocol:              types.ProtocolTypeHTTP,
+				LoadBalancerAlgorithm: types.LoadBalancerAlgorithmLeastConnections,
+				TimeoutMemberConnect:  &timeoutMemberConnect,
+				Members: []lbpools.CreatePoolMemberOpts{{
+					InstanceID:   "a7e7e8d6-0bf7-4ac9-8170-831b47ee2ba9",
+					Address:      net.ParseIP("192.168.1.101"),
+					ProtocolPort: 8000,
+					Weight:       2,
+				}, {
+					InstanceID:   "169942e0-9b53-42df-95ef-1a8b6525c2bd",
+					Address:      net.ParseIP("192.168.1.102"),
+					ProtocolPort: 8000,
+					Weight:       1,
+					Backup:       true,
+				}},
+				HealthMonitor: &lbpools.CreateHealthMonitorOpts{
+					Type:          types.HealthMonitorTypeHTTP,
+					Delay:         10,
+					MaxRetries:    3,
+					Timeout:       5,
+					HTTPMethod:    types.HTTPMethodPointer(types.HTTPMethodGET),
+					URLPath:       "/healthz",
+					ExpectedCodes: "200",
+				},
+				SessionPersistence: &lbpools.CreateSessionPersistenceOpts{
+					Type: types.PersistenceTypeHTTPCookie,
+				},
+			}},
+		}},
+	}
+
+	client := fake.ServiceTokenClient("loadbalancers", "v2")
+	tasks, err := loadbalancers.Create(client, options, nil).Extract()
+	require.NoError(t, err)
+	require.Equal(t, Tasks1, *tasks)
+}
+
+func TestCreateValidation(t *testing.T) {
+	options := loadbalancers.CreateOpts{
+		Name:         LoadBalancer1.Name,
+		VipNetworkID: "b0ef5a5c-a6c4-4c1a-9e6a-62f4f5bcb2d8",
+		VipPortID:    "169942e0-9b53-42df-95ef-1a8b6525c2bd",
+	}
+	_, err := options.ToLoadBalancerCreateMap()
+	require.Error(t, err)
+
+	options = loadbalancers.CreateOpts{
+		Name: LoadBalancer1.Name,
+		Listeners: []loadbalancers.CreateListenerOpts{{
+			Name:         "listener_name",
+			ProtocolPort: 80,
+			Protocol:     types.ProtocolTypeHTTP,
+			AllowedCIDRS: []string{"not-a-cidr"},
+		}},
+	}
+	_, err = options.ToLoadBalancerCreateMap()
+	require.Error(t, err)
+}
+
+func TestDelete(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareGetTestURL(LoadBalancer1.ID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		w.WriteHeader(http.StatusOK)
+		_, err := fmt.Fprint(w, TasksResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	client := fake.ServiceTokenClient("loadbalancers", "v2")
+	tasks, err := loadbalancers.Delete(client, LoadBalancer1.ID, nil).Extract()
+	require.NoError(t, err)
+	require.Equal(t, Tasks1, *tasks)
+}
+
+func TestUpdate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareGetTestURL(LoadBalancer1.ID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PATCH")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, UpdateRequest)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		_, err := fmt.Fprint(w, GetResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	client := fake.ServiceTokenClient("loadbalancers", "v2")
+
+	opts := loadbalancers.UpdateOpts{
+		Name: LoadBalancer1.Name,
+	}
+
+	ct, err := loadbalancers.Update(client, LoadBalancer1.ID, opts).Extract()
+
+	require.NoError(t, err)
+	require.Equal(t, LoadBalancer1, *ct)
+}
+
+func TestExtractLoadBalancerResourcesFromTask(t *testing.T) {
+	task := tasks.Task{
+		CreatedResources: &map[string]interface{}{
+			"loadbalancers": []interface{}{LoadBalancer1.ID},
+			"listeners":     []interface{}{"43658ea9-54bd-4807-90b1-925921c9a0d1"},
+			"pools":         []interface{}{"9fccf0a3-c0de-441d-9afd-2b9b58b08b9f"},
+		},
+	}
+
+	lbID, err := loadbalancers.ExtractLoadBalancerIDFromTask(&task)
+	require.NoError(t, err)
+	require.Equal(t, LoadBalancer1.ID, lbID)
+
+	resources, err := loadbalancers.ExtractLoadBalancerResourcesFromTask(&task)
+	require.NoError(t, err)
+	require.Equal(t, []string{"43658ea9-54bd-4807-90b1-925921c9a0d1"}, resources.Listeners)
+	require.Equal(t, []string{"9fccf0a3-c0de-441d-9afd-2b9b58b08b9f"}, resources.Pools)
+}
+
+func TestMetadataCreate(t *testing.T) {
+	metadataV2Testing.BuildTestMetadataCreate("loadbalancers", LoadBalancer1.ID)(t)
+}
+
+func TestMetadataUpdate(t *testing.T) {
+	metadataV2Testing.BuildTestMetadataUpdate("loadbalancers", LoadBalancer1.ID)(t)
+}
+
+func TestMetadataDelete(t *testing.T) {
+	metadataV2Testing.BuildTestMetadataDelete("loadbalancers", LoadBalancer1.ID)(t)
+}
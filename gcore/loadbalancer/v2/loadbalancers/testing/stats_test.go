@@ -0,0 +1,311 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/types"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v2/loadbalancers"
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+)
+
+const statsLoadBalancerResponse = `
+{
+  "id": "e8ab1be4-1521-4266-be69-28dad4148a30",
+  "name": "lbname",
+  "provisioning_status": "ACTIVE",
+  "operating_status": "%s",
+  "stats": {
+    "active_connections": %d,
+    "bytes_in": %d,
+    "bytes_out": %d,
+    "request_errors": 0,
+    "total_connections": 10
+  }
+}
+`
+
+const statsListenersResponse = `
+{
+  "count": 1,
+  "results": [
+    {
+      "id": "43658ea9-54bd-4807-90b1-925921c9a0d1",
+      "name": "listener",
+      "loadbalancer_id": "e8ab1be4-1521-4266-be69-28dad4148a30",
+      "operating_status": "ONLINE",
+      "provisioning_status": "ACTIVE",
+      "stats": {
+        "active_connections": %d,
+        "bytes_in": %d,
+        "bytes_out": %d,
+        "request_errors": 0,
+        "total_connections": 10
+      }
+    }
+  ]
+}
+`
+
+const statsPoolsResponse = `
+{
+  "count": 1,
+  "results": [
+    {
+      "id": "9fccf0a3-c0de-441d-9afd-2b9b58b08b9f",
+      "name": "pool",
+      "provisioning_status": "ACTIVE",
+      "operating_status": "ONLINE",
+      "members": [
+        {
+          "id": "65f4e0eb-7846-490e-b44d-726c8baf3c25",
+          "address": "192.168.13.9",
+          "protocol_port": 80,
+          "operating_status": "ONLINE"
+        },
+        {
+          "id": "f6a9c5dd-f8cc-448d-8e57-81de69d127cb",
+          "address": "192.168.13.8",
+          "protocol_port": 80,
+          "operating_status": "%s"
+        }
+      ]
+    }
+  ]
+}
+`
+
+const statsPoolsSingleMemberResponse = `
+{
+  "count": 1,
+  "results": [
+    {
+      "id": "9fccf0a3-c0de-441d-9afd-2b9b58b08b9f",
+      "name": "pool",
+      "provisioning_status": "ACTIVE",
+      "operating_status": "ONLINE",
+      "members": [
+        {
+          "id": "65f4e0eb-7846-490e-b44d-726c8baf3c25",
+          "address": "192.168.13.9",
+          "protocol_port": 80,
+          "operating_status": "ONLINE"
+        }
+      ]
+    }
+  ]
+}
+`
+
+// setupStatsHandlers serves growing counters and turns the second member OFFLINE after the first poll.
+func setupStatsHandlers(t *testing.T) {
+	var poolCalls int32
+
+	setupStatsTrafficHandlers(t)
+
+	th.Mux.HandleFunc(fmt.Sprintf("/v2/lbpools/%d/%d", fake.ProjectID, fake.RegionID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		require.Equal(t, "true", r.URL.Query().Get("details"))
+		status := "ONLINE"
+		if atomic.AddInt32(&poolCalls, 1) > 1 {
+			status = "OFFLINE"
+		}
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := fmt.Fprintf(w, statsPoolsResponse, status)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+}
+
+// setupStatsTrafficHandlers serves growing loadbalancer and listener counters.
+func setupStatsTrafficHandlers(t *testing.T) {
+	var lbCalls, listenerCalls int32
+
+	th.Mux.HandleFunc(prepareGetTestURL(LoadBalancer1.ID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		require.Equal(t, "true", r.URL.Query().Get("show_stats"))
+		n := int(atomic.AddInt32(&lbCalls, 1))
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := fmt.Fprintf(w, statsLoadBalancerResponse, "ONLINE", n*2, n*1000, n*4000)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	th.Mux.HandleFunc(fmt.Sprintf("/v2/lblisteners/%d/%d", fake.ProjectID, fake.RegionID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		require.Equal(t, LoadBalancer1.ID, r.URL.Query().Get("loadbalancer_id"))
+		n := int(atomic.AddInt32(&listenerCalls, 1))
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := fmt.Fprintf(w, statsListenersResponse, n, n*500, n*2000)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+}
+
+func newTestStatsWatcher(interval time.Duration) *loadbalancers.StatsWatcher {
+	return loadbalancers.NewStatsWatcher(
+		fake.ServiceTokenClient("loadbalancers", "v2"),
+		fake.ServiceTokenClient("lblisteners", "v2"),
+		fake.ServiceTokenClient("lbpools", "v2"),
+		loadbalancers.StatsWatcherOpts{LoadBalancerIDs: []string{LoadBalancer1.ID}, Interval: interval},
+	)
+}
+
+func TestStatsWatcherPoll(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	setupStatsHandlers(t)
+
+	watcher := newTestStatsWatcher(time.Second)
+
+	events, err := watcher.Poll()
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	time.Sleep(10 * time.Millisecond)
+
+	events, err = watcher.Poll()
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	byType := make(map[loadbalancers.StatsEventType]loadbalancers.StatsEvent)
+	for _, e := range events {
+		byType[e.Type] = e
+	}
+
+	lbEvent := byType[loadbalancers.StatsEventLoadBalancer]
+	require.Equal(t, LoadBalancer1.ID, lbEvent.LoadBalancerID)
+	require.Equal(t, 4, lbEvent.ActiveConnections)
+	require.Equal(t, 2, lbEvent.ActiveConnectionsDelta)
+	require.Greater(t, lbEvent.BytesInRate, float64(0))
+	require.Greater(t, lbEvent.BytesOutRate, lbEvent.BytesInRate)
+
+	listenerEvent := byType[loadbalancers.StatsEventListener]
+	require.Equal(t, "43658ea9-54bd-4807-90b1-925921c9a0d1", listenerEvent.ListenerID)
+	require.Equal(t, 1, listenerEvent.ActiveConnectionsDelta)
+
+	memberEvent := byType[loadbalancers.StatsEventMemberStatus]
+	require.Equal(t, "f6a9c5dd-f8cc-448d-8e57-81de69d127cb", memberEvent.MemberID)
+	require.Equal(t, types.OperatingStatusOnline, memberEvent.PreviousOperatingStatus)
+	require.Equal(t, types.OperatingStatusOffline, memberEvent.OperatingStatus)
+	require.True(t, memberEvent.IsMemberFailure())
+}
+
+func TestStatsWatcherCollect(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	setupStatsHandlers(t)
+
+	watcher := newTestStatsWatcher(time.Second)
+	require.Equal(t, 0, testutil.CollectAndCount(watcher))
+
+	_, err := watcher.Poll()
+	require.NoError(t, err)
+
+	require.Equal(t, 1, testutil.CollectAndCount(watcher, "gcore_loadbalancer_active_connections"))
+	require.Equal(t, 1, testutil.CollectAndCount(watcher, "gcore_loadbalancer_listener_bytes_in_total"))
+	require.Equal(t, 2, testutil.CollectAndCount(watcher, "gcore_loadbalancer_pool_member_up"))
+	require.Equal(t, 2, testutil.CollectAndCount(watcher, "gcore_loadbalancer_pool_member_operating_status"))
+	problems, err := testutil.CollectAndLint(watcher)
+	require.NoError(t, err)
+	require.Empty(t, problems)
+}
+
+func TestStatsWatcherRun(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	setupStatsHandlers(t)
+
+	watcher := newTestStatsWatcher(10 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	go func() {
+		err := watcher.Run(ctx, func(err error) {
+			t.Error(err)
+		})
+		require.NoError(t, err)
+	}()
+
+	reported := false
+	for event := range watcher.Events() {
+		if event.IsMemberFailure() {
+			reported = true
+			cancel()
+		}
+	}
+	require.True(t, reported, "member failure was not reported")
+}
+
+func TestStatsWatcherRunTwice(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	setupStatsHandlers(t)
+
+	watcher := newTestStatsWatcher(10 * time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	require.NoError(t, watcher.Run(ctx, nil))
+	require.Equal(t, loadbalancers.ErrStatsWatcherStarted, watcher.Run(ctx, nil))
+
+	_, ok := <-watcher.Events()
+	require.False(t, ok)
+}
+
+func TestStatsWatcherMemberRemoved(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	setupStatsTrafficHandlers(t)
+
+	var poolCalls int32
+	th.Mux.HandleFunc(fmt.Sprintf("/v2/lbpools/%d/%d", fake.ProjectID, fake.RegionID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		var err error
+		if atomic.AddInt32(&poolCalls, 1) > 1 {
+			_, err = fmt.Fprint(w, statsPoolsSingleMemberResponse)
+		} else {
+			_, err = fmt.Fprintf(w, statsPoolsResponse, "ONLINE")
+		}
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	watcher := newTestStatsWatcher(time.Second)
+
+	_, err := watcher.Poll()
+	require.NoError(t, err)
+	events, err := watcher.Poll()
+	require.NoError(t, err)
+
+	var removed []loadbalancers.StatsEvent
+	for _, e := range events {
+		if e.Type == loadbalancers.StatsEventMemberRemoved {
+			removed = append(removed, e)
+		}
+	}
+	require.Len(t, removed, 1)
+	require.Equal(t, "f6a9c5dd-f8cc-448d-8e57-81de69d127cb", removed[0].MemberID)
+	require.Equal(t, "9fccf0a3-c0de-441d-9afd-2b9b58b08b9f", removed[0].PoolID)
+	require.Equal(t, types.OperatingStatusOnline, removed[0].PreviousOperatingStatus)
+	require.False(t, removed[0].IsMemberFailure())
+	require.Equal(t, 1, testutil.CollectAndCount(watcher, "gcore_loadbalancer_pool_member_up"))
+}
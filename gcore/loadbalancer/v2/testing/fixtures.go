@@ -1,11 +0,0 @@
-package testing
-
-import (
-	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/loadbalancers"
-)
-
-var (
-	LoadBalancer1 = loadbalancers.LoadBalancer{
-		ID: "e8ab1be4-1521-4266-be69-28dad4148a30",
-	}
-)
@@ -0,0 +1,299 @@
+package quotas
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/flavor/v1/flavors"
+	gpuflavors "github.com/G-Core/gcorelabscloud-go/gcore/gpu/v3/flavors"
+)
+
+const (
+	// ScopeRegional marks a quota of a region.
+	ScopeRegional = "regional"
+	// ScopeGlobal marks a quota of the whole account.
+	ScopeGlobal = "global"
+	// GPUServerQuotaName is the regional quota of baremetal GPU servers.
+	GPUServerQuotaName = "baremetal_gpu_count"
+)
+
+// baremetalClasses are flavor classes with a regional baremetal server quota, e.g. bm1-hf-medium is counted in baremetal_hf_count.
+var baremetalClasses = map[string]bool{"basic": true, "hf": true, "infrastructure": true, "network": true, "storage": true}
+
+var nonAlphanumeric = regexp.MustCompile("[^a-z0-9]+")
+
+// GPUModelQuotaName returns the regional quota of GPU servers of a model, e.g. baremetal_gpu_h100_count for H100.
+func GPUModelQuotaName(model string) string {
+	model = strings.Trim(nonAlphanumeric.ReplaceAllString(strings.ToLower(model), "_"), "_")
+	return fmt.Sprintf("baremetal_gpu_%s_count", model)
+}
+
+// PlanClients groups service clients used to plan creations.
+type PlanClients struct {
+	// Quotas is a quotas v2 client.
+	Quotas *gcorecloud.ServiceClient `validate:"required"`
+	// Flavors is a flavors v1 client of the region. It is required to plan virtual instances.
+	Flavors *gcorecloud.ServiceClient
+	// GPU is a GPU baremetal v3 client of the region. It is required to plan GPU servers.
+	GPU *gcorecloud.ServiceClient
+}
+
+// VolumePlan is an intended volume creation.
+type VolumePlan struct {
+	// Size in GiB.
+	Size  int `validate:"required,gt=0"`
+	Count int `validate:"omitempty,gt=0"`
+}
+
+// InstancePlan is an intended instance creation. Flavors starting with bm are baremetal flavors.
+type InstancePlan struct {
+	Flavor string `validate:"required"`
+	Count  int    `validate:"omitempty,gt=0"`
+	// Volumes are created with every instance.
+	Volumes []VolumePlan `validate:"omitempty,dive"`
+	// ExternalIP is set when every instance gets an external interface.
+	ExternalIP bool
+}
+
+// GPUServerPlan is an intended creation of baremetal GPU cluster servers.
+type GPUServerPlan struct {
+	Flavor string `validate:"required"`
+	Count  int    `validate:"required,gt=0"`
+}
+
+// PlanOpts represents intended creations in a region.
+type PlanOpts struct {
+	ClientID      int             `validate:"required"`
+	RegionID      int             `validate:"required"`
+	Instances     []InstancePlan  `validate:"omitempty,dive"`
+	Volumes       []VolumePlan    `validate:"omitempty,dive"`
+	GPUServers    []GPUServerPlan `validate:"omitempty,dive"`
+	FloatingIPs   int             `validate:"omitempty,gte=0"`
+	LoadBalancers int             `validate:"omitempty,gte=0"`
+	Keypairs      int             `validate:"omitempty,gte=0"`
+	Projects      int             `validate:"omitempty,gte=0"`
+}
+
+// QuotaCheck compares a quota with the requested amount.
+type QuotaCheck struct {
+	Name      string `json:"name"`
+	Scope     string `json:"scope"`
+	Limit     int    `json:"limit"`
+	Usage     int    `json:"usage"`
+	Requested int    `json:"requested"`
+}
+
+// Free returns the amount left in the quota.
+func (q QuotaCheck) Free() int {
+	return q.Limit - q.Usage
+}
+
+// Exceeded returns whether the requested amount does not fit in the quota.
+func (q QuotaCheck) Exceeded() bool {
+	return q.Usage+q.Requested > q.Limit
+}
+
+// String describes the check.
+func (q QuotaCheck) String() string {
+	return fmt.Sprintf("%s quota %s: %d requested, %d of %d used", q.Scope, q.Name, q.Requested, q.Usage, q.Limit)
+}
+
+// PlanResult describes checked quotas.
+type PlanResult struct {
+	RegionID int          `json:"region_id"`
+	Checks   []QuotaCheck `json:"checks"`
+	Exceeded []QuotaCheck `json:"exceeded"`
+	// Unknown are requested quotas the account has no limit for. They are not checked.
+	Unknown []string `json:"unknown,omitempty"`
+}
+
+// Err returns an error listing the exceeded quotas, nil when everything fits.
+func (r *PlanResult) Err() error {
+	if len(r.Exceeded) == 0 {
+		return nil
+	}
+	messages := make([]string, 0, len(r.Exceeded))
+	for _, q := range r.Exceeded {
+		messages = append(messages, q.String())
+	}
+	return fmt.Errorf("quotas exceeded in region %d: %s", r.RegionID, strings.Join(messages, "; "))
+}
+
+// Requirements maps planned creations onto regional and global quota names.
+type Requirements struct {
+	Regional map[string]int `json:"regional"`
+	Global   map[string]int `json:"global"`
+}
+
+func (r Requirements) addRegional(name string, amount int) {
+	if amount != 0 {
+		r.Regional[name] += amount
+	}
+}
+
+func count(n int) int {
+	if n == 0 {
+		return 1
+	}
+	return n
+}
+
+// Require maps planned creations onto quota names. Virtual instances take vm_count, or shared_vm_count for
+// shared flavors, together with cpu_count and ram from the flavor. Baremetal instances take the quota of their
+// flavor class, e.g. baremetal_hf_count. GPU servers take baremetal_gpu_count and the quota of their GPU model.
+func Require(clients PlanClients, opts PlanOpts) (*Requirements, error) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	req := &Requirements{Regional: map[string]int{}, Global: map[string]int{}}
+
+	var vmFlavors []flavors.Flavor
+	for _, instance := range opts.Instances {
+		n := count(instance.Count)
+		if strings.HasPrefix(instance.Flavor, "bm") {
+			parts := strings.SplitN(instance.Flavor, "-", 3)
+			if len(parts) < 2 || !baremetalClasses[parts[1]] {
+				return nil, fmt.Errorf("unknown baremetal flavor class of %s", instance.Flavor)
+			}
+			req.addRegional(fmt.Sprintf("baremetal_%s_count", parts[1]), n)
+		} else {
+			if vmFlavors == nil {
+				if clients.Flavors == nil {
+					return nil, fmt.Errorf("flavors client is required to plan instances")
+				}
+				var err error
+				if vmFlavors, err = flavors.ListAll(clients.Flavors, nil); err != nil {
+					return nil, fmt.Errorf("cannot list flavors: %w", err)
+				}
+			}
+			flavor := findFlavor(vmFlavors, instance.Flavor)
+			if flavor == nil {
+				return nil, fmt.Errorf("flavor %s does not exist in region %d", instance.Flavor, opts.RegionID)
+			}
+			if strings.Contains(flavor.FlavorName, "shared") {
+				req.addRegional("shared_vm_count", n)
+			} else {
+				req.addRegional("vm_count", n)
+			}
+			req.addRegional("cpu_count", flavor.VCPUS*n)
+			req.addRegional("ram", flavor.RAM*n)
+		}
+		for _, volume := range instance.Volumes {
+			req.addRegional("volume_count", count(volume.Count)*n)
+			req.addRegional("volume_size", volume.Size*count(volume.Count)*n)
+		}
+		if instance.ExternalIP {
+			req.addRegional("external_ip_count", n)
+		}
+	}
+	for _, volume := range opts.Volumes {
+		req.addRegional("volume_count", count(volume.Count))
+		req.addRegional("volume_size", volume.Size*count(volume.Count))
+	}
+
+	if len(opts.GPUServers) != 0 {
+		if clients.GPU == nil {
+			return nil, fmt.Errorf("GPU client is required to plan GPU servers")
+		}
+		pages, err := gpuflavors.ListBaremetal(clients.GPU, nil).AllPages()
+		if err != nil {
+			return nil, fmt.Errorf("cannot list GPU flavors: %w", err)
+		}
+		gpuFlavors, err := gpuflavors.ExtractBMFlavors(pages)
+		if err != nil {
+			return nil, err
+		}
+		for _, server := range opts.GPUServers {
+			flavor := findGPUFlavor(gpuFlavors, server.Flavor)
+			if flavor == nil {
+				return nil, fmt.Errorf("GPU flavor %s does not exist in region %d", server.Flavor, opts.RegionID)
+			}
+			req.addRegional(GPUServerQuotaName, server.Count)
+			if p := flavor.HardwareProperties; p != nil && p.GPUModel != nil && *p.GPUModel != "" {
+				req.addRegional(GPUModelQuotaName(*p.GPUModel), server.Count)
+			}
+		}
+	}
+
+	req.addRegional("floating_count", opts.FloatingIPs)
+	req.addRegional("loadbalancer_count", opts.LoadBalancers)
+	if opts.Keypairs != 0 {
+		req.Global["keypair_count"] += opts.Keypairs
+	}
+	if opts.Projects != 0 {
+		req.Global["project_count"] += opts.Projects
+	}
+	return req, nil
+}
+
+func findFlavor(list []flavors.Flavor, name string) *flavors.Flavor {
+	for i := range list {
+		if list[i].FlavorName == name || list[i].FlavorID == name {
+			return &list[i]
+		}
+	}
+	return nil
+}
+
+func findGPUFlavor(list []gpuflavors.BMFlavor, name string) *gpuflavors.BMFlavor {
+	for i := range list {
+		if list[i].Name == name || list[i].ID == name {
+			return &list[i]
+		}
+	}
+	return nil
+}
+
+// Plan checks planned creations in a region against the regional and global quotas of the account before
+// anything is created. Every requested quota is reported, exceeded ones also in Exceeded. Use PlanResult.Err
+// to stop on exceeded quotas.
+func Plan(clients PlanClients, opts PlanOpts) (*PlanResult, error) {
+	if err := gcorecloud.ValidateStruct(clients); err != nil {
+		return nil, err
+	}
+	req, err := Require(clients, opts)
+	if err != nil {
+		return nil, err
+	}
+	combined, err := ListCombined(clients.Quotas, ListCombinedOpts{ClientID: opts.ClientID}).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get quotas: %w", err)
+	}
+	var regional Quota
+	for _, q := range combined.RegionalQuotas {
+		if q["region_id"] == opts.RegionID {
+			regional = q
+			break
+		}
+	}
+	if regional == nil && len(req.Regional) != 0 {
+		return nil, fmt.Errorf("no quotas for region %d", opts.RegionID)
+	}
+
+	result := &PlanResult{RegionID: opts.RegionID}
+	check := func(scope string, quota Quota, requested map[string]int) {
+		names := make([]string, 0, len(requested))
+		for name := range requested {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			limit, ok := quota[name+"_limit"]
+			if !ok {
+				result.Unknown = append(result.Unknown, name)
+				continue
+			}
+			q := QuotaCheck{Name: name, Scope: scope, Limit: limit, Usage: quota[name+"_usage"], Requested: requested[name]}
+			result.Checks = append(result.Checks, q)
+			if q.Exceeded() {
+				result.Exceeded = append(result.Exceeded, q)
+			}
+		}
+	}
+	check(ScopeRegional, regional, req.Regional)
+	check(ScopeGlobal, combined.GlobalQuotas, req.Global)
+	return result, nil
+}
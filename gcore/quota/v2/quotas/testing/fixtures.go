@@ -149,3 +149,69 @@ var (
 	clientID = 3
 	regionID = 1
 )
+
+const PlanFlavorsResponse = `
+{
+  "count": 2,
+  "results": [
+    {"flavor_id": "g1-standard-2-4", "flavor_name": "g1-standard-2-4", "ram": 4096, "vcpus": 2},
+    {"flavor_id": "g1s-shared-1-0.5", "flavor_name": "g1s-shared-1-0.5", "ram": 512, "vcpus": 1}
+  ]
+}
+`
+
+const PlanGPUFlavorsResponse = `
+{
+  "count": 1,
+  "results": [
+    {
+      "id": "bm3-ai-1xlarge-h100-80-8",
+      "name": "bm3-ai-1xlarge-h100-80-8",
+      "capacity": 4,
+      "disabled": false,
+      "hardware_properties": {"gpu_model": "H100", "gpu_manufacturer": "NVIDIA", "gpu_count": 8}
+    }
+  ]
+}
+`
+
+const PlanCombinedResponse = `
+{
+  "global_quotas": {
+    "keypair_count_limit": 100,
+    "keypair_count_usage": 99,
+    "project_count_limit": 2,
+    "project_count_usage": 1
+  },
+  "regional_quotas": [
+    {
+      "region_id": 1,
+      "vm_count_limit": 10,
+      "vm_count_usage": 8,
+      "shared_vm_count_limit": 5,
+      "shared_vm_count_usage": 0,
+      "cpu_count_limit": 20,
+      "cpu_count_usage": 10,
+      "ram_limit": 40960,
+      "ram_usage": 10240,
+      "volume_count_limit": 10,
+      "volume_count_usage": 2,
+      "volume_size_limit": 500,
+      "volume_size_usage": 450,
+      "external_ip_count_limit": 5,
+      "external_ip_count_usage": 0,
+      "baremetal_hf_count_limit": 1,
+      "baremetal_hf_count_usage": 0,
+      "baremetal_gpu_count_limit": 4,
+      "baremetal_gpu_count_usage": 0,
+      "floating_count_limit": 2,
+      "floating_count_usage": 2
+    },
+    {
+      "region_id": 2,
+      "vm_count_limit": 100,
+      "vm_count_usage": 0
+    }
+  ]
+}
+`
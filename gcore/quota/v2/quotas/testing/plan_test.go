@@ -0,0 +1,120 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/quota/v2/quotas"
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+)
+
+func handlePlan(t *testing.T) {
+	for url, body := range map[string]string{
+		fmt.Sprintf("/v1/flavors/%d/%d", fake.ProjectID, fake.RegionID):               PlanFlavorsResponse,
+		fmt.Sprintf("/v3/gpu/baremetal/%d/%d/flavors", fake.ProjectID, fake.RegionID): PlanGPUFlavorsResponse,
+		prepareListCombinedTestURL():                                                  PlanCombinedResponse,
+	} {
+		body := body
+		th.Mux.HandleFunc(url, func(w http.ResponseWriter, r *http.Request) {
+			th.TestMethod(t, r, "GET")
+			th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = fmt.Fprint(w, body)
+		})
+	}
+}
+
+func planClients() quotas.PlanClients {
+	return quotas.PlanClients{
+		Quotas:  fake.ServiceTokenClient("", "v2"),
+		Flavors: fake.ServiceTokenClient("flavors", "v1"),
+		GPU:     fake.ServiceTokenClient("gpu/baremetal", "v3"),
+	}
+}
+
+func TestPlan(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	handlePlan(t)
+
+	result, err := quotas.Plan(planClients(), quotas.PlanOpts{
+		ClientID: 2,
+		RegionID: fake.RegionID,
+		Instances: []quotas.InstancePlan{
+			{Flavor: "g1-standard-2-4", Count: 3, ExternalIP: true, Volumes: []quotas.VolumePlan{{Size: 20}}},
+			{Flavor: "g1s-shared-1-0.5"},
+			{Flavor: "bm1-hf-medium"},
+		},
+		Volumes:     []quotas.VolumePlan{{Size: 10, Count: 2}},
+		GPUServers:  []quotas.GPUServerPlan{{Flavor: "bm3-ai-1xlarge-h100-80-8", Count: 2}},
+		FloatingIPs: 1,
+		Keypairs:    1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []quotas.QuotaCheck{
+		{Name: "baremetal_gpu_count", Scope: quotas.ScopeRegional, Limit: 4, Usage: 0, Requested: 2},
+		{Name: "baremetal_hf_count", Scope: quotas.ScopeRegional, Limit: 1, Usage: 0, Requested: 1},
+		{Name: "cpu_count", Scope: quotas.ScopeRegional, Limit: 20, Usage: 10, Requested: 7},
+		{Name: "external_ip_count", Scope: quotas.ScopeRegional, Limit: 5, Usage: 0, Requested: 3},
+		{Name: "floating_count", Scope: quotas.ScopeRegional, Limit: 2, Usage: 2, Requested: 1},
+		{Name: "ram", Scope: quotas.ScopeRegional, Limit: 40960, Usage: 10240, Requested: 12800},
+		{Name: "shared_vm_count", Scope: quotas.ScopeRegional, Limit: 5, Usage: 0, Requested: 1},
+		{Name: "vm_count", Scope: quotas.ScopeRegional, Limit: 10, Usage: 8, Requested: 3},
+		{Name: "volume_count", Scope: quotas.ScopeRegional, Limit: 10, Usage: 2, Requested: 5},
+		{Name: "volume_size", Scope: quotas.ScopeRegional, Limit: 500, Usage: 450, Requested: 80},
+		{Name: "keypair_count", Scope: quotas.ScopeGlobal, Limit: 100, Usage: 99, Requested: 1},
+	}, result.Checks)
+	require.Equal(t, []string{"floating_count", "vm_count", "volume_size"}, names(result.Exceeded))
+	require.Equal(t, []string{"baremetal_gpu_h100_count"}, result.Unknown)
+	require.EqualError(t, result.Err(), "quotas exceeded in region 1: "+
+		"regional quota floating_count: 1 requested, 2 of 2 used; "+
+		"regional quota vm_count: 3 requested, 8 of 10 used; "+
+		"regional quota volume_size: 80 requested, 450 of 500 used")
+}
+
+func TestPlanFits(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	handlePlan(t)
+
+	result, err := quotas.Plan(planClients(), quotas.PlanOpts{
+		ClientID:  2,
+		RegionID:  fake.RegionID,
+		Instances: []quotas.InstancePlan{{Flavor: "g1-standard-2-4", Count: 2}},
+		Projects:  1,
+	})
+	require.NoError(t, err)
+	require.Empty(t, result.Exceeded)
+	require.NoError(t, result.Err())
+}
+
+func TestPlanUnknownFlavor(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	handlePlan(t)
+
+	_, err := quotas.Plan(planClients(), quotas.PlanOpts{
+		ClientID:  2,
+		RegionID:  fake.RegionID,
+		Instances: []quotas.InstancePlan{{Flavor: "g9-missing"}},
+	})
+	require.EqualError(t, err, "flavor g9-missing does not exist in region 1")
+}
+
+func TestGPUModelQuotaName(t *testing.T) {
+	require.Equal(t, "baremetal_gpu_h100_count", quotas.GPUModelQuotaName("H100"))
+	require.Equal(t, "baremetal_gpu_a100_80gb_count", quotas.GPUModelQuotaName("A100 80GB"))
+}
+
+func names(checks []quotas.QuotaCheck) []string {
+	var result []string
+	for _, q := range checks {
+		result = append(result, q.Name)
+	}
+	return result
+}
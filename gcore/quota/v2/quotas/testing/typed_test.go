@@ -0,0 +1,52 @@
+package testing
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/quota/v2/quotas"
+)
+
+func TestUsage(t *testing.T) {
+	u := quotas.Usage{Limit: 10, Usage: 8}
+	require.Equal(t, 2, u.Free())
+	require.Equal(t, 80.0, u.Percent())
+	require.True(t, u.Above(80))
+	require.False(t, u.Above(81))
+	require.Equal(t, "8 of 10 used (80%)", u.String())
+
+	require.Equal(t, 100.0, quotas.Usage{Usage: 1}.Percent())
+	require.Equal(t, 0.0, quotas.Usage{}.Percent())
+	require.False(t, quotas.Usage{}.Above(0))
+}
+
+func TestTypedQuota(t *testing.T) {
+	regional := CombinedQuota1.RegionalQuotas[0].Regional()
+	require.Equal(t, 1, regional.RegionID)
+	require.Equal(t, quotas.Usage{Limit: 2}, regional.CPUCount)
+	require.Equal(t, quotas.Usage{Limit: 4096}, regional.RAM)
+	require.Equal(t, quotas.Usage{Limit: 50}, regional.VolumeSnapshotsSize)
+	require.Equal(t, quotas.GlobalQuota{
+		KeypairCount: quotas.Usage{Limit: 100},
+		ProjectCount: quotas.Usage{Limit: 2, Usage: 1},
+	}, CombinedQuota1.Global())
+	require.Len(t, regional.Usages(), 27)
+}
+
+func TestTypedQuotaAbove(t *testing.T) {
+	var combined quotas.CombinedQuota
+	require.NoError(t, json.Unmarshal([]byte(PlanCombinedResponse), &combined))
+
+	require.Equal(t, []string{"keypair_count"}, combined.Global().Above(80))
+	require.Equal(t, []string{"floating_count", "vm_count", "volume_size"}, combined.Region(1).Above(80))
+	require.Equal(t, []string{"cpu_count", "floating_count", "vm_count", "volume_size"}, combined.Region(1).Above(50))
+	require.Empty(t, combined.Region(2).Above(1))
+	require.Nil(t, combined.Region(3))
+	require.Len(t, combined.Regional(), 2)
+
+	usages := combined.RegionalQuotas[0].Usages()
+	require.Equal(t, quotas.Usage{Limit: 4}, usages["baremetal_gpu_count"])
+	require.NotContains(t, usages, "region_id")
+}
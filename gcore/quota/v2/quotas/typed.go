@@ -0,0 +1,188 @@
+package quotas
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Usage is the limit of a quota together with its usage.
+type Usage struct {
+	Limit int `json:"limit"`
+	Usage int `json:"usage"`
+}
+
+// Free returns the amount left in the quota.
+func (u Usage) Free() int {
+	return u.Limit - u.Usage
+}
+
+// Percent returns the used part of the limit in percent. A used quota without a limit is 100 percent used.
+func (u Usage) Percent() float64 {
+	if u.Limit <= 0 {
+		if u.Usage > 0 {
+			return 100
+		}
+		return 0
+	}
+	return float64(u.Usage) * 100 / float64(u.Limit)
+}
+
+// Above returns whether the used part of the limit reaches the threshold in percent.
+func (u Usage) Above(threshold float64) bool {
+	return u.Usage > 0 && u.Percent() >= threshold
+}
+
+// String describes the usage.
+func (u Usage) String() string {
+	return fmt.Sprintf("%d of %d used (%.0f%%)", u.Usage, u.Limit, u.Percent())
+}
+
+// GlobalQuota is a typed global quota of an account. Fields are named after the quotas without the _limit and _usage suffixes.
+type GlobalQuota struct {
+	KeypairCount Usage `json:"keypair_count"`
+	ProjectCount Usage `json:"project_count"`
+}
+
+// RegionalQuota is a typed regional quota of an account. Fields are named after the quotas without the _limit and _usage suffixes.
+type RegionalQuota struct {
+	RegionID                     int   `json:"region_id"`
+	BaremetalBasicCount          Usage `json:"baremetal_basic_count"`
+	BaremetalHFCount             Usage `json:"baremetal_hf_count"`
+	BaremetalInfrastructureCount Usage `json:"baremetal_infrastructure_count"`
+	BaremetalNetworkCount        Usage `json:"baremetal_network_count"`
+	BaremetalStorageCount        Usage `json:"baremetal_storage_count"`
+	ClusterCount                 Usage `json:"cluster_count"`
+	CPUCount                     Usage `json:"cpu_count"`
+	ExternalIPCount              Usage `json:"external_ip_count"`
+	FirewallCount                Usage `json:"firewall_count"`
+	FloatingCount                Usage `json:"floating_count"`
+	GPUCount                     Usage `json:"gpu_count"`
+	ImageCount                   Usage `json:"image_count"`
+	ImageSize                    Usage `json:"image_size"`
+	LoadbalancerCount            Usage `json:"loadbalancer_count"`
+	NetworkCount                 Usage `json:"network_count"`
+	RAM                          Usage `json:"ram"`
+	RouterCount                  Usage `json:"router_count"`
+	SecretCount                  Usage `json:"secret_count"`
+	ServergroupCount             Usage `json:"servergroup_count"`
+	SharedVMCount                Usage `json:"shared_vm_count"`
+	SnapshotScheduleCount        Usage `json:"snapshot_schedule_count"`
+	SubnetCount                  Usage `json:"subnet_count"`
+	VMCount                      Usage `json:"vm_count"`
+	VolumeCount                  Usage `json:"volume_count"`
+	VolumeSize                   Usage `json:"volume_size"`
+	VolumeSnapshotsCount         Usage `json:"volume_snapshots_count"`
+	VolumeSnapshotsSize          Usage `json:"volume_snapshots_size"`
+}
+
+var usageType = reflect.TypeOf(Usage{})
+
+// fillUsages sets Usage fields of the struct pointed by v from the _limit and _usage keys of the quota.
+func fillUsages(q Quota, v interface{}) {
+	value := reflect.ValueOf(v).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if field.Type == usageType {
+			value.Field(i).Set(reflect.ValueOf(Usage{Limit: q[name+"_limit"], Usage: q[name+"_usage"]}))
+		} else if field.Type.Kind() == reflect.Int {
+			value.Field(i).SetInt(int64(q[name]))
+		}
+	}
+}
+
+// usages returns Usage fields of the struct by quota name.
+func usages(v interface{}) map[string]Usage {
+	value := reflect.ValueOf(v)
+	m := make(map[string]Usage)
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.Type == usageType {
+			m[strings.Split(field.Tag.Get("json"), ",")[0]] = value.Field(i).Interface().(Usage)
+		}
+	}
+	return m
+}
+
+// Regional returns the typed regional quota.
+func (q Quota) Regional() RegionalQuota {
+	var r RegionalQuota
+	fillUsages(q, &r)
+	return r
+}
+
+// Global returns the typed global quota.
+func (q Quota) Global() GlobalQuota {
+	var g GlobalQuota
+	fillUsages(q, &g)
+	return g
+}
+
+// Usages returns every quota having a limit by name, including quotas missing from the typed model, e.g. GPU model quotas.
+func (q Quota) Usages() map[string]Usage {
+	m := make(map[string]Usage)
+	for key, limit := range q {
+		if name := strings.TrimSuffix(key, "_limit"); name != key {
+			m[name] = Usage{Limit: limit, Usage: q[name+"_usage"]}
+		}
+	}
+	return m
+}
+
+// Usages returns the global quotas by name.
+func (g GlobalQuota) Usages() map[string]Usage {
+	return usages(g)
+}
+
+// Usages returns the regional quotas by name.
+func (r RegionalQuota) Usages() map[string]Usage {
+	return usages(r)
+}
+
+// Above returns names of the global quotas reaching the threshold in percent, sorted.
+func (g GlobalQuota) Above(threshold float64) []string {
+	return above(g.Usages(), threshold)
+}
+
+// Above returns names of the regional quotas reaching the threshold in percent, sorted.
+func (r RegionalQuota) Above(threshold float64) []string {
+	return above(r.Usages(), threshold)
+}
+
+func above(m map[string]Usage, threshold float64) []string {
+	var names []string
+	for name, u := range m {
+		if u.Above(threshold) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Global returns the typed global quota.
+func (c CombinedQuota) Global() GlobalQuota {
+	return c.GlobalQuotas.Global()
+}
+
+// Regional returns the typed regional quotas.
+func (c CombinedQuota) Regional() []RegionalQuota {
+	r := make([]RegionalQuota, 0, len(c.RegionalQuotas))
+	for _, q := range c.RegionalQuotas {
+		r = append(r, q.Regional())
+	}
+	return r
+}
+
+// Region returns the typed quota of the region, nil when the account has no quota there.
+func (c CombinedQuota) Region(regionID int) *RegionalQuota {
+	for _, q := range c.RegionalQuotas {
+		if q["region_id"] == regionID {
+			r := q.Regional()
+			return &r
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+/*
+Package acme issues and renews loadbalancer listener certificates with an ACME server such as Let's Encrypt.
+
+HTTP-01 challenges are answered by a Responder served by the caller. LoadBalancerSolver routes
+/.well-known/acme-challenge/ requests of the loadbalancer HTTP listener to it through a temporary
+pool and l7 policy, which are removed once the challenges are solved. Certificates are stored as v2 secrets.
+
+Example to serve challenges through the loadbalancer
+
+	responder := acme.NewResponder()
+	go http.ListenAndServe(":8080", responder)
+
+	solver, err := acme.NewLoadBalancerSolver(acme.RouteClients{
+		Pools:      poolClientV2,
+		L7Policies: l7policyClient,
+	}, responder, acme.RouteOpts{
+		LoadBalancerID: "79943b8c-3c2c-4c71-8b3f-0e3f1e1e1a2b",
+		ListenerID:     "c63341c7-4b4c-4ad7-8b57-e4b2ea2bfd1f",
+		Address:        net.ParseIP("10.0.0.5"),
+		ProtocolPort:   8080,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+Example to issue or renew a listener certificate
+
+	issuer, err := acme.NewIssuer(acme.IssuerOpts{
+		DirectoryURL: acme.LetsEncryptURL,
+		AccountKey:   accountKey,
+		Contact:      []string{"mailto:admin@example.com"},
+		Solver:       solver,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	result, err := issuer.Renew(ctx, certs.Clients{
+		Listeners: listenerClientV2,
+		Secrets:   secretClient,
+		SecretsV2: secretClientV2,
+	}, acme.RenewOpts{
+		ListenerID: "43658ea9-54bd-4807-90b1-925921c9a0d1",
+		Domains:    []string{"www.example.com"},
+	})
+	if err != nil {
+		panic(err)
+	}
+*/
+package acme
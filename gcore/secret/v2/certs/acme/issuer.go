@@ -0,0 +1,191 @@
+package acme
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/secret/v2/certs"
+	xacme "golang.org/x/crypto/acme"
+)
+
+const (
+	// LetsEncryptURL is the Let's Encrypt production directory.
+	LetsEncryptURL = xacme.LetsEncryptURL
+	// LetsEncryptStagingURL is the Let's Encrypt staging directory.
+	LetsEncryptStagingURL = "https://acme-staging-v02.api.letsencrypt.org/directory"
+)
+
+// IssuerOpts represents options used to create an Issuer.
+type IssuerOpts struct {
+	// DirectoryURL is the ACME directory, e.g. LetsEncryptURL or a local pebble https://localhost:14000/dir.
+	DirectoryURL string `validate:"required,url"`
+	// AccountKey is the key of the ACME account. The account is registered on the first issuance when it does not exist.
+	AccountKey crypto.Signer `validate:"required"`
+	// Contact are the account contact URLs, e.g. mailto:admin@example.com.
+	Contact []string
+	// HTTPClient is used to talk to the ACME server. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+	Solver     Solver `validate:"required"`
+}
+
+// Issuer obtains certificates from an ACME server solving HTTP-01 challenges with a Solver.
+type Issuer struct {
+	client  *xacme.Client
+	solver  Solver
+	contact []string
+
+	mu         sync.Mutex
+	registered bool
+}
+
+// NewIssuer creates an Issuer.
+func NewIssuer(opts IssuerOpts) (*Issuer, error) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	return &Issuer{
+		client: &xacme.Client{
+			Key:          opts.AccountKey,
+			HTTPClient:   opts.HTTPClient,
+			DirectoryURL: opts.DirectoryURL,
+			UserAgent:    "gcorelabscloud-go",
+		},
+		solver:  opts.Solver,
+		contact: opts.Contact,
+	}, nil
+}
+
+// Issue orders a certificate for the domains. The first domain is the certificate common name.
+// A new ECDSA P-256 key is generated for every certificate.
+func (i *Issuer) Issue(ctx context.Context, domains []string) (*certs.Bundle, error) {
+	if len(domains) == 0 {
+		return nil, fmt.Errorf("at least one domain is required")
+	}
+	if err := i.register(ctx); err != nil {
+		return nil, err
+	}
+	order, err := i.client.AuthorizeOrder(ctx, xacme.DomainIDs(domains...))
+	if err != nil {
+		return nil, fmt.Errorf("cannot create order: %w", err)
+	}
+	if err := i.authorize(ctx, order.AuthzURLs); err != nil {
+		return nil, err
+	}
+	orderURI := order.URI
+	if order, err = i.client.WaitOrder(ctx, orderURI); err != nil {
+		return nil, fmt.Errorf("order %s is not ready: %w", orderURI, err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: domains[0]},
+		DNSNames: domains,
+	}, key)
+	if err != nil {
+		return nil, err
+	}
+	der, _, err := i.client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return nil, fmt.Errorf("cannot finalize order: %w", err)
+	}
+	if len(der) == 0 {
+		return nil, fmt.Errorf("no certificate returned")
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	var chain strings.Builder
+	for _, d := range der[1:] {
+		chain.Write(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: d}))
+	}
+	return certs.ParseBundle(
+		string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der[0]})),
+		chain.String(),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})),
+	)
+}
+
+func (i *Issuer) register(ctx context.Context) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.registered {
+		return nil
+	}
+	_, err := i.client.Register(ctx, &xacme.Account{Contact: i.contact}, xacme.AcceptTOS)
+	if err != nil && !errors.Is(err, xacme.ErrAccountAlreadyExists) {
+		return fmt.Errorf("cannot register account: %w", err)
+	}
+	i.registered = true
+	return nil
+}
+
+// authorize solves pending authorizations. Every presented token is cleaned up before it returns.
+func (i *Issuer) authorize(ctx context.Context, authzURLs []string) (err error) {
+	var tokens []string
+	defer func() {
+		for _, token := range tokens {
+			if cleanErr := i.solver.CleanUp(ctx, token); cleanErr != nil && err == nil {
+				err = fmt.Errorf("cannot clean up challenge: %w", cleanErr)
+			}
+		}
+	}()
+
+	var challenges []*xacme.Challenge
+	var pending []string
+	for _, u := range authzURLs {
+		authz, err := i.client.GetAuthorization(ctx, u)
+		if err != nil {
+			return fmt.Errorf("cannot get authorization: %w", err)
+		}
+		if authz.Status == xacme.StatusValid {
+			continue
+		}
+		var challenge *xacme.Challenge
+		for _, c := range authz.Challenges {
+			if c.Type == "http-01" {
+				challenge = c
+				break
+			}
+		}
+		if challenge == nil {
+			return fmt.Errorf("no http-01 challenge for %s", authz.Identifier.Value)
+		}
+		keyAuth, err := i.client.HTTP01ChallengeResponse(challenge.Token)
+		if err != nil {
+			return err
+		}
+		if err := i.solver.Present(ctx, challenge.Token, keyAuth); err != nil {
+			return fmt.Errorf("cannot present challenge for %s: %w", authz.Identifier.Value, err)
+		}
+		tokens = append(tokens, challenge.Token)
+		challenges = append(challenges, challenge)
+		pending = append(pending, authz.URI)
+	}
+
+	// Challenges are accepted only after all of them are presented, so the route is created once.
+	for n, challenge := range challenges {
+		if _, err := i.client.Accept(ctx, challenge); err != nil {
+			return fmt.Errorf("cannot accept challenge: %w", err)
+		}
+		if _, err := i.client.WaitAuthorization(ctx, pending[n]); err != nil {
+			return fmt.Errorf("authorization failed: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package acme
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v2/listeners"
+	secrets1 "github.com/G-Core/gcorelabscloud-go/gcore/secret/v1/secrets"
+	"github.com/G-Core/gcorelabscloud-go/gcore/secret/v2/certs"
+)
+
+// DefaultRenewBefore is how long before the expiration a certificate is renewed.
+const DefaultRenewBefore = 30 * 24 * time.Hour
+
+// RenewOpts represents options used to issue or renew the certificate of a TERMINATED_HTTPS listener.
+type RenewOpts struct {
+	ListenerID string   `validate:"required"`
+	Domains    []string `validate:"required,min=1,dive,required"`
+	// Name is the name of the new secret. Defaults to the first domain followed by the issue date.
+	Name string
+	// RenewBefore defaults to DefaultRenewBefore.
+	RenewBefore time.Duration `validate:"omitempty,gt=0"`
+	// Force issues a new certificate regardless of the current expiration.
+	Force bool
+	// KeepOldSecret disables deleting the replaced secret.
+	KeepOldSecret bool
+	WaitSeconds   int `validate:"omitempty,gt=0"`
+}
+
+// RenewResult describes a finished renewal check.
+type RenewResult struct {
+	Renewed  bool                `json:"renewed"`
+	SecretID string              `json:"secret_id"`
+	NotAfter time.Time           `json:"not_after"`
+	Rotation *certs.RotateResult `json:"rotation,omitempty"`
+	// Skipped explains why a due renewal could not be decided, the listener is left untouched.
+	Skipped string `json:"skipped,omitempty"`
+}
+
+// Renew issues a certificate for a listener without one, or renews the current listener certificate
+// when its secret expires within RenewBefore. The new certificate is stored as a v2 secret and the
+// listener is switched to it with certs.AttachListener or certs.RotateListener.
+// It is meant to be called periodically, a listener with a certificate valid long enough is left untouched.
+// A current secret without an expiration is reported in RenewResult.Skipped and only renewed with Force.
+func (i *Issuer) Renew(ctx context.Context, clients certs.Clients, opts RenewOpts) (*RenewResult, error) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	renewBefore := opts.RenewBefore
+	if renewBefore == 0 {
+		renewBefore = DefaultRenewBefore
+	}
+
+	listener, err := listeners.Get(clients.Listeners, opts.ListenerID, nil).Extract()
+	if err != nil {
+		return nil, err
+	}
+	if listener.SecretID != nil && *listener.SecretID != "" && !opts.Force {
+		secret, err := secrets1.Get(clients.Secrets, *listener.SecretID).Extract()
+		if err != nil {
+			return nil, err
+		}
+		expiration := secret.Expiration.Time
+		if expiration.IsZero() {
+			return &RenewResult{
+				SecretID: secret.ID,
+				Skipped:  fmt.Sprintf("expiration of secret %s is unknown, use force to renew it", secret.ID),
+			}, nil
+		}
+		if expiration.After(time.Now().Add(renewBefore)) {
+			return &RenewResult{SecretID: secret.ID, NotAfter: expiration}, nil
+		}
+	}
+
+	bundle, err := i.Issue(ctx, opts.Domains)
+	if err != nil {
+		return nil, err
+	}
+	name := opts.Name
+	if name == "" {
+		name = fmt.Sprintf("%s-%s", opts.Domains[0], bundle.Certificate.NotBefore.UTC().Format("20060102"))
+	}
+	validate := certs.ValidateOpts{DNSNames: opts.Domains}
+
+	var rotation *certs.RotateResult
+	if listener.SecretID == nil || *listener.SecretID == "" {
+		rotation, err = certs.AttachListener(clients, certs.AttachOpts{
+			ListenerID:  opts.ListenerID,
+			Name:        name,
+			Bundle:      bundle,
+			Validate:    validate,
+			WaitSeconds: opts.WaitSeconds,
+		})
+	} else {
+		rotation, err = certs.RotateListener(clients, certs.RotateOpts{
+			ListenerID:    opts.ListenerID,
+			Name:          name,
+			Bundle:        bundle,
+			KeepOldSecret: opts.KeepOldSecret,
+			Validate:      validate,
+			WaitSeconds:   opts.WaitSeconds,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &RenewResult{
+		Renewed:  true,
+		SecretID: rotation.NewSecretID,
+		NotAfter: bundle.Certificate.NotAfter,
+		Rotation: rotation,
+	}, nil
+}
@@ -0,0 +1,350 @@
+package acme
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/l7policies"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/types"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v2/lbpools"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+)
+
+const (
+	// ChallengePathPrefix is the path HTTP-01 challenges are requested on.
+	ChallengePathPrefix = "/.well-known/acme-challenge/"
+	// DefaultRouteName is the name of the temporary pool and l7 policy.
+	DefaultRouteName = "acme-http01"
+	// DefaultWaitSeconds is how long the solver waits for every task it starts.
+	DefaultWaitSeconds = 300
+)
+
+// Solver makes a key authorization available on http://<domain>/.well-known/acme-challenge/<token>.
+type Solver interface {
+	Present(ctx context.Context, token, keyAuth string) error
+	CleanUp(ctx context.Context, token string) error
+}
+
+// Responder is an http.Handler answering HTTP-01 challenges with the presented key authorizations.
+// It is safe for concurrent use and implements Solver itself, for the case it is already reachable on port 80.
+type Responder struct {
+	mu     sync.RWMutex
+	tokens map[string]string
+}
+
+// NewResponder creates an empty Responder.
+func NewResponder() *Responder {
+	return &Responder{tokens: map[string]string{}}
+}
+
+// ServeHTTP serves the key authorization of a presented token, 404 is returned for anything else.
+func (r *Responder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if !strings.HasPrefix(req.URL.Path, ChallengePathPrefix) {
+		http.NotFound(w, req)
+		return
+	}
+	r.mu.RLock()
+	keyAuth, ok := r.tokens[strings.TrimPrefix(req.URL.Path, ChallengePathPrefix)]
+	r.mu.RUnlock()
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = w.Write([]byte(keyAuth))
+}
+
+// Present adds a key authorization for the token.
+func (r *Responder) Present(_ context.Context, token, keyAuth string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens[token] = keyAuth
+	return nil
+}
+
+// CleanUp removes the token.
+func (r *Responder) CleanUp(_ context.Context, token string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.tokens, token)
+	return nil
+}
+
+// RouteClients groups service clients used to route challenges through a loadbalancer.
+type RouteClients struct {
+	// Pools is a lbpools v2 client.
+	Pools *gcorecloud.ServiceClient
+	// L7Policies is a l7policies v1 client.
+	L7Policies *gcorecloud.ServiceClient
+}
+
+// RouteOpts represents options used to route challenges of a loadbalancer HTTP listener to a Responder.
+type RouteOpts struct {
+	LoadBalancerID string `validate:"required"`
+	// ListenerID is the HTTP listener on port 80 the challenges arrive on.
+	ListenerID string `validate:"required,uuid"`
+	// Address and ProtocolPort are where the Responder is served, reachable from the loadbalancer.
+	Address      net.IP `validate:"required"`
+	ProtocolPort int    `validate:"required,gt=0,lt=65536"`
+	SubnetID     string
+	// Name is the name of the temporary pool and l7 policy. Defaults to DefaultRouteName.
+	Name        string
+	WaitSeconds int `validate:"omitempty,gt=0"`
+}
+
+// LoadBalancerSolver presents challenges with a Responder and routes challenge requests to it
+// through a temporary pool and l7 policy on the loadbalancer. The route is created on the first Present
+// and removed once every presented token is cleaned up, so one route serves all challenges of an order.
+type LoadBalancerSolver struct {
+	responder   *Responder
+	clients     RouteClients
+	opts        RouteOpts
+	waitSeconds int
+
+	mu       sync.Mutex
+	tokens   map[string]struct{}
+	poolID   string
+	policyID string
+}
+
+// NewLoadBalancerSolver creates a LoadBalancerSolver for the responder.
+func NewLoadBalancerSolver(clients RouteClients, responder *Responder, opts RouteOpts) (*LoadBalancerSolver, error) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	if responder == nil {
+		return nil, fmt.Errorf("responder is required")
+	}
+	if opts.Name == "" {
+		opts.Name = DefaultRouteName
+	}
+	s := &LoadBalancerSolver{
+		responder:   responder,
+		clients:     clients,
+		opts:        opts,
+		waitSeconds: opts.WaitSeconds,
+		tokens:      map[string]struct{}{},
+	}
+	if s.waitSeconds == 0 {
+		s.waitSeconds = DefaultWaitSeconds
+	}
+	return s, nil
+}
+
+// Present adds the key authorization to the responder and creates the route when it does not exist yet.
+func (s *LoadBalancerSolver) Present(ctx context.Context, token, keyAuth string) error {
+	if err := s.responder.Present(ctx, token, keyAuth); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.policyID == "" {
+		if err := s.createRoute(); err != nil {
+			_ = s.responder.CleanUp(ctx, token)
+			return err
+		}
+	}
+	s.tokens[token] = struct{}{}
+	return nil
+}
+
+// CleanUp removes the token from the responder and deletes the route after the last token.
+func (s *LoadBalancerSolver) CleanUp(ctx context.Context, token string) error {
+	if err := s.responder.CleanUp(ctx, token); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tokens, token)
+	if len(s.tokens) != 0 {
+		return nil
+	}
+	return s.deleteRoute()
+}
+
+// PoolID returns the temporary pool ID while the route exists.
+func (s *LoadBalancerSolver) PoolID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.poolID
+}
+
+// PolicyID returns the temporary l7 policy ID while the route exists.
+func (s *LoadBalancerSolver) PolicyID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.policyID
+}
+
+func (s *LoadBalancerSolver) createRoute() error {
+	// a route left behind by a failed rollback is removed before creating a new one
+	if err := s.deleteRoute(); err != nil {
+		return err
+	}
+
+	c := s.clients.Pools
+	results, err := lbpools.Create(c, lbpools.CreateOpts{
+		Name:            s.opts.Name,
+		Protocol:        types.ProtocolTypeHTTP,
+		LBPoolAlgorithm: types.LoadBalancerAlgorithmRoundRobin,
+		LoadBalancerID:  s.opts.LoadBalancerID,
+		Members: []lbpools.CreatePoolMemberOpts{{
+			Address:      s.opts.Address,
+			ProtocolPort: s.opts.ProtocolPort,
+			SubnetID:     s.opts.SubnetID,
+		}},
+	}, nil).Extract()
+	if err != nil {
+		return fmt.Errorf("cannot create pool: %w", err)
+	}
+	poolID, err := waitCreated(c, results, s.waitSeconds, lbpools.ExtractPoolIDFromTask)
+	if err != nil {
+		s.poolID = s.findPool(results)
+		return s.rollback(fmt.Errorf("cannot create pool: %w", err))
+	}
+	s.poolID = poolID
+
+	c = s.clients.L7Policies
+	results, err = l7policies.Create(c, l7policies.CreateOpts{
+		Name:           s.opts.Name,
+		ListenerID:     s.opts.ListenerID,
+		Action:         l7policies.ActionRedirectToPool,
+		Position:       1,
+		RedirectPoolID: poolID,
+	}).Extract()
+	if err != nil {
+		return s.rollback(fmt.Errorf("cannot create l7 policy: %w", err))
+	}
+	policyID, err := waitCreated(c, results, s.waitSeconds, l7policies.ExtractL7PolicyIDFromTask)
+	if err != nil {
+		s.policyID = s.findPolicy(results)
+		return s.rollback(fmt.Errorf("cannot create l7 policy: %w", err))
+	}
+	s.policyID = policyID
+
+	results, err = l7policies.CreateRule(c, s.policyID, l7policies.CreateRuleOpts{
+		CompareType: l7policies.CompareTypeStartWith,
+		Type:        l7policies.TypePath,
+		Value:       ChallengePathPrefix,
+	}).Extract()
+	if err == nil {
+		_, err = waitCreated(c, results, s.waitSeconds, l7policies.ExtractRuleIDFromTask)
+	}
+	if err != nil {
+		return s.rollback(fmt.Errorf("cannot create l7 rule: %w", err))
+	}
+	return nil
+}
+
+// findPool returns the ID of a pool whose create task did not finish, so it can still be deleted.
+// The ID is taken from the task created resources, or else from the pool with the route name and member.
+func (s *LoadBalancerSolver) findPool(results *tasks.TaskResults) string {
+	c := s.clients.Pools
+	if len(results.Tasks) != 0 {
+		if task, err := tasks.Get(c, string(results.Tasks[0])).Extract(); err == nil {
+			if id, err := lbpools.ExtractPoolIDFromTask(task); err == nil {
+				return id
+			}
+		}
+	}
+	details := true
+	pools, err := lbpools.ListAll(c, lbpools.ListOpts{LoadBalancerID: &s.opts.LoadBalancerID, MemberDetails: &details})
+	if err != nil {
+		return ""
+	}
+	for _, pool := range pools {
+		if pool.Name != s.opts.Name {
+			continue
+		}
+		for _, member := range pool.Members {
+			if member.Address != nil && member.Address.Equal(s.opts.Address) && member.ProtocolPort == s.opts.ProtocolPort {
+				return pool.ID
+			}
+		}
+	}
+	return ""
+}
+
+// findPolicy returns the ID of an l7 policy whose create task did not finish, so it can still be deleted.
+// The ID is taken from the task created resources, or else from the policy with the route name on the listener.
+func (s *LoadBalancerSolver) findPolicy(results *tasks.TaskResults) string {
+	c := s.clients.L7Policies
+	if len(results.Tasks) != 0 {
+		if task, err := tasks.Get(c, string(results.Tasks[0])).Extract(); err == nil {
+			if id, err := l7policies.ExtractL7PolicyIDFromTask(task); err == nil {
+				return id
+			}
+		}
+	}
+	policies, err := l7policies.ListAll(c)
+	if err != nil {
+		return ""
+	}
+	for _, policy := range policies {
+		if policy.Name == s.opts.Name && policy.ListenerID == s.opts.ListenerID && policy.RedirectPoolID == s.poolID {
+			return policy.ID
+		}
+	}
+	return ""
+}
+
+func (s *LoadBalancerSolver) rollback(err error) error {
+	if deleteErr := s.deleteRoute(); deleteErr != nil {
+		return fmt.Errorf("%w, cannot delete route: %s", err, deleteErr)
+	}
+	return err
+}
+
+// deleteRoute deletes the l7 policy together with its rule, then the pool.
+func (s *LoadBalancerSolver) deleteRoute() error {
+	if s.policyID != "" {
+		if err := waitDeleted(s.clients.L7Policies, l7policies.Delete(s.clients.L7Policies, s.policyID), s.waitSeconds); err != nil {
+			return fmt.Errorf("cannot delete l7 policy %s: %w", s.policyID, err)
+		}
+		s.policyID = ""
+	}
+	if s.poolID != "" {
+		if err := waitDeleted(s.clients.Pools, lbpools.Delete(s.clients.Pools, s.poolID, nil), s.waitSeconds); err != nil {
+			return fmt.Errorf("cannot delete pool %s: %w", s.poolID, err)
+		}
+		s.poolID = ""
+	}
+	return nil
+}
+
+func waitCreated(c *gcorecloud.ServiceClient, results *tasks.TaskResults, waitSeconds int, extract func(*tasks.Task) (string, error)) (string, error) {
+	if len(results.Tasks) == 0 {
+		return "", fmt.Errorf("wrong task response")
+	}
+	id, err := tasks.WaitTaskAndReturnResult(c, results.Tasks[0], true, waitSeconds, func(task tasks.TaskID) (interface{}, error) {
+		taskInfo, err := tasks.Get(c, string(task)).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
+		}
+		return extract(taskInfo)
+	})
+	if err != nil {
+		return "", err
+	}
+	return id.(string), nil
+}
+
+func waitDeleted(c *gcorecloud.ServiceClient, r tasks.Result, waitSeconds int) error {
+	results, err := r.Extract()
+	if err != nil {
+		return err
+	}
+	if len(results.Tasks) == 0 {
+		return nil
+	}
+	return tasks.WaitForFinishedTask(c, results.Tasks[0], waitSeconds)
+}
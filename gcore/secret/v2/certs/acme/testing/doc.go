@@ -0,0 +1,2 @@
+// acme unit tests
+package testing
@@ -0,0 +1,146 @@
+package testing
+
+import "fmt"
+
+const (
+	LoadBalancerID     = "79943b8c-3c2c-4c71-8b3f-0e3f1e1e1a2b"
+	HTTPListenerID     = "c63341c7-4b4c-4ad7-8b57-e4b2ea2bfd1f"
+	HTTPSListenerID    = "43658ea9-54bd-4807-90b1-925921c9a0d1"
+	PoolID             = "a5b0a5a6-0d8c-4a3b-9c5e-0f1e2d3c4b5a"
+	PolicyID           = "7d2f5f0a-2c3b-4e4d-8f6a-1b2c3d4e5f6a"
+	RuleID             = "3e4f5a6b-7c8d-4e9f-a0b1-c2d3e4f5a6b7"
+	SecretID           = "bfc7824b-31b6-4a28-a0c4-7df137139215"
+	PoolTaskID         = "50f53a35-42ed-40c4-82b2-5a37fb3e00bc"
+	PolicyTaskID       = "8d8b7c0a-6f8c-4c1e-9f2e-5c1f0d3e2b11"
+	RuleTaskID         = "e0b3e5a4-3c4b-4a5e-9d7f-0b2c3d4e5f60"
+	PolicyDeleteTaskID = "f1e2d3c4-b5a6-4978-8a9b-0c1d2e3f4a5b"
+	PoolDeleteTaskID   = "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"
+)
+
+var PoolCreateRequest = fmt.Sprintf(`
+{
+  "name": "acme-http01",
+  "protocol": "HTTP",
+  "lb_algorithm": "ROUND_ROBIN",
+  "loadbalancer_id": "%s",
+  "members": [
+    {
+      "address": "10.0.0.5",
+      "protocol_port": 8080
+    }
+  ]
+}
+`, LoadBalancerID)
+
+var PolicyCreateRequest = fmt.Sprintf(`
+{
+  "name": "acme-http01",
+  "listener_id": "%s",
+  "action": "REDIRECT_TO_POOL",
+  "position": 1,
+  "redirect_pool_id": "%s"
+}
+`, HTTPListenerID, PoolID)
+
+var PolicyListResponse = fmt.Sprintf(`
+{
+  "count": 2,
+  "results": [
+    {
+      "id": "0c4b5a6d-7e8f-4a1b-9c2d-3e4f5a6b7c8d",
+      "name": "acme-http01",
+      "listener_id": "%s",
+      "action": "REDIRECT_TO_URL",
+      "position": 2
+    },
+    {
+      "id": "%s",
+      "name": "acme-http01",
+      "listener_id": "%s",
+      "action": "REDIRECT_TO_POOL",
+      "position": 1,
+      "redirect_pool_id": "%s"
+    }
+  ]
+}
+`, HTTPSListenerID, PolicyID, HTTPListenerID, PoolID)
+
+const RuleCreateRequest = `
+{
+  "compare_type": "STARTS_WITH",
+  "invert": false,
+  "type": "PATH",
+  "value": "/.well-known/acme-challenge/"
+}
+`
+
+var HTTPSListenerResponse = fmt.Sprintf(`
+{
+  "id": "%s",
+  "name": "https",
+  "protocol": "TERMINATED_HTTPS",
+  "protocol_port": 443,
+  "operating_status": "ONLINE",
+  "provisioning_status": "ACTIVE",
+  "secret_id": "%s"
+}
+`, HTTPSListenerID, SecretID)
+
+// SecretResponse renders a secret expiring at the given time.
+func SecretResponse(expiration string) string {
+	return fmt.Sprintf(`
+{
+  "id": "%s",
+  "name": "www-old",
+  "status": "ACTIVE",
+  "algorithm": "aes",
+  "bit_length": 256,
+  "content_types": {"default": "text/plain"},
+  "secret_type": "certificate",
+  "created": "2024-01-01T00:00:00+00:00",
+  "expiration": "%s"
+}
+`, SecretID, expiration)
+}
+
+// TaskResponse renders a response with a single task.
+func TaskResponse(taskID string) string {
+	return fmt.Sprintf(`{"tasks": ["%s"]}`, taskID)
+}
+
+// FinishedTaskResponse renders a finished task with the given created resources.
+func FinishedTaskResponse(taskID, createdResources string) string {
+	return fmt.Sprintf(`
+{
+  "id": "%s",
+  "state": "FINISHED",
+  "task_type": "task",
+  "project_id": 1,
+  "created_on": "2019-06-25T08:42:42",
+  "created_resources": %s
+}
+`, taskID, createdResources)
+}
+
+// ErrorTaskResponse renders a failed task with the given created resources.
+func ErrorTaskResponse(taskID, createdResources string) string {
+	return fmt.Sprintf(`
+{
+  "id": "%s",
+  "state": "ERROR",
+  "error": "provisioning failed",
+  "task_type": "task",
+  "project_id": 1,
+  "created_on": "2019-06-25T08:42:42",
+  "created_resources": %s
+}
+`, taskID, createdResources)
+}
+
+var CreatedResources = map[string]string{
+	PoolTaskID:         fmt.Sprintf(`{"pools": ["%s"]}`, PoolID),
+	PolicyTaskID:       fmt.Sprintf(`{"l7polices": ["%s"]}`, PolicyID),
+	RuleTaskID:         fmt.Sprintf(`{"l7rules": ["%s"]}`, RuleID),
+	PolicyDeleteTaskID: "null",
+	PoolDeleteTaskID:   "null",
+}
@@ -0,0 +1,72 @@
+package testing
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/secret/v2/certs"
+	"github.com/G-Core/gcorelabscloud-go/gcore/secret/v2/certs/acme"
+	"github.com/stretchr/testify/require"
+)
+
+func newAccountKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func getenv(name, defaultValue string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// TestIssuePebble issues a certificate from a local pebble server. It runs only when PEBBLE_DIRECTORY_URL is set, e.g.
+//
+//	pebble -config test/config/pebble-config.json &
+//	PEBBLE_DIRECTORY_URL=https://localhost:14000/dir go test ./gcore/secret/v2/certs/acme/testing/ -run Pebble
+//
+// The responder listens on PEBBLE_HTTP_ADDRESS (default :5002, the pebble httpPort)
+// and PEBBLE_DOMAIN (default localhost) must resolve to it for the pebble validation authority.
+func TestIssuePebble(t *testing.T) {
+	directoryURL := os.Getenv("PEBBLE_DIRECTORY_URL")
+	if directoryURL == "" {
+		t.Skip("PEBBLE_DIRECTORY_URL is not set")
+	}
+	domain := getenv("PEBBLE_DOMAIN", "localhost")
+
+	responder := acme.NewResponder()
+	listener, err := net.Listen("tcp", getenv("PEBBLE_HTTP_ADDRESS", ":5002"))
+	require.NoError(t, err)
+	server := &http.Server{Handler: responder, ReadHeaderTimeout: 10 * time.Second}
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+
+	issuer, err := acme.NewIssuer(acme.IssuerOpts{
+		DirectoryURL: directoryURL,
+		AccountKey:   newAccountKey(t),
+		Contact:      []string{"mailto:admin@example.com"},
+		// pebble serves its API with a certificate signed by a throwaway CA
+		HTTPClient: &http.Client{Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, // nolint:gosec
+		}},
+		Solver: responder,
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	bundle, err := issuer.Issue(ctx, []string{domain})
+	require.NoError(t, err)
+	require.NoError(t, bundle.Validate(certs.ValidateOpts{DNSNames: []string{domain}}))
+	require.NotEmpty(t, bundle.ChainPEM)
+}
@@ -0,0 +1,297 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/secret/v2/certs"
+	"github.com/G-Core/gcorelabscloud-go/gcore/secret/v2/certs/acme"
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func respond(w http.ResponseWriter, body string) {
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, err := fmt.Fprint(w, body)
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+func handleTasks() {
+	for _, version := range []string{"v1", "v2"} {
+		th.Mux.HandleFunc(fmt.Sprintf("/%s/tasks/", version), func(w http.ResponseWriter, r *http.Request) {
+			id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			respond(w, FinishedTaskResponse(id, CreatedResources[id]))
+		})
+	}
+}
+
+func TestResponder(t *testing.T) {
+	responder := acme.NewResponder()
+	server := httptest.NewServer(responder)
+	defer server.Close()
+
+	get := func(path string) (int, string) {
+		resp, err := http.Get(server.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(body)
+	}
+
+	require.NoError(t, responder.Present(context.Background(), "token1", "token1.thumbprint"))
+	code, body := get(acme.ChallengePathPrefix + "token1")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "token1.thumbprint", body)
+
+	code, _ = get(acme.ChallengePathPrefix + "token2")
+	require.Equal(t, http.StatusNotFound, code)
+	code, _ = get("/token1")
+	require.Equal(t, http.StatusNotFound, code)
+
+	require.NoError(t, responder.CleanUp(context.Background(), "token1"))
+	code, _ = get(acme.ChallengePathPrefix + "token1")
+	require.Equal(t, http.StatusNotFound, code)
+}
+
+func TestLoadBalancerSolver(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var calls []string
+	handleTasks()
+	th.Mux.HandleFunc(fmt.Sprintf("/v2/lbpools/%d/%d", fake.ProjectID, fake.RegionID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestJSONRequest(t, r, PoolCreateRequest)
+		calls = append(calls, "POST pool")
+		respond(w, TaskResponse(PoolTaskID))
+	})
+	th.Mux.HandleFunc(fmt.Sprintf("/v2/lbpools/%d/%d/%s", fake.ProjectID, fake.RegionID, PoolID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		calls = append(calls, "DELETE pool")
+		respond(w, TaskResponse(PoolDeleteTaskID))
+	})
+	th.Mux.HandleFunc(fmt.Sprintf("/v1/l7policies/%d/%d", fake.ProjectID, fake.RegionID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestJSONRequest(t, r, PolicyCreateRequest)
+		calls = append(calls, "POST policy")
+		respond(w, TaskResponse(PolicyTaskID))
+	})
+	th.Mux.HandleFunc(fmt.Sprintf("/v1/l7policies/%d/%d/%s", fake.ProjectID, fake.RegionID, PolicyID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		calls = append(calls, "DELETE policy")
+		respond(w, TaskResponse(PolicyDeleteTaskID))
+	})
+	th.Mux.HandleFunc(fmt.Sprintf("/v1/l7policies/%d/%d/%s/rules", fake.ProjectID, fake.RegionID, PolicyID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestJSONRequest(t, r, RuleCreateRequest)
+		calls = append(calls, "POST rule")
+		respond(w, TaskResponse(RuleTaskID))
+	})
+
+	responder := acme.NewResponder()
+	solver, err := acme.NewLoadBalancerSolver(acme.RouteClients{
+		Pools:      fake.ServiceTokenClient("lbpools", "v2"),
+		L7Policies: fake.ServiceTokenClient("l7policies", "v1"),
+	}, responder, acme.RouteOpts{
+		LoadBalancerID: LoadBalancerID,
+		ListenerID:     HTTPListenerID,
+		Address:        net.ParseIP("10.0.0.5"),
+		ProtocolPort:   8080,
+		WaitSeconds:    5,
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, solver.Present(ctx, "token1", "token1.thumbprint"))
+	require.NoError(t, solver.Present(ctx, "token2", "token2.thumbprint"))
+	require.Equal(t, []string{"POST pool", "POST policy", "POST rule"}, calls)
+	require.Equal(t, PoolID, solver.PoolID())
+	require.Equal(t, PolicyID, solver.PolicyID())
+
+	require.NoError(t, solver.CleanUp(ctx, "token1"))
+	require.Len(t, calls, 3)
+	require.NoError(t, solver.CleanUp(ctx, "token2"))
+	require.Equal(t, []string{"POST pool", "POST policy", "POST rule", "DELETE policy", "DELETE pool"}, calls)
+	require.Empty(t, solver.PoolID())
+	require.Empty(t, solver.PolicyID())
+}
+
+// renewWithSecret runs Renew for the HTTPS listener whose current secret has the given expiration.
+func renewWithSecret(t *testing.T, expiration string) *acme.RenewResult {
+	th.Mux.HandleFunc(fmt.Sprintf("/v2/lblisteners/%d/%d/%s", fake.ProjectID, fake.RegionID, HTTPSListenerID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		respond(w, HTTPSListenerResponse)
+	})
+	th.Mux.HandleFunc(fmt.Sprintf("/v1/secrets/%d/%d/%s", fake.ProjectID, fake.RegionID, SecretID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		respond(w, SecretResponse(expiration))
+	})
+
+	issuer, err := acme.NewIssuer(acme.IssuerOpts{
+		DirectoryURL: "https://localhost:14000/dir",
+		AccountKey:   newAccountKey(t),
+		Solver:       acme.NewResponder(),
+	})
+	require.NoError(t, err)
+
+	result, err := issuer.Renew(context.Background(), certs.Clients{
+		Listeners: fake.ServiceTokenClient("lblisteners", "v2"),
+		Secrets:   fake.ServiceTokenClient("secrets", "v1"),
+		SecretsV2: fake.ServiceTokenClient("secrets", "v2"),
+	}, acme.RenewOpts{
+		ListenerID: HTTPSListenerID,
+		Domains:    []string{"www.example.com"},
+	})
+	require.NoError(t, err)
+	return result
+}
+
+func TestLoadBalancerSolverPoolTaskFailure(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var calls []string
+	th.Mux.HandleFunc("/v2/tasks/", func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		if id == PoolTaskID {
+			respond(w, ErrorTaskResponse(id, CreatedResources[id]))
+			return
+		}
+		respond(w, FinishedTaskResponse(id, CreatedResources[id]))
+	})
+	th.Mux.HandleFunc(fmt.Sprintf("/v2/lbpools/%d/%d", fake.ProjectID, fake.RegionID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		calls = append(calls, "POST pool")
+		respond(w, TaskResponse(PoolTaskID))
+	})
+	th.Mux.HandleFunc(fmt.Sprintf("/v2/lbpools/%d/%d/%s", fake.ProjectID, fake.RegionID, PoolID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		calls = append(calls, "DELETE pool")
+		respond(w, TaskResponse(PoolDeleteTaskID))
+	})
+
+	solver, err := acme.NewLoadBalancerSolver(acme.RouteClients{
+		Pools:      fake.ServiceTokenClient("lbpools", "v2"),
+		L7Policies: fake.ServiceTokenClient("l7policies", "v1"),
+	}, acme.NewResponder(), acme.RouteOpts{
+		LoadBalancerID: LoadBalancerID,
+		ListenerID:     HTTPListenerID,
+		Address:        net.ParseIP("10.0.0.5"),
+		ProtocolPort:   8080,
+		WaitSeconds:    5,
+	})
+	require.NoError(t, err)
+
+	err = solver.Present(context.Background(), "token1", "token1.thumbprint")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cannot create pool")
+	require.Equal(t, []string{"POST pool", "DELETE pool"}, calls)
+	require.Empty(t, solver.PoolID())
+}
+
+func TestLoadBalancerSolverPolicyTaskFailure(t *testing.T) {
+	for name, createdResources := range map[string]string{
+		"created resources": CreatedResources[PolicyTaskID],
+		"listed":            "null",
+	} {
+		t.Run(name, func(t *testing.T) {
+			th.SetupHTTP()
+			defer th.TeardownHTTP()
+
+			var calls []string
+			for _, version := range []string{"v1", "v2"} {
+				th.Mux.HandleFunc(fmt.Sprintf("/%s/tasks/", version), func(w http.ResponseWriter, r *http.Request) {
+					id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+					if id == PolicyTaskID {
+						respond(w, ErrorTaskResponse(id, createdResources))
+						return
+					}
+					respond(w, FinishedTaskResponse(id, CreatedResources[id]))
+				})
+			}
+			th.Mux.HandleFunc(fmt.Sprintf("/v2/lbpools/%d/%d", fake.ProjectID, fake.RegionID), func(w http.ResponseWriter, r *http.Request) {
+				th.TestMethod(t, r, "POST")
+				calls = append(calls, "POST pool")
+				respond(w, TaskResponse(PoolTaskID))
+			})
+			th.Mux.HandleFunc(fmt.Sprintf("/v2/lbpools/%d/%d/%s", fake.ProjectID, fake.RegionID, PoolID), func(w http.ResponseWriter, r *http.Request) {
+				th.TestMethod(t, r, "DELETE")
+				calls = append(calls, "DELETE pool")
+				respond(w, TaskResponse(PoolDeleteTaskID))
+			})
+			th.Mux.HandleFunc(fmt.Sprintf("/v1/l7policies/%d/%d", fake.ProjectID, fake.RegionID), func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, r.Method+" policy")
+				if r.Method == http.MethodGet {
+					respond(w, PolicyListResponse)
+					return
+				}
+				th.TestMethod(t, r, "POST")
+				respond(w, TaskResponse(PolicyTaskID))
+			})
+			th.Mux.HandleFunc(fmt.Sprintf("/v1/l7policies/%d/%d/%s", fake.ProjectID, fake.RegionID, PolicyID), func(w http.ResponseWriter, r *http.Request) {
+				th.TestMethod(t, r, "DELETE")
+				calls = append(calls, "DELETE policy")
+				respond(w, TaskResponse(PolicyDeleteTaskID))
+			})
+
+			solver, err := acme.NewLoadBalancerSolver(acme.RouteClients{
+				Pools:      fake.ServiceTokenClient("lbpools", "v2"),
+				L7Policies: fake.ServiceTokenClient("l7policies", "v1"),
+			}, acme.NewResponder(), acme.RouteOpts{
+				LoadBalancerID: LoadBalancerID,
+				ListenerID:     HTTPListenerID,
+				Address:        net.ParseIP("10.0.0.5"),
+				ProtocolPort:   8080,
+				WaitSeconds:    5,
+			})
+			require.NoError(t, err)
+
+			err = solver.Present(context.Background(), "token1", "token1.thumbprint")
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "cannot create l7 policy")
+			expected := []string{"POST pool", "POST policy", "DELETE policy", "DELETE pool"}
+			if createdResources == "null" {
+				expected = []string{"POST pool", "POST policy", "GET policy", "DELETE policy", "DELETE pool"}
+			}
+			require.Equal(t, expected, calls)
+			require.Empty(t, solver.PolicyID())
+			require.Empty(t, solver.PoolID())
+		})
+	}
+}
+
+func TestRenewNotDue(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	expiration := time.Now().Add(60 * 24 * time.Hour).UTC().Truncate(time.Second)
+	result := renewWithSecret(t, expiration.Format("2006-01-02T15:04:05+00:00"))
+	require.False(t, result.Renewed)
+	require.Empty(t, result.Skipped)
+	require.Equal(t, SecretID, result.SecretID)
+	require.True(t, expiration.Equal(result.NotAfter))
+}
+
+func TestRenewUnknownExpiration(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	result := renewWithSecret(t, "")
+	require.False(t, result.Renewed)
+	require.Equal(t, SecretID, result.SecretID)
+	require.Contains(t, result.Skipped, "expiration")
+	require.True(t, result.NotAfter.IsZero())
+}
@@ -0,0 +1,207 @@
+package certs
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/secret/v2/secrets"
+)
+
+// Bundle is a parsed certificate with its intermediate chain and private key.
+type Bundle struct {
+	Certificate *x509.Certificate
+	Chain       []*x509.Certificate
+	PrivateKey  crypto.PrivateKey
+
+	CertificatePEM string
+	ChainPEM       string
+	PrivateKeyPEM  string
+}
+
+// ParseBundle parses a PEM encoded certificate, an optional intermediate chain and a private key.
+// Certificates following the first one in certificatePEM are treated as the beginning of the chain.
+// The private key can be in PKCS#1, PKCS#8 or SEC 1 form.
+func ParseBundle(certificatePEM, chainPEM, privateKeyPEM string) (*Bundle, error) {
+	certificates, err := parseCertificates([]byte(certificatePEM))
+	if err != nil {
+		return nil, fmt.Errorf("certificate: %w", err)
+	}
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("certificate: no certificate found")
+	}
+	chain := certificates[1:]
+	if strings.TrimSpace(chainPEM) != "" {
+		extra, err := parseCertificates([]byte(chainPEM))
+		if err != nil {
+			return nil, fmt.Errorf("certificate chain: %w", err)
+		}
+		chain = append(chain, extra...)
+	}
+	key, err := parsePrivateKey([]byte(privateKeyPEM))
+	if err != nil {
+		return nil, fmt.Errorf("private key: %w", err)
+	}
+	var chainBuf bytes.Buffer
+	for _, certificate := range chain {
+		chainBuf.Write(encodeCertificate(certificate))
+	}
+	return &Bundle{
+		Certificate:    certificates[0],
+		Chain:          chain,
+		PrivateKey:     key,
+		CertificatePEM: string(encodeCertificate(certificates[0])),
+		ChainPEM:       chainBuf.String(),
+		PrivateKeyPEM:  privateKeyPEM,
+	}, nil
+}
+
+func encodeCertificate(certificate *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw})
+}
+
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var result []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, certificate)
+	}
+	if len(bytes.TrimSpace(data)) != 0 {
+		return nil, fmt.Errorf("invalid PEM data")
+	}
+	return result, nil
+}
+
+func parsePrivateKey(data []byte) (crypto.PrivateKey, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("no private key found")
+		}
+		switch block.Type {
+		case "RSA PRIVATE KEY":
+			return x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "EC PRIVATE KEY":
+			return x509.ParseECPrivateKey(block.Bytes)
+		case "PRIVATE KEY":
+			return x509.ParsePKCS8PrivateKey(block.Bytes)
+		}
+	}
+}
+
+// ValidateOpts represents options used to validate a Bundle.
+type ValidateOpts struct {
+	// Now is the time the validity is checked at. Defaults to the current time.
+	Now time.Time
+	// MinValidity is the minimum remaining validity of the certificate.
+	MinValidity time.Duration
+	// DNSNames must all be covered by the certificate SANs.
+	DNSNames []string
+}
+
+// Validate checks that the private key matches the certificate, the chain is ordered from the issuer of the
+// certificate towards the root, every certificate is currently valid and the certificate covers the DNS names.
+// All problems found are returned joined in a single error.
+func (b *Bundle) Validate(opts ValidateOpts) error {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	var errs []error
+
+	if err := keyMatches(b.Certificate, b.PrivateKey); err != nil {
+		errs = append(errs, err)
+	}
+
+	issued := b.Certificate
+	for i, issuer := range b.Chain {
+		if err := issued.CheckSignatureFrom(issuer); err != nil {
+			errs = append(errs, fmt.Errorf("chain certificate %d (%s) is not the issuer of %s: %w",
+				i, issuer.Subject.CommonName, issued.Subject.CommonName, err))
+		}
+		issued = issuer
+	}
+
+	for _, certificate := range append([]*x509.Certificate{b.Certificate}, b.Chain...) {
+		if now.Before(certificate.NotBefore) {
+			errs = append(errs, fmt.Errorf("certificate %s is not valid before %s",
+				certificate.Subject.CommonName, certificate.NotBefore.Format(time.RFC3339)))
+		}
+		if now.After(certificate.NotAfter) {
+			errs = append(errs, fmt.Errorf("certificate %s expired at %s",
+				certificate.Subject.CommonName, certificate.NotAfter.Format(time.RFC3339)))
+		}
+	}
+	if opts.MinValidity > 0 && now.Before(b.Certificate.NotAfter) && b.Certificate.NotAfter.Sub(now) < opts.MinValidity {
+		errs = append(errs, fmt.Errorf("certificate %s expires at %s, less than %s from now",
+			b.Certificate.Subject.CommonName, b.Certificate.NotAfter.Format(time.RFC3339), opts.MinValidity))
+	}
+
+	for _, name := range opts.DNSNames {
+		if err := b.Certificate.VerifyHostname(name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func keyMatches(certificate *x509.Certificate, key crypto.PrivateKey) error {
+	var public crypto.PublicKey
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		public = k.Public()
+	case *ecdsa.PrivateKey:
+		public = k.Public()
+	case ed25519.PrivateKey:
+		public = k.Public()
+	default:
+		return fmt.Errorf("unsupported private key type %T", key)
+	}
+	expected, err := x509.MarshalPKIXPublicKey(certificate.PublicKey)
+	if err != nil {
+		return err
+	}
+	actual, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(expected, actual) {
+		return fmt.Errorf("private key does not match certificate %s", certificate.Subject.CommonName)
+	}
+	return nil
+}
+
+// ToCreateOpts builds options to upload the bundle as a secret expiring together with the certificate.
+func (b *Bundle) ToCreateOpts(name string) secrets.CreateOpts {
+	expiration := b.Certificate.NotAfter.UTC()
+	return secrets.CreateOpts{
+		Name:       name,
+		Expiration: &expiration,
+		Payload: secrets.PayloadOpts{
+			Certificate:      b.CertificatePEM,
+			CertificateChain: b.ChainPEM,
+			PrivateKey:       b.PrivateKeyPEM,
+		},
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Package certs validates TLS certificate bundles and rotates loadbalancer listener certificates stored as secrets
+
+Example to validate a certificate bundle
+
+	bundle, err := certs.ParseBundle(certificatePEM, chainPEM, privateKeyPEM)
+	if err != nil {
+		panic(err)
+	}
+
+	err = bundle.Validate(certs.ValidateOpts{
+		MinValidity: 7 * 24 * time.Hour,
+		DNSNames:    []string{"www.example.com"},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+Example to list secrets expiring within 30 days
+
+	expiring, err := certs.ListExpiring(secretClient, 30*24*time.Hour)
+	if err != nil {
+		panic(err)
+	}
+
+Example to rotate a listener certificate
+
+	result, err := certs.RotateListener(certs.Clients{
+		Listeners: listenerClient,
+		Secrets:   secretClient,
+		SecretsV2: secretClientV2,
+	}, certs.RotateOpts{
+		ListenerID: "43658ea9-54bd-4807-90b1-925921c9a0d1",
+		Name:       "www-2024",
+		Bundle:     bundle,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+Example to attach a certificate to a listener without one
+
+	result, err := certs.AttachListener(certs.Clients{
+		Listeners: listenerClient,
+		Secrets:   secretClient,
+		SecretsV2: secretClientV2,
+	}, certs.AttachOpts{
+		ListenerID: "43658ea9-54bd-4807-90b1-925921c9a0d1",
+		Name:       "www-2024",
+		Bundle:     bundle,
+	})
+	if err != nil {
+		panic(err)
+	}
+*/
+package certs
@@ -0,0 +1,47 @@
+package certs
+
+import (
+	"sort"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	secrets1 "github.com/G-Core/gcorelabscloud-go/gcore/secret/v1/secrets"
+)
+
+// ExpiringSecret is a secret expiring within the requested period.
+type ExpiringSecret struct {
+	secrets1.Secret
+	ExpiresIn time.Duration `json:"expires_in"`
+	Expired   bool          `json:"expired"`
+}
+
+// ListExpiring returns secrets expiring within the given period, already expired ones included,
+// sorted by expiration. Secrets without an expiration are skipped. The client must be a secrets v1 client.
+func ListExpiring(c *gcorecloud.ServiceClient, within time.Duration) ([]ExpiringSecret, error) {
+	all, err := secrets1.ListAll(c)
+	if err != nil {
+		return nil, err
+	}
+	return FilterExpiring(all, time.Now(), within), nil
+}
+
+// FilterExpiring returns secrets expiring before now plus the given period, sorted by expiration.
+func FilterExpiring(all []secrets1.Secret, now time.Time, within time.Duration) []ExpiringSecret {
+	deadline := now.Add(within)
+	result := []ExpiringSecret{}
+	for _, secret := range all {
+		expiration := secret.Expiration.Time
+		if expiration.IsZero() || expiration.After(deadline) {
+			continue
+		}
+		result = append(result, ExpiringSecret{
+			Secret:    secret,
+			ExpiresIn: expiration.Sub(now),
+			Expired:   !expiration.After(now),
+		})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Expiration.Before(result[j].Expiration.Time)
+	})
+	return result
+}
@@ -0,0 +1,220 @@
+package certs
+
+import (
+	"fmt"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v2/listeners"
+	secrets1 "github.com/G-Core/gcorelabscloud-go/gcore/secret/v1/secrets"
+	"github.com/G-Core/gcorelabscloud-go/gcore/secret/v2/secrets"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+)
+
+// DefaultWaitSeconds is how long rotation waits for every task it starts.
+const DefaultWaitSeconds = 300
+
+// Clients groups service clients used for rotation.
+type Clients struct {
+	// Listeners is a loadbalancer listeners v2 client.
+	Listeners *gcorecloud.ServiceClient
+	// Secrets is a secrets v1 client used to delete the old secret.
+	Secrets *gcorecloud.ServiceClient
+	// SecretsV2 is a secrets v2 client used to upload the new secret.
+	SecretsV2 *gcorecloud.ServiceClient
+}
+
+// RotateOpts represents options used to rotate a listener certificate.
+type RotateOpts struct {
+	ListenerID string `validate:"required"`
+	// Name is the name of the new secret.
+	Name   string  `validate:"required"`
+	Bundle *Bundle `validate:"required"`
+	// SecretID is the secret being replaced. Defaults to the listener SecretID.
+	// It is replaced both as the listener default certificate and in the SNI list.
+	SecretID string
+	// KeepOldSecret disables deleting the replaced secret after the listener is updated.
+	KeepOldSecret bool
+	// Validate is applied to the bundle before anything is uploaded.
+	Validate    ValidateOpts
+	WaitSeconds int `validate:"omitempty,gt=0"`
+}
+
+// RotateResult describes a finished rotation.
+type RotateResult struct {
+	ListenerID   string   `json:"listener_id"`
+	OldSecretID  string   `json:"old_secret_id"`
+	NewSecretID  string   `json:"new_secret_id"`
+	SecretID     string   `json:"secret_id"`
+	SNISecretIDs []string `json:"sni_secret_id"`
+	OldDeleted   bool     `json:"old_deleted"`
+}
+
+// RotateListener replaces a certificate of a listener. The bundle is validated and uploaded as a new secret,
+// then the listener default certificate and SNI list are repointed in a single update.
+// The old secret is deleted once the listener update task is finished. If the update fails, the new secret is deleted.
+func RotateListener(clients Clients, opts RotateOpts) (*RotateResult, error) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	waitSeconds := opts.WaitSeconds
+	if waitSeconds == 0 {
+		waitSeconds = DefaultWaitSeconds
+	}
+	if err := opts.Bundle.Validate(opts.Validate); err != nil {
+		return nil, fmt.Errorf("invalid certificate bundle: %w", err)
+	}
+
+	listener, err := listeners.Get(clients.Listeners, opts.ListenerID, nil).Extract()
+	if err != nil {
+		return nil, err
+	}
+	oldSecretID := opts.SecretID
+	if oldSecretID == "" && listener.SecretID != nil {
+		oldSecretID = *listener.SecretID
+	}
+	if oldSecretID == "" {
+		return nil, fmt.Errorf("listener %s has no secret to rotate", opts.ListenerID)
+	}
+
+	newSecretID, err := uploadSecret(clients.SecretsV2, opts.Bundle.ToCreateOpts(opts.Name), waitSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("cannot upload secret: %w", err)
+	}
+
+	result := &RotateResult{
+		ListenerID:  opts.ListenerID,
+		OldSecretID: oldSecretID,
+		NewSecretID: newSecretID,
+	}
+	updateOpts := listeners.UpdateOpts{}
+	replaced := false
+	if listener.SecretID != nil && *listener.SecretID == oldSecretID {
+		updateOpts.SecretID = newSecretID
+		result.SecretID = newSecretID
+		replaced = true
+	} else if listener.SecretID != nil {
+		result.SecretID = *listener.SecretID
+	}
+	for _, id := range listener.SNISecretID {
+		if id == oldSecretID {
+			id = newSecretID
+			replaced = true
+		}
+		result.SNISecretIDs = append(result.SNISecretIDs, id)
+	}
+	if !replaced {
+		_ = deleteSecret(clients.Secrets, newSecretID, waitSeconds)
+		return nil, fmt.Errorf("secret %s is not used by listener %s", oldSecretID, opts.ListenerID)
+	}
+	updateOpts.SNISecretID = result.SNISecretIDs
+
+	if err := updateListener(clients.Listeners, opts.ListenerID, updateOpts, waitSeconds); err != nil {
+		if deleteErr := deleteSecret(clients.Secrets, newSecretID, waitSeconds); deleteErr != nil {
+			return nil, fmt.Errorf("cannot update listener: %w, cannot delete new secret %s: %s", err, newSecretID, deleteErr)
+		}
+		return nil, fmt.Errorf("cannot update listener: %w", err)
+	}
+
+	if !opts.KeepOldSecret {
+		if err := deleteSecret(clients.Secrets, oldSecretID, waitSeconds); err != nil {
+			return result, fmt.Errorf("listener rotated, cannot delete old secret %s: %w", oldSecretID, err)
+		}
+		result.OldDeleted = true
+	}
+	return result, nil
+}
+
+func uploadSecret(c *gcorecloud.ServiceClient, opts secrets.CreateOpts, waitSeconds int) (string, error) {
+	results, err := secrets.Create(c, opts).Extract()
+	if err != nil {
+		return "", err
+	}
+	if len(results.Tasks) == 0 {
+		return "", fmt.Errorf("wrong task response")
+	}
+	secretID, err := tasks.WaitTaskAndReturnResult(c, results.Tasks[0], true, waitSeconds, func(task tasks.TaskID) (interface{}, error) {
+		taskInfo, err := tasks.Get(c, string(task)).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
+		}
+		return secrets1.ExtractSecretIDFromTask(taskInfo)
+	})
+	if err != nil {
+		return "", err
+	}
+	return secretID.(string), nil
+}
+
+func updateListener(c *gcorecloud.ServiceClient, listenerID string, opts listeners.UpdateOpts, waitSeconds int) error {
+	results, err := listeners.Update(c, listenerID, opts, nil).Extract()
+	if err != nil {
+		return err
+	}
+	if len(results.Tasks) == 0 {
+		return fmt.Errorf("wrong task response")
+	}
+	return tasks.WaitForFinishedTask(c, results.Tasks[0], waitSeconds)
+}
+
+func deleteSecret(c *gcorecloud.ServiceClient, secretID string, waitSeconds int) error {
+	results, err := secrets1.Delete(c, secretID).Extract()
+	if err != nil {
+		return err
+	}
+	if len(results.Tasks) == 0 {
+		return nil
+	}
+	return tasks.WaitForFinishedTask(c, results.Tasks[0], waitSeconds)
+}
+
+// AttachOpts represents options used to attach a certificate to a listener without one.
+type AttachOpts struct {
+	ListenerID string `validate:"required"`
+	// Name is the name of the new secret.
+	Name   string  `validate:"required"`
+	Bundle *Bundle `validate:"required"`
+	// Validate is applied to the bundle before anything is uploaded.
+	Validate    ValidateOpts
+	WaitSeconds int `validate:"omitempty,gt=0"`
+}
+
+// AttachListener uploads the bundle as a new secret and sets it as the listener default certificate.
+// Listeners already having a default certificate should use RotateListener instead. If the update fails, the new secret is deleted.
+func AttachListener(clients Clients, opts AttachOpts) (*RotateResult, error) {
+	if err := gcorecloud.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+	waitSeconds := opts.WaitSeconds
+	if waitSeconds == 0 {
+		waitSeconds = DefaultWaitSeconds
+	}
+	if err := opts.Bundle.Validate(opts.Validate); err != nil {
+		return nil, fmt.Errorf("invalid certificate bundle: %w", err)
+	}
+
+	listener, err := listeners.Get(clients.Listeners, opts.ListenerID, nil).Extract()
+	if err != nil {
+		return nil, err
+	}
+	if listener.SecretID != nil && *listener.SecretID != "" {
+		return nil, fmt.Errorf("listener %s already has secret %s", opts.ListenerID, *listener.SecretID)
+	}
+
+	newSecretID, err := uploadSecret(clients.SecretsV2, opts.Bundle.ToCreateOpts(opts.Name), waitSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("cannot upload secret: %w", err)
+	}
+	updateOpts := listeners.UpdateOpts{SecretID: newSecretID}
+	if err := updateListener(clients.Listeners, opts.ListenerID, updateOpts, waitSeconds); err != nil {
+		if deleteErr := deleteSecret(clients.Secrets, newSecretID, waitSeconds); deleteErr != nil {
+			return nil, fmt.Errorf("cannot update listener: %w, cannot delete new secret %s: %s", err, newSecretID, deleteErr)
+		}
+		return nil, fmt.Errorf("cannot update listener: %w", err)
+	}
+	return &RotateResult{
+		ListenerID:   opts.ListenerID,
+		NewSecretID:  newSecretID,
+		SecretID:     newSecretID,
+		SNISecretIDs: listener.SNISecretID,
+	}, nil
+}
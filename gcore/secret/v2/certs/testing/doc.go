@@ -0,0 +1,2 @@
+// certs unit tests
+package testing
@@ -0,0 +1,175 @@
+package testing
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const (
+	ListenerID   = "43658ea9-54bd-4807-90b1-925921c9a0d1"
+	OldSecretID  = "bfc7824b-31b6-4a28-a0c4-7df137139215"
+	SNISecretID  = "5ad9bd04-7e4e-4b3a-9c5f-8a2f8ef81e4c"
+	NewSecretID  = "f6b8f2c3-21a9-4c36-b6d3-0fa1d7b3a123"
+	CreateTaskID = "50f53a35-42ed-40c4-82b2-5a37fb3e00bc"
+	UpdateTaskID = "8d8b7c0a-6f8c-4c1e-9f2e-5c1f0d3e2b11"
+	DeleteTaskID = "e0b3e5a4-3c4b-4a5e-9d7f-0b2c3d4e5f60"
+)
+
+// TestPKI is a generated root, intermediate and leaf certificate with their keys.
+type TestPKI struct {
+	RootPEM         string
+	IntermediatePEM string
+	LeafPEM         string
+	LeafKeyPEM      string
+	OtherKeyPEM     string
+	NotAfter        time.Time
+}
+
+func newKey() *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+func encodeKey(key *ecdsa.PrivateKey) string {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		panic(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func issue(template, parent *x509.Certificate, key *ecdsa.PrivateKey, parentKey *ecdsa.PrivateKey) (*x509.Certificate, string) {
+	if parent == nil {
+		parent = template
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		panic(err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		panic(err)
+	}
+	return certificate, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+// NewTestPKI generates a certificate chain for www.example.com valid for the given period.
+func NewTestPKI(now time.Time, validity time.Duration) *TestPKI {
+	rootKey, intermediateKey, leafKey := newKey(), newKey(), newKey()
+	notBefore := now.Add(-time.Hour)
+	notAfter := now.Add(validity)
+
+	root, rootPEM := issue(&x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Root"},
+		NotBefore:             notBefore,
+		NotAfter:              now.Add(10 * 365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, rootKey, rootKey)
+
+	intermediate, intermediatePEM := issue(&x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "Test Intermediate"},
+		NotBefore:             notBefore,
+		NotAfter:              now.Add(5 * 365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, root, intermediateKey, rootKey)
+
+	_, leafPEM := issue(&x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "www.example.com"},
+		DNSNames:     []string{"www.example.com", "example.com"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, intermediate, leafKey, intermediateKey)
+
+	return &TestPKI{
+		RootPEM:         rootPEM,
+		IntermediatePEM: intermediatePEM,
+		LeafPEM:         leafPEM,
+		LeafKeyPEM:      encodeKey(leafKey),
+		OtherKeyPEM:     encodeKey(newKey()),
+		NotAfter:        notAfter,
+	}
+}
+
+var ListenerResponse = fmt.Sprintf(`
+{
+  "id": "%s",
+  "name": "https",
+  "protocol": "TERMINATED_HTTPS",
+  "protocol_port": 443,
+  "loadbalancer_id": "79943b68-2d1e-4f2f-8bd5-2cbb1d5c84ef",
+  "pool_count": 1,
+  "provisioning_status": "ACTIVE",
+  "operating_status": "ONLINE",
+  "insert_x_forwarded": false,
+  "secret_id": "%s",
+  "sni_secret_id": ["%s", "%s"]
+}
+`, ListenerID, OldSecretID, SNISecretID, OldSecretID)
+
+var ListenerUpdateRequest = fmt.Sprintf(`
+{
+  "secret_id": "%s",
+  "sni_secret_id": ["%s", "%s"]
+}
+`, NewSecretID, SNISecretID, NewSecretID)
+
+// TaskResponse renders a response with a single task.
+func TaskResponse(taskID string) string {
+	return fmt.Sprintf(`{"tasks": ["%s"]}`, taskID)
+}
+
+// FinishedTaskResponse renders a finished task with the given created resources.
+func FinishedTaskResponse(taskID, createdResources string) string {
+	return fmt.Sprintf(`
+{
+  "id": "%s",
+  "state": "FINISHED",
+  "task_type": "task",
+  "project_id": 1,
+  "created_on": "2019-06-25T08:42:42",
+  "created_resources": %s
+}
+`, taskID, createdResources)
+}
+
+var SecretCreatedResources = fmt.Sprintf(`{"secrets": ["%s"]}`, NewSecretID)
+
+var ListenerWithoutSecretResponse = fmt.Sprintf(`
+{
+  "id": "%s",
+  "name": "https",
+  "protocol": "TERMINATED_HTTPS",
+  "protocol_port": 443,
+  "loadbalancer_id": "79943b68-2d1e-4f2f-8bd5-2cbb1d5c84ef",
+  "pool_count": 1,
+  "provisioning_status": "ACTIVE",
+  "operating_status": "ONLINE",
+  "insert_x_forwarded": false,
+  "secret_id": null
+}
+`, ListenerID)
+
+var ListenerAttachRequest = fmt.Sprintf(`
+{
+  "secret_id": "%s"
+}
+`, NewSecretID)
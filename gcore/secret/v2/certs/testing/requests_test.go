@@ -0,0 +1,241 @@
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	secrets1 "github.com/G-Core/gcorelabscloud-go/gcore/secret/v1/secrets"
+	"github.com/G-Core/gcorelabscloud-go/gcore/secret/v2/certs"
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBundle(t *testing.T) {
+	pki := NewTestPKI(time.Now(), 90*24*time.Hour)
+
+	bundle, err := certs.ParseBundle(pki.LeafPEM+pki.IntermediatePEM, pki.RootPEM, pki.LeafKeyPEM)
+	require.NoError(t, err)
+	require.Equal(t, "www.example.com", bundle.Certificate.Subject.CommonName)
+	require.Len(t, bundle.Chain, 2)
+	require.Equal(t, pki.LeafPEM, bundle.CertificatePEM)
+	require.Equal(t, pki.IntermediatePEM+pki.RootPEM, bundle.ChainPEM)
+
+	_, err = certs.ParseBundle("garbage", "", pki.LeafKeyPEM)
+	require.Error(t, err)
+
+	_, err = certs.ParseBundle(pki.LeafPEM, "", pki.LeafPEM)
+	require.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Now()
+	pki := NewTestPKI(now, 90*24*time.Hour)
+
+	bundle, err := certs.ParseBundle(pki.LeafPEM, pki.IntermediatePEM+pki.RootPEM, pki.LeafKeyPEM)
+	require.NoError(t, err)
+	require.NoError(t, bundle.Validate(certs.ValidateOpts{
+		Now:         now,
+		MinValidity: 30 * 24 * time.Hour,
+		DNSNames:    []string{"www.example.com", "example.com"},
+	}))
+
+	err = bundle.Validate(certs.ValidateOpts{Now: now, DNSNames: []string{"api.example.com"}})
+	require.Error(t, err)
+
+	err = bundle.Validate(certs.ValidateOpts{Now: now, MinValidity: 120 * 24 * time.Hour})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "less than")
+
+	err = bundle.Validate(certs.ValidateOpts{Now: pki.NotAfter.Add(time.Hour)})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "expired")
+
+	mismatched, err := certs.ParseBundle(pki.LeafPEM, pki.IntermediatePEM, pki.OtherKeyPEM)
+	require.NoError(t, err)
+	err = mismatched.Validate(certs.ValidateOpts{Now: now})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not match")
+
+	misordered, err := certs.ParseBundle(pki.LeafPEM, pki.RootPEM+pki.IntermediatePEM, pki.LeafKeyPEM)
+	require.NoError(t, err)
+	err = misordered.Validate(certs.ValidateOpts{Now: now})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is not the issuer")
+}
+
+func TestToCreateOpts(t *testing.T) {
+	pki := NewTestPKI(time.Now(), 90*24*time.Hour)
+	bundle, err := certs.ParseBundle(pki.LeafPEM, pki.IntermediatePEM, pki.LeafKeyPEM)
+	require.NoError(t, err)
+
+	opts := bundle.ToCreateOpts("www")
+	require.Equal(t, "www", opts.Name)
+	require.Equal(t, bundle.Certificate.NotAfter.UTC(), *opts.Expiration)
+	require.Equal(t, pki.IntermediatePEM, opts.Payload.CertificateChain)
+	require.Equal(t, pki.LeafKeyPEM, opts.Payload.PrivateKey)
+}
+
+func TestFilterExpiring(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	secret := func(id string, expiration time.Time) secrets1.Secret {
+		return secrets1.Secret{ID: id, Expiration: gcorecloud.JSONRFC3339ZColon{Time: expiration}}
+	}
+	all := []secrets1.Secret{
+		secret("later", now.Add(60*24*time.Hour)),
+		secret("soon", now.Add(10*24*time.Hour)),
+		secret("never", time.Time{}),
+		secret("expired", now.Add(-24*time.Hour)),
+	}
+
+	expiring := certs.FilterExpiring(all, now, 30*24*time.Hour)
+	require.Len(t, expiring, 2)
+	require.Equal(t, "expired", expiring[0].ID)
+	require.True(t, expiring[0].Expired)
+	require.Equal(t, "soon", expiring[1].ID)
+	require.False(t, expiring[1].Expired)
+	require.Equal(t, 10*24*time.Hour, expiring[1].ExpiresIn)
+}
+
+func respond(w http.ResponseWriter, body string) {
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, err := fmt.Fprint(w, body)
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+func handleTasks() {
+	resources := map[string]string{
+		CreateTaskID: SecretCreatedResources,
+		UpdateTaskID: "null",
+		DeleteTaskID: "null",
+	}
+	for _, version := range []string{"v1", "v2"} {
+		th.Mux.HandleFunc(fmt.Sprintf("/%s/tasks/", version), func(w http.ResponseWriter, r *http.Request) {
+			id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			respond(w, FinishedTaskResponse(id, resources[id]))
+		})
+	}
+}
+
+func rotateClients() certs.Clients {
+	return certs.Clients{
+		Listeners: fake.ServiceTokenClient("lblisteners", "v2"),
+		Secrets:   fake.ServiceTokenClient("secrets", "v1"),
+		SecretsV2: fake.ServiceTokenClient("secrets", "v2"),
+	}
+}
+
+func TestRotateListener(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	pki := NewTestPKI(time.Now(), 90*24*time.Hour)
+	bundle, err := certs.ParseBundle(pki.LeafPEM, pki.IntermediatePEM, pki.LeafKeyPEM)
+	require.NoError(t, err)
+
+	var calls []string
+	handleTasks()
+	th.Mux.HandleFunc(fmt.Sprintf("/v2/lblisteners/%d/%d/%s", fake.ProjectID, fake.RegionID, ListenerID), func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" listener")
+		switch r.Method {
+		case http.MethodGet:
+			respond(w, ListenerResponse)
+		case http.MethodPatch:
+			th.TestJSONRequest(t, r, ListenerUpdateRequest)
+			respond(w, TaskResponse(UpdateTaskID))
+		}
+	})
+	th.Mux.HandleFunc(fmt.Sprintf("/v2/secrets/%d/%d", fake.ProjectID, fake.RegionID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		calls = append(calls, "POST secret")
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var request map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &request))
+		require.Equal(t, "www-new", request["name"])
+		require.NotEmpty(t, request["expiration"])
+		respond(w, TaskResponse(CreateTaskID))
+	})
+	th.Mux.HandleFunc(fmt.Sprintf("/v1/secrets/%d/%d/%s", fake.ProjectID, fake.RegionID, OldSecretID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		calls = append(calls, "DELETE secret")
+		respond(w, TaskResponse(DeleteTaskID))
+	})
+
+	result, err := certs.RotateListener(rotateClients(), certs.RotateOpts{
+		ListenerID:  ListenerID,
+		Name:        "www-new",
+		Bundle:      bundle,
+		Validate:    certs.ValidateOpts{DNSNames: []string{"www.example.com"}},
+		WaitSeconds: 5,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"GET listener", "POST secret", "PATCH listener", "DELETE secret"}, calls)
+	require.Equal(t, OldSecretID, result.OldSecretID)
+	require.Equal(t, NewSecretID, result.NewSecretID)
+	require.Equal(t, NewSecretID, result.SecretID)
+	require.Equal(t, []string{SNISecretID, NewSecretID}, result.SNISecretIDs)
+	require.True(t, result.OldDeleted)
+}
+
+func TestRotateListenerInvalidBundle(t *testing.T) {
+	pki := NewTestPKI(time.Now(), 90*24*time.Hour)
+	bundle, err := certs.ParseBundle(pki.LeafPEM, pki.IntermediatePEM, pki.OtherKeyPEM)
+	require.NoError(t, err)
+
+	_, err = certs.RotateListener(rotateClients(), certs.RotateOpts{
+		ListenerID: ListenerID,
+		Name:       "www-new",
+		Bundle:     bundle,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid certificate bundle")
+}
+
+func TestAttachListener(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	pki := NewTestPKI(time.Now(), 90*24*time.Hour)
+	bundle, err := certs.ParseBundle(pki.LeafPEM, pki.IntermediatePEM, pki.LeafKeyPEM)
+	require.NoError(t, err)
+
+	var calls []string
+	handleTasks()
+	th.Mux.HandleFunc(fmt.Sprintf("/v2/lblisteners/%d/%d/%s", fake.ProjectID, fake.RegionID, ListenerID), func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" listener")
+		switch r.Method {
+		case http.MethodGet:
+			respond(w, ListenerWithoutSecretResponse)
+		case http.MethodPatch:
+			th.TestJSONRequest(t, r, ListenerAttachRequest)
+			respond(w, TaskResponse(UpdateTaskID))
+		}
+	})
+	th.Mux.HandleFunc(fmt.Sprintf("/v2/secrets/%d/%d", fake.ProjectID, fake.RegionID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		calls = append(calls, "POST secret")
+		respond(w, TaskResponse(CreateTaskID))
+	})
+
+	result, err := certs.AttachListener(rotateClients(), certs.AttachOpts{
+		ListenerID:  ListenerID,
+		Name:        "www-new",
+		Bundle:      bundle,
+		WaitSeconds: 5,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"GET listener", "POST secret", "PATCH listener"}, calls)
+	require.Equal(t, NewSecretID, result.SecretID)
+	require.Empty(t, result.OldSecretID)
+}
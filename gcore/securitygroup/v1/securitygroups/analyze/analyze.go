@@ -0,0 +1,313 @@
+package analyze
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
+	"github.com/G-Core/gcorelabscloud-go/gcore/securitygroup/v1/securitygroups"
+	"github.com/G-Core/gcorelabscloud-go/gcore/securitygroup/v1/types"
+)
+
+// InstanceAttachment describes an instance together with IDs of the security groups attached to it.
+type InstanceAttachment struct {
+	Instance         instances.Instance
+	SecurityGroupIDs []string
+}
+
+// Addresses returns all IP addresses of the instance.
+func (a InstanceAttachment) Addresses() []net.IP {
+	var result []net.IP
+	for _, addresses := range a.Instance.Addresses {
+		for _, address := range addresses {
+			if address.Address != nil {
+				result = append(result, address.Address)
+			}
+		}
+	}
+	return result
+}
+
+// PortAttachment describes a port together with IDs of the security groups attached to it.
+// InstanceID is empty for ports which do not belong to an instance.
+type PortAttachment struct {
+	PortID           string
+	InstanceID       string
+	SecurityGroupIDs []string
+}
+
+// Snapshot is a point-in-time view of the security groups, rules and instance and port attachments of a project.
+type Snapshot struct {
+	Groups    []securitygroups.SecurityGroup
+	Instances []InstanceAttachment
+	Ports     []PortAttachment
+
+	groups         map[string]securitygroups.SecurityGroup
+	instances      map[string]InstanceAttachment
+	attachedTo     map[string][]string
+	portAttachedTo map[string][]string
+}
+
+// NewSnapshot builds a Snapshot from already loaded security groups, instance attachments and port attachments.
+func NewSnapshot(groups []securitygroups.SecurityGroup, attachments []InstanceAttachment, ports ...PortAttachment) *Snapshot {
+	s := &Snapshot{
+		Groups:         groups,
+		Instances:      attachments,
+		Ports:          ports,
+		groups:         make(map[string]securitygroups.SecurityGroup, len(groups)),
+		instances:      make(map[string]InstanceAttachment, len(attachments)),
+		attachedTo:     make(map[string][]string),
+		portAttachedTo: make(map[string][]string),
+	}
+	for _, group := range groups {
+		s.groups[group.ID] = group
+	}
+	for _, attachment := range attachments {
+		s.instances[attachment.Instance.ID] = attachment
+		for _, groupID := range attachment.SecurityGroupIDs {
+			s.attachedTo[groupID] = append(s.attachedTo[groupID], attachment.Instance.ID)
+		}
+	}
+	for _, port := range ports {
+		for _, groupID := range port.SecurityGroupIDs {
+			s.portAttachedTo[groupID] = append(s.portAttachedTo[groupID], port.PortID)
+		}
+	}
+	return s
+}
+
+// Load fetches all security groups and instances of the project along with the security groups attached to every
+// instance and to every instance port.
+func Load(securityGroupClient, instanceClient *gcorecloud.ServiceClient) (*Snapshot, error) {
+	groups, err := securitygroups.ListAll(securityGroupClient, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list security groups: %w", err)
+	}
+	instanceList, err := instances.ListAll(instanceClient, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list instances: %w", err)
+	}
+	attachments := make([]InstanceAttachment, 0, len(instanceList))
+	var ports []PortAttachment
+	for _, instance := range instanceList {
+		sgs, err := instances.ListSecurityGroupsAll(instanceClient, instance.ID)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list security groups of instance %s: %w", instance.ID, err)
+		}
+		attachment := InstanceAttachment{Instance: instance}
+		for _, sg := range sgs {
+			attachment.SecurityGroupIDs = append(attachment.SecurityGroupIDs, sg.ID)
+		}
+		attachments = append(attachments, attachment)
+
+		instancePorts, err := instances.ListPortsAll(instanceClient, instance.ID)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list ports of instance %s: %w", instance.ID, err)
+		}
+		for _, p := range instancePorts {
+			port := PortAttachment{PortID: p.ID, InstanceID: instance.ID}
+			for _, sg := range p.SecurityGroups {
+				port.SecurityGroupIDs = append(port.SecurityGroupIDs, sg.ID)
+			}
+			ports = append(ports, port)
+		}
+	}
+	return NewSnapshot(groups, attachments, ports...), nil
+}
+
+// Query describes traffic between a remote network and an instance.
+type Query struct {
+	// Remote is an IP address or a CIDR. For ingress it is the traffic source, for egress the destination.
+	Remote     string
+	InstanceID string
+	Protocol   types.Protocol
+	// Port is a port for protocols with ports, or an ICMP type for ICMP.
+	Port int
+	// Direction defaults to ingress.
+	Direction types.RuleDirection
+}
+
+// Match is a rule allowing the queried traffic.
+type Match struct {
+	SecurityGroupID   string                           `json:"security_group_id"`
+	SecurityGroupName string                           `json:"security_group_name"`
+	Rule              securitygroups.SecurityGroupRule `json:"rule"`
+	Description       string                           `json:"description"`
+}
+
+// Verdict is the answer to a Query.
+type Verdict struct {
+	Allowed bool    `json:"allowed"`
+	Matches []Match `json:"matches"`
+}
+
+// CanReach reports whether the traffic described by the query is allowed by any security group attached to the instance.
+// Rules referencing a remote group match only single addresses that belong to instances of that group.
+func (s *Snapshot) CanReach(q Query) (*Verdict, error) {
+	attachment, ok := s.instances[q.InstanceID]
+	if !ok {
+		return nil, gcorecloud.ErrResourceNotFound{Name: q.InstanceID, ResourceType: "instance"}
+	}
+	if q.Remote == "" {
+		return nil, fmt.Errorf("remote address is required")
+	}
+	if q.Direction == "" {
+		q.Direction = types.RuleDirectionIngress
+	}
+	protocol := q.Protocol
+	if protocol == "" {
+		protocol = types.ProtocolAny
+	}
+	target, err := securitygroups.NormalizeRuleOpts(securitygroups.CreateSecurityGroupRuleOpts{
+		Direction:      q.Direction,
+		Protocol:       protocol,
+		RemoteIPPrefix: &q.Remote,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if target.PortRangeMin != 0 && q.Port == 0 {
+		return nil, fmt.Errorf("port is required for protocol %s", target.Protocol)
+	}
+	prefix := target.RemoteIPPrefix
+	if prefix == "" {
+		prefix = "0.0.0.0/0"
+		if target.EtherType == types.EtherTypeIPv6 {
+			prefix = "::/0"
+		}
+	}
+	_, remote, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	verdict := &Verdict{Matches: []Match{}}
+	for _, groupID := range attachment.SecurityGroupIDs {
+		group, ok := s.groups[groupID]
+		if !ok {
+			continue
+		}
+		for _, rule := range group.SecurityGroupRules {
+			n, err := securitygroups.NormalizeRule(rule)
+			if err != nil {
+				return nil, fmt.Errorf("security group rule %s: %w", rule.ID, err)
+			}
+			if !s.ruleAllows(n, target, remote, q.Port) {
+				continue
+			}
+			verdict.Matches = append(verdict.Matches, Match{
+				SecurityGroupID:   group.ID,
+				SecurityGroupName: group.Name,
+				Rule:              rule,
+				Description:       n.String(),
+			})
+		}
+	}
+	verdict.Allowed = len(verdict.Matches) > 0
+	return verdict, nil
+}
+
+func (s *Snapshot) ruleAllows(rule, target securitygroups.NormalizedRule, remote *net.IPNet, port int) bool {
+	if rule.Direction != target.Direction || rule.EtherType != target.EtherType {
+		return false
+	}
+	if rule.Protocol != types.ProtocolAny && rule.Protocol != target.Protocol {
+		return false
+	}
+	if rule.Protocol != types.ProtocolAny && (rule.PortRangeMin != 0 || rule.PortRangeMax != 0) {
+		if isICMP(rule.Protocol) {
+			if port != rule.PortRangeMin {
+				return false
+			}
+		} else if port < rule.PortRangeMin || port > rule.PortRangeMax {
+			return false
+		}
+	}
+	switch {
+	case rule.RemoteGroupID != "":
+		return s.groupContains(rule.RemoteGroupID, remote)
+	case rule.RemoteIPPrefix == "":
+		return true
+	default:
+		return cidrContains(rule.RemoteIPPrefix, remote)
+	}
+}
+
+// groupContains reports whether the network is a single address of an instance attached to the group.
+func (s *Snapshot) groupContains(groupID string, network *net.IPNet) bool {
+	ones, bits := network.Mask.Size()
+	if ones != bits {
+		return false
+	}
+	for _, instanceID := range s.attachedTo[groupID] {
+		for _, address := range s.instances[instanceID].Addresses() {
+			if address.Equal(network.IP) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func cidrContains(prefix string, network *net.IPNet) bool {
+	_, ruleNetwork, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return false
+	}
+	ruleOnes, _ := ruleNetwork.Mask.Size()
+	ones, _ := network.Mask.Size()
+	return ruleOnes <= ones && ruleNetwork.Contains(network.IP)
+}
+
+func isICMP(protocol types.Protocol) bool {
+	return protocol == types.ProtocolICMP || protocol == types.ProtocolIPv6ICMP
+}
+
+// Finding is an ingress rule open to any address.
+type Finding struct {
+	SecurityGroupID   string                           `json:"security_group_id"`
+	SecurityGroupName string                           `json:"security_group_name"`
+	Rule              securitygroups.SecurityGroupRule `json:"rule"`
+	Description       string                           `json:"description"`
+	InstanceIDs       []string                         `json:"instance_ids"`
+}
+
+// OpenToWorld lists ingress rules allowing traffic from 0.0.0.0/0 or ::/0, along with the instances they apply to.
+func (s *Snapshot) OpenToWorld() []Finding {
+	findings := []Finding{}
+	for _, group := range s.Groups {
+		for _, rule := range group.SecurityGroupRules {
+			n, err := securitygroups.NormalizeRule(rule)
+			if err != nil || n.Direction != types.RuleDirectionIngress {
+				continue
+			}
+			if n.RemoteGroupID != "" || n.RemoteIPPrefix != "" {
+				continue
+			}
+			findings = append(findings, Finding{
+				SecurityGroupID:   group.ID,
+				SecurityGroupName: group.Name,
+				Rule:              rule,
+				Description:       n.String(),
+				InstanceIDs:       append([]string{}, s.attachedTo[group.ID]...),
+			})
+		}
+	}
+	return findings
+}
+
+// UnusedGroups lists security groups attached neither to an instance nor to a port of the snapshot, sorted by name.
+// Load only knows about instance ports, so ports of other resources have to be passed to NewSnapshot
+// for their groups not to be reported.
+func (s *Snapshot) UnusedGroups() []securitygroups.SecurityGroup {
+	unused := []securitygroups.SecurityGroup{}
+	for _, group := range s.Groups {
+		if len(s.attachedTo[group.ID]) == 0 && len(s.portAttachedTo[group.ID]) == 0 {
+			unused = append(unused, group)
+		}
+	}
+	sort.SliceStable(unused, func(i, j int) bool { return unused[i].Name < unused[j].Name })
+	return unused
+}
@@ -0,0 +1,26 @@
+/*
+Package analyze evaluates the effective access granted by security groups attached to the instances and ports of a project
+
+Example to check whether a network can reach an instance port
+
+	snapshot, err := analyze.Load(securityGroupClient, instanceClient)
+	if err != nil {
+		panic(err)
+	}
+
+	verdict, err := snapshot.CanReach(analyze.Query{
+		Source:     "10.0.0.0/8",
+		InstanceID: "a7e7e8d6-0bf7-4ac9-8170-831b47ee2ba9",
+		Protocol:   types.ProtocolTCP,
+		Port:       22,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+Example to list ingress rules open to the whole internet and security groups attached to no instance or port
+
+	findings := snapshot.OpenToWorld()
+	unused := snapshot.UnusedGroups()
+*/
+package analyze
@@ -0,0 +1,2 @@
+// analyze unit tests
+package testing
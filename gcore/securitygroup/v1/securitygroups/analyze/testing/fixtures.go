@@ -0,0 +1,245 @@
+package testing
+
+const SecurityGroupListResponse = `
+{
+  "count": 3,
+  "results": [
+    {
+      "id": "11111111-1111-1111-1111-111111111111",
+      "name": "web",
+      "description": "",
+      "revision_number": 1,
+      "created_at": "2019-07-26T13:25:03+0000",
+      "project_id": 1,
+      "region_id": 1,
+      "region": "Luxembourg 1",
+      "security_group_rules": [
+        {
+          "id": "11111111-0000-0000-0000-000000000001",
+          "security_group_id": "11111111-1111-1111-1111-111111111111",
+          "direction": "ingress",
+          "ethertype": "IPv4",
+          "protocol": "tcp",
+          "port_range_min": 22,
+          "port_range_max": 22,
+          "remote_ip_prefix": "10.0.0.0/8",
+          "remote_group_id": null,
+          "revision_number": 0,
+          "created_at": "2019-07-26T13:25:03+0000"
+        },
+        {
+          "id": "11111111-0000-0000-0000-000000000002",
+          "security_group_id": "11111111-1111-1111-1111-111111111111",
+          "direction": "ingress",
+          "ethertype": "IPv4",
+          "protocol": "tcp",
+          "port_range_min": 443,
+          "port_range_max": 443,
+          "remote_ip_prefix": "0.0.0.0/0",
+          "remote_group_id": null,
+          "revision_number": 0,
+          "created_at": "2019-07-26T13:25:03+0000"
+        },
+        {
+          "id": "11111111-0000-0000-0000-000000000003",
+          "security_group_id": "11111111-1111-1111-1111-111111111111",
+          "direction": "ingress",
+          "ethertype": "IPv4",
+          "protocol": "tcp",
+          "port_range_min": 5432,
+          "port_range_max": 5432,
+          "remote_ip_prefix": null,
+          "remote_group_id": "22222222-2222-2222-2222-222222222222",
+          "revision_number": 0,
+          "created_at": "2019-07-26T13:25:03+0000"
+        }
+      ]
+    },
+    {
+      "id": "22222222-2222-2222-2222-222222222222",
+      "name": "app",
+      "description": "",
+      "revision_number": 1,
+      "created_at": "2019-07-26T13:25:03+0000",
+      "project_id": 1,
+      "region_id": 1,
+      "region": "Luxembourg 1",
+      "security_group_rules": [
+        {
+          "id": "22222222-0000-0000-0000-000000000001",
+          "security_group_id": "22222222-2222-2222-2222-222222222222",
+          "direction": "egress",
+          "ethertype": "IPv4",
+          "protocol": null,
+          "port_range_min": null,
+          "port_range_max": null,
+          "remote_ip_prefix": null,
+          "remote_group_id": null,
+          "revision_number": 0,
+          "created_at": "2019-07-26T13:25:03+0000"
+        }
+      ]
+    },
+    {
+      "id": "33333333-3333-3333-3333-333333333333",
+      "name": "legacy",
+      "description": "",
+      "revision_number": 1,
+      "created_at": "2019-07-26T13:25:03+0000",
+      "project_id": 1,
+      "region_id": 1,
+      "region": "Luxembourg 1",
+      "security_group_rules": [
+        {
+          "id": "33333333-0000-0000-0000-000000000001",
+          "security_group_id": "33333333-3333-3333-3333-333333333333",
+          "direction": "ingress",
+          "ethertype": "IPv4",
+          "protocol": "any",
+          "port_range_min": null,
+          "port_range_max": null,
+          "remote_ip_prefix": null,
+          "remote_group_id": null,
+          "revision_number": 0,
+          "created_at": "2019-07-26T13:25:03+0000"
+        }
+      ]
+    }
+  ]
+}
+`
+
+const InstanceListResponse = `
+{
+  "count": 2,
+  "results": [
+    {
+      "instance_id": "a7e7e8d6-0bf7-4ac9-8170-831b47ee2ba9",
+      "instance_name": "web-1",
+      "instance_created": "2019-07-11T06:58:48Z",
+      "status": "ACTIVE",
+      "vm_state": "active",
+      "addresses": {
+        "net1": [
+          {
+            "type": "fixed",
+            "addr": "10.0.0.17"
+          }
+        ]
+      },
+      "security_groups": [
+        {
+          "name": "web"
+        }
+      ],
+      "project_id": 1,
+      "region_id": 1,
+      "region": "Luxembourg 1"
+    },
+    {
+      "instance_id": "8dc30d49-bb34-4920-9bbd-03a2587ec0ad",
+      "instance_name": "app-1",
+      "instance_created": "2019-07-11T06:58:48Z",
+      "status": "ACTIVE",
+      "vm_state": "active",
+      "addresses": {
+        "net1": [
+          {
+            "type": "fixed",
+            "addr": "10.0.1.5"
+          }
+        ]
+      },
+      "security_groups": [
+        {
+          "name": "app"
+        }
+      ],
+      "project_id": 1,
+      "region_id": 1,
+      "region": "Luxembourg 1"
+    }
+  ]
+}
+`
+
+const WebInstanceSecurityGroupsResponse = `
+{
+  "count": 1,
+  "results": [
+    {
+      "id": "11111111-1111-1111-1111-111111111111",
+      "name": "web"
+    }
+  ]
+}
+`
+
+const AppInstanceSecurityGroupsResponse = `
+{
+  "count": 1,
+  "results": [
+    {
+      "id": "22222222-2222-2222-2222-222222222222",
+      "name": "app"
+    }
+  ]
+}
+`
+
+const WebInstancePortsResponse = `
+{
+  "count": 1,
+  "results": [
+    {
+      "id": "5c6f1b4e-66f2-4bb6-9c0e-3f3f0bd1c9a1",
+      "name": "web-port",
+      "security_groups": [
+        {
+          "id": "11111111-1111-1111-1111-111111111111",
+          "name": "web"
+        }
+      ]
+    }
+  ]
+}
+`
+
+const AppInstancePortsResponse = `
+{
+  "count": 2,
+  "results": [
+    {
+      "id": "0a7c5a3e-2f7b-4d4e-8d52-7d0b6f3b8e21",
+      "name": "app-port",
+      "security_groups": [
+        {
+          "id": "22222222-2222-2222-2222-222222222222",
+          "name": "app"
+        }
+      ]
+    },
+    {
+      "id": "9e4d2c1b-7a6f-4b3e-a1d0-5c8b7e6f4a32",
+      "name": "app-legacy-port",
+      "security_groups": [
+        {
+          "id": "33333333-3333-3333-3333-333333333333",
+          "name": "legacy"
+        }
+      ]
+    }
+  ]
+}
+`
+
+const (
+	WebGroupID     = "11111111-1111-1111-1111-111111111111"
+	AppGroupID     = "22222222-2222-2222-2222-222222222222"
+	LegacyGroupID  = "33333333-3333-3333-3333-333333333333"
+	WebInstanceID  = "a7e7e8d6-0bf7-4ac9-8170-831b47ee2ba9"
+	AppInstanceID  = "8dc30d49-bb34-4920-9bbd-03a2587ec0ad"
+	SSHRuleID      = "11111111-0000-0000-0000-000000000001"
+	HTTPSRuleID    = "11111111-0000-0000-0000-000000000002"
+	DatabaseRuleID = "11111111-0000-0000-0000-000000000003"
+)
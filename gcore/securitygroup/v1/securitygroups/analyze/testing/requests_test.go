@@ -0,0 +1,162 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/securitygroup/v1/securitygroups/analyze"
+	"github.com/G-Core/gcorelabscloud-go/gcore/securitygroup/v1/types"
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func handleJSON(t *testing.T, path, body string) {
+	th.Mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := fmt.Fprint(w, body)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+}
+
+func loadSnapshot(t *testing.T) *analyze.Snapshot {
+	handleJSON(t, fmt.Sprintf("/v1/securitygroups/%d/%d", fake.ProjectID, fake.RegionID), SecurityGroupListResponse)
+	handleJSON(t, fmt.Sprintf("/v1/instances/%d/%d", fake.ProjectID, fake.RegionID), InstanceListResponse)
+	handleJSON(t, fmt.Sprintf("/v1/instances/%d/%d/%s/securitygroups", fake.ProjectID, fake.RegionID, WebInstanceID),
+		WebInstanceSecurityGroupsResponse)
+	handleJSON(t, fmt.Sprintf("/v1/instances/%d/%d/%s/securitygroups", fake.ProjectID, fake.RegionID, AppInstanceID),
+		AppInstanceSecurityGroupsResponse)
+	handleJSON(t, fmt.Sprintf("/v1/instances/%d/%d/%s/ports", fake.ProjectID, fake.RegionID, WebInstanceID),
+		WebInstancePortsResponse)
+	handleJSON(t, fmt.Sprintf("/v1/instances/%d/%d/%s/ports", fake.ProjectID, fake.RegionID, AppInstanceID),
+		AppInstancePortsResponse)
+
+	snapshot, err := analyze.Load(
+		fake.ServiceTokenClient("securitygroups", "v1"),
+		fake.ServiceTokenClient("instances", "v1"),
+	)
+	require.NoError(t, err)
+	require.Len(t, snapshot.Groups, 3)
+	require.Len(t, snapshot.Instances, 2)
+	require.Len(t, snapshot.Ports, 3)
+	return snapshot
+}
+
+func TestCanReach(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	snapshot := loadSnapshot(t)
+
+	verdict, err := snapshot.CanReach(analyze.Query{
+		Remote:     "10.20.0.0/16",
+		InstanceID: WebInstanceID,
+		Protocol:   types.ProtocolTCP,
+		Port:       22,
+	})
+	require.NoError(t, err)
+	require.True(t, verdict.Allowed)
+	require.Len(t, verdict.Matches, 1)
+	require.Equal(t, SSHRuleID, verdict.Matches[0].Rule.ID)
+
+	verdict, err = snapshot.CanReach(analyze.Query{
+		Remote:     "0.0.0.0/0",
+		InstanceID: WebInstanceID,
+		Protocol:   types.ProtocolTCP,
+		Port:       22,
+	})
+	require.NoError(t, err)
+	require.False(t, verdict.Allowed)
+
+	verdict, err = snapshot.CanReach(analyze.Query{
+		Remote:     "203.0.113.10",
+		InstanceID: WebInstanceID,
+		Protocol:   types.ProtocolTCP,
+		Port:       443,
+	})
+	require.NoError(t, err)
+	require.True(t, verdict.Allowed)
+	require.Equal(t, HTTPSRuleID, verdict.Matches[0].Rule.ID)
+
+	verdict, err = snapshot.CanReach(analyze.Query{
+		Remote:     "10.0.1.5",
+		InstanceID: WebInstanceID,
+		Protocol:   types.ProtocolTCP,
+		Port:       5432,
+	})
+	require.NoError(t, err)
+	require.True(t, verdict.Allowed)
+	require.Equal(t, DatabaseRuleID, verdict.Matches[0].Rule.ID)
+
+	verdict, err = snapshot.CanReach(analyze.Query{
+		Remote:     "10.0.1.6",
+		InstanceID: WebInstanceID,
+		Protocol:   types.ProtocolTCP,
+		Port:       5432,
+	})
+	require.NoError(t, err)
+	require.False(t, verdict.Allowed)
+
+	verdict, err = snapshot.CanReach(analyze.Query{
+		Remote:     "10.0.0.1",
+		InstanceID: AppInstanceID,
+		Protocol:   types.ProtocolUDP,
+		Port:       53,
+		Direction:  types.RuleDirectionEgress,
+	})
+	require.NoError(t, err)
+	require.True(t, verdict.Allowed)
+
+	_, err = snapshot.CanReach(analyze.Query{
+		Remote:     "10.0.0.1",
+		InstanceID: WebInstanceID,
+		Protocol:   types.ProtocolTCP,
+	})
+	require.Error(t, err)
+
+	_, err = snapshot.CanReach(analyze.Query{
+		Remote:     "10.0.0.1",
+		InstanceID: "unknown",
+	})
+	require.Error(t, err)
+}
+
+func TestOpenToWorld(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	snapshot := loadSnapshot(t)
+
+	findings := snapshot.OpenToWorld()
+	require.Len(t, findings, 2)
+	require.Equal(t, HTTPSRuleID, findings[0].Rule.ID)
+	require.Equal(t, []string{WebInstanceID}, findings[0].InstanceIDs)
+	require.Equal(t, LegacyGroupID, findings[1].SecurityGroupID)
+	require.Empty(t, findings[1].InstanceIDs)
+}
+
+func TestUnusedGroups(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	snapshot := loadSnapshot(t)
+	require.Empty(t, snapshot.UnusedGroups())
+
+	instancesOnly := analyze.NewSnapshot(snapshot.Groups, snapshot.Instances)
+	unused := instancesOnly.UnusedGroups()
+	require.Len(t, unused, 1)
+	require.Equal(t, LegacyGroupID, unused[0].ID)
+
+	withPort := analyze.NewSnapshot(snapshot.Groups, snapshot.Instances, analyze.PortAttachment{
+		PortID:           "b1c2d3e4-0000-0000-0000-000000000001",
+		SecurityGroupIDs: []string{LegacyGroupID},
+	})
+	require.Empty(t, withPort.UnusedGroups())
+}
@@ -0,0 +1,310 @@
+package securitygroups
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/securitygroup/v1/types"
+)
+
+const (
+	minPort = 1
+	maxPort = 65535
+)
+
+// protocolAliases maps IANA protocol numbers the API may return to the names used in requests.
+var protocolAliases = map[string]types.Protocol{
+	"":    types.ProtocolAny,
+	"0":   types.ProtocolAny,
+	"1":   types.ProtocolICMP,
+	"6":   types.ProtocolTCP,
+	"17":  types.ProtocolUDP,
+	"58":  types.ProtocolIPv6ICMP,
+	"132": types.ProtocolSCTP,
+}
+
+// portProtocols lists protocols for which port ranges are meaningful.
+var portProtocols = map[types.Protocol]bool{
+	types.ProtocolTCP:     true,
+	types.ProtocolUDP:     true,
+	types.ProtocolSCTP:    true,
+	types.ProtocolDCCP:    true,
+	types.ProtocolUDPLITE: true,
+}
+
+// NormalizedRule is the canonical form of a security group rule used to compare desired and live rules.
+// Descriptions are not part of the rule identity, so changing one does not cause the rule to be recreated.
+type NormalizedRule struct {
+	Direction      types.RuleDirection
+	EtherType      types.EtherType
+	Protocol       types.Protocol
+	PortRangeMin   int
+	PortRangeMax   int
+	RemoteIPPrefix string
+	RemoteGroupID  string
+}
+
+// String returns a human-readable representation of the rule.
+func (r NormalizedRule) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s %s", r.Direction, r.EtherType, r.Protocol)
+	if r.PortRangeMin != 0 || r.PortRangeMax != 0 {
+		if r.PortRangeMin == r.PortRangeMax {
+			fmt.Fprintf(&b, " port %d", r.PortRangeMin)
+		} else {
+			fmt.Fprintf(&b, " ports %d-%d", r.PortRangeMin, r.PortRangeMax)
+		}
+	}
+	switch {
+	case r.RemoteGroupID != "":
+		fmt.Fprintf(&b, " group %s", r.RemoteGroupID)
+	case r.RemoteIPPrefix != "":
+		fmt.Fprintf(&b, " remote %s", r.RemoteIPPrefix)
+	default:
+		b.WriteString(" remote any")
+	}
+	return b.String()
+}
+
+// ReconcilePlan describes the changes required to converge a security group to the desired rule set.
+type ReconcilePlan struct {
+	SecurityGroupID string
+	// Add contains desired rules missing in the security group.
+	Add []CreateSecurityGroupRuleOpts
+	// Remove contains live rules that are not desired or duplicate another live rule.
+	Remove []SecurityGroupRule
+	// Unchanged contains live rules matching a desired rule.
+	Unchanged []SecurityGroupRule
+}
+
+// IsEmpty reports whether the plan has no changes to apply.
+func (p ReconcilePlan) IsEmpty() bool {
+	return len(p.Add) == 0 && len(p.Remove) == 0
+}
+
+// ToUpdateOpts converts the plan into UpdateOpts with a single set of changed rules.
+func (p ReconcilePlan) ToUpdateOpts() UpdateOpts {
+	changed := make([]UpdateSecurityGroupRuleOpts, 0, len(p.Add)+len(p.Remove))
+	for _, rule := range p.Remove {
+		changed = append(changed, UpdateSecurityGroupRuleOpts{
+			Action:              types.ActionDelete,
+			SecurityGroupRuleID: rule.ID,
+		})
+	}
+	for _, rule := range p.Add {
+		changed = append(changed, UpdateSecurityGroupRuleOpts{
+			Action:          types.ActionCreate,
+			Direction:       rule.Direction,
+			EtherType:       rule.EtherType,
+			Protocol:        rule.Protocol,
+			SecurityGroupID: rule.SecurityGroupID,
+			RemoteGroupID:   rule.RemoteGroupID,
+			PortRangeMax:    rule.PortRangeMax,
+			PortRangeMin:    rule.PortRangeMin,
+			Description:     rule.Description,
+			RemoteIPPrefix:  rule.RemoteIPPrefix,
+		})
+	}
+	return UpdateOpts{ChangedRules: changed}
+}
+
+// NormalizeRuleOpts returns the canonical form of a rule described by CreateSecurityGroupRuleOpts.
+func NormalizeRuleOpts(opts CreateSecurityGroupRuleOpts) (NormalizedRule, error) {
+	return normalizeRule(opts.Direction, opts.EtherType, opts.Protocol, opts.PortRangeMin, opts.PortRangeMax,
+		opts.RemoteIPPrefix, opts.RemoteGroupID)
+}
+
+// NormalizeRule returns the canonical form of a live security group rule.
+func NormalizeRule(rule SecurityGroupRule) (NormalizedRule, error) {
+	var etherType types.EtherType
+	if rule.EtherType != nil {
+		etherType = *rule.EtherType
+	}
+	var protocol types.Protocol
+	if rule.Protocol != nil {
+		protocol = *rule.Protocol
+	}
+	return normalizeRule(rule.Direction, etherType, protocol, rule.PortRangeMin, rule.PortRangeMax,
+		rule.RemoteIPPrefix, rule.RemoteGroupID)
+}
+
+func normalizeRule(direction types.RuleDirection, etherType types.EtherType, protocol types.Protocol,
+	portMin, portMax *int, remoteIPPrefix, remoteGroupID *string) (NormalizedRule, error) {
+	var n NormalizedRule
+
+	n.Direction = types.RuleDirection(strings.ToLower(direction.String()))
+	if err := n.Direction.IsValid(); err != nil {
+		return n, err
+	}
+
+	n.Protocol = types.Protocol(strings.ToLower(protocol.String()))
+	if alias, ok := protocolAliases[n.Protocol.String()]; ok {
+		n.Protocol = alias
+	}
+
+	if remoteGroupID != nil && *remoteGroupID != "" {
+		n.RemoteGroupID = *remoteGroupID
+	}
+
+	var prefixFamily types.EtherType
+	if remoteIPPrefix != nil && *remoteIPPrefix != "" {
+		cidr, family, err := normalizeCIDR(*remoteIPPrefix)
+		if err != nil {
+			return n, err
+		}
+		n.RemoteIPPrefix = cidr
+		prefixFamily = family
+	}
+
+	switch strings.ToLower(etherType.String()) {
+	case "":
+		n.EtherType = types.EtherTypeIPv4
+		if prefixFamily != "" {
+			n.EtherType = prefixFamily
+		}
+	case "ipv4":
+		n.EtherType = types.EtherTypeIPv4
+	case "ipv6":
+		n.EtherType = types.EtherTypeIPv6
+	default:
+		return n, etherType.IsValid()
+	}
+	if prefixFamily != "" && prefixFamily != n.EtherType {
+		return n, fmt.Errorf("remote ip prefix %s does not match ethertype %s", *remoteIPPrefix, n.EtherType)
+	}
+	// a prefix covering the whole address space is the same as no prefix at all
+	if n.RemoteIPPrefix == "0.0.0.0/0" || n.RemoteIPPrefix == "::/0" {
+		n.RemoteIPPrefix = ""
+	}
+
+	if portProtocols[n.Protocol] {
+		switch {
+		case portMin == nil && portMax == nil:
+			n.PortRangeMin, n.PortRangeMax = minPort, maxPort
+		case portMin == nil:
+			n.PortRangeMin, n.PortRangeMax = *portMax, *portMax
+		case portMax == nil:
+			n.PortRangeMin, n.PortRangeMax = *portMin, *portMin
+		default:
+			n.PortRangeMin, n.PortRangeMax = *portMin, *portMax
+		}
+		if n.PortRangeMin < minPort || n.PortRangeMax > maxPort || n.PortRangeMin > n.PortRangeMax {
+			return n, fmt.Errorf("invalid port range %d-%d", n.PortRangeMin, n.PortRangeMax)
+		}
+	} else {
+		// ICMP rules carry type and code in the port fields
+		if portMin != nil {
+			n.PortRangeMin = *portMin
+		}
+		if portMax != nil {
+			n.PortRangeMax = *portMax
+		}
+	}
+
+	return n, nil
+}
+
+// normalizeCIDR converts an address or a network into its canonical network form.
+func normalizeCIDR(s string) (string, types.EtherType, error) {
+	s = strings.TrimSpace(s)
+	if !strings.Contains(s, "/") {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return "", "", fmt.Errorf("invalid remote ip prefix: %s", s)
+		}
+		if ip.To4() != nil {
+			return ip.To4().String() + "/32", types.EtherTypeIPv4, nil
+		}
+		return ip.String() + "/128", types.EtherTypeIPv6, nil
+	}
+	_, network, err := net.ParseCIDR(s)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid remote ip prefix: %w", err)
+	}
+	ones, _ := network.Mask.Size()
+	if network.IP.To4() != nil {
+		return network.IP.To4().String() + "/" + strconv.Itoa(ones), types.EtherTypeIPv4, nil
+	}
+	return network.IP.String() + "/" + strconv.Itoa(ones), types.EtherTypeIPv6, nil
+}
+
+// DiffRules computes a minimal plan converging live rules to the desired ones.
+// Desired rules are deduplicated, and live rules duplicating each other are removed except for one.
+func DiffRules(live []SecurityGroupRule, desired []CreateSecurityGroupRuleOpts) (*ReconcilePlan, error) {
+	plan := &ReconcilePlan{}
+
+	wanted := make(map[NormalizedRule]bool, len(desired))
+	var order []NormalizedRule
+	addOpts := make(map[NormalizedRule]CreateSecurityGroupRuleOpts, len(desired))
+	for i, opts := range desired {
+		n, err := NormalizeRuleOpts(opts)
+		if err != nil {
+			return nil, fmt.Errorf("desired rule %d: %w", i, err)
+		}
+		if wanted[n] {
+			continue
+		}
+		wanted[n] = true
+		order = append(order, n)
+		addOpts[n] = opts
+	}
+
+	present := make(map[NormalizedRule]bool, len(live))
+	for _, rule := range live {
+		n, err := NormalizeRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("security group rule %s: %w", rule.ID, err)
+		}
+		if !wanted[n] || present[n] {
+			plan.Remove = append(plan.Remove, rule)
+			continue
+		}
+		present[n] = true
+		plan.Unchanged = append(plan.Unchanged, rule)
+	}
+
+	for _, n := range order {
+		if !present[n] {
+			plan.Add = append(plan.Add, addOpts[n])
+		}
+	}
+
+	sort.SliceStable(plan.Remove, func(i, j int) bool { return plan.Remove[i].ID < plan.Remove[j].ID })
+
+	return plan, nil
+}
+
+// Plan fetches a security group and computes the changes Reconcile would apply without applying them.
+func Plan(c *gcorecloud.ServiceClient, securityGroupID string, desired []CreateSecurityGroupRuleOpts) (*ReconcilePlan, error) {
+	sg, err := Get(c, securityGroupID).Extract()
+	if err != nil {
+		return nil, err
+	}
+	plan, err := DiffRules(sg.SecurityGroupRules, desired)
+	if err != nil {
+		return nil, err
+	}
+	plan.SecurityGroupID = securityGroupID
+	return plan, nil
+}
+
+// Reconcile converges the rules of a security group to the desired set.
+// It compares normalized rules, so equivalent rules are kept and their IDs do not change,
+// and applies all additions and removals in a single Update call. Nothing is sent if the group already matches.
+func Reconcile(c *gcorecloud.ServiceClient, securityGroupID string, desired []CreateSecurityGroupRuleOpts) (*ReconcilePlan, error) {
+	plan, err := Plan(c, securityGroupID, desired)
+	if err != nil {
+		return nil, err
+	}
+	if plan.IsEmpty() {
+		return plan, nil
+	}
+	if _, err := Update(c, securityGroupID, plan.ToUpdateOpts()).Extract(); err != nil {
+		return plan, err
+	}
+	return plan, nil
+}
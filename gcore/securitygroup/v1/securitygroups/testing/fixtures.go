@@ -362,3 +362,110 @@ var (
 	}
 	ExpectedMetadataList = []securitygroups.Metadata{Metadata1, Metadata2}
 )
+
+const ReconcileGetResponse = `
+{
+  "name": "web",
+  "description": "",
+  "id": "3addc7a1-e926-46da-b5a2-eb4b2f935230",
+  "revision_number": 2,
+  "created_at": "2019-07-26T13:25:03+0000",
+  "region": "Luxembourg 1",
+  "project_id": 1,
+  "region_id": 1,
+  "metadata": [],
+  "security_group_rules": [
+    {
+      "id": "0d5b6a5e-8f57-4c58-8e07-7b3b9ef4d001",
+      "security_group_id": "3addc7a1-e926-46da-b5a2-eb4b2f935230",
+      "direction": "ingress",
+      "ethertype": "IPv4",
+      "protocol": "tcp",
+      "port_range_min": 443,
+      "port_range_max": 443,
+      "remote_ip_prefix": "0.0.0.0/0",
+      "remote_group_id": null,
+      "description": "https",
+      "revision_number": 0,
+      "created_at": "2019-07-26T13:25:03+0000"
+    },
+    {
+      "id": "0d5b6a5e-8f57-4c58-8e07-7b3b9ef4d002",
+      "security_group_id": "3addc7a1-e926-46da-b5a2-eb4b2f935230",
+      "direction": "ingress",
+      "ethertype": "IPv4",
+      "protocol": "tcp",
+      "port_range_min": 22,
+      "port_range_max": 22,
+      "remote_ip_prefix": "10.0.0.0/8",
+      "remote_group_id": null,
+      "description": null,
+      "revision_number": 0,
+      "created_at": "2019-07-26T13:25:03+0000"
+    },
+    {
+      "id": "0d5b6a5e-8f57-4c58-8e07-7b3b9ef4d003",
+      "security_group_id": "3addc7a1-e926-46da-b5a2-eb4b2f935230",
+      "direction": "egress",
+      "ethertype": "IPv4",
+      "protocol": null,
+      "port_range_min": null,
+      "port_range_max": null,
+      "remote_ip_prefix": null,
+      "remote_group_id": null,
+      "description": null,
+      "revision_number": 0,
+      "created_at": "2019-07-26T13:25:03+0000"
+    },
+    {
+      "id": "0d5b6a5e-8f57-4c58-8e07-7b3b9ef4d004",
+      "security_group_id": "3addc7a1-e926-46da-b5a2-eb4b2f935230",
+      "direction": "egress",
+      "ethertype": "IPv4",
+      "protocol": "any",
+      "port_range_min": null,
+      "port_range_max": null,
+      "remote_ip_prefix": "0.0.0.0/0",
+      "remote_group_id": null,
+      "description": null,
+      "revision_number": 0,
+      "created_at": "2019-07-26T13:25:03+0000"
+    }
+  ]
+}
+`
+
+const ReconcileUpdateRequest = `
+{
+  "changed_rules": [
+    {
+      "action": "delete",
+      "security_group_rule_id": "0d5b6a5e-8f57-4c58-8e07-7b3b9ef4d002"
+    },
+    {
+      "action": "delete",
+      "security_group_rule_id": "0d5b6a5e-8f57-4c58-8e07-7b3b9ef4d004"
+    },
+    {
+      "action": "create",
+      "direction": "ingress",
+      "ethertype": "IPv4",
+      "protocol": "tcp",
+      "port_range_min": 22,
+      "port_range_max": 22,
+      "remote_ip_prefix": "192.168.1.0/24"
+    }
+  ]
+}
+`
+
+const ReconcileDuplicatesUpdateRequest = `
+{
+  "changed_rules": [
+    {
+      "action": "delete",
+      "security_group_rule_id": "0d5b6a5e-8f57-4c58-8e07-7b3b9ef4d004"
+    }
+  ]
+}
+`
@@ -0,0 +1,146 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/securitygroup/v1/securitygroups"
+	"github.com/G-Core/gcorelabscloud-go/gcore/securitygroup/v1/types"
+	th "github.com/G-Core/gcorelabscloud-go/testhelper"
+	fake "github.com/G-Core/gcorelabscloud-go/testhelper/client"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(s string) *string { return &s }
+
+func intPtr(i int) *int { return &i }
+
+var desiredRules = []securitygroups.CreateSecurityGroupRuleOpts{
+	{
+		Direction:      types.RuleDirectionIngress,
+		Protocol:       "TCP",
+		PortRangeMin:   intPtr(443),
+		RemoteIPPrefix: strPtr("0.0.0.0/0"),
+	},
+	{
+		Direction:      types.RuleDirectionIngress,
+		EtherType:      types.EtherTypeIPv4,
+		Protocol:       types.ProtocolTCP,
+		PortRangeMin:   intPtr(22),
+		PortRangeMax:   intPtr(22),
+		RemoteIPPrefix: strPtr("192.168.1.0/24"),
+	},
+	{
+		Direction: types.RuleDirectionEgress,
+		EtherType: types.EtherTypeIPv4,
+		Protocol:  types.ProtocolAny,
+	},
+}
+
+func TestReconcile(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareGetTestURL(groupID), func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer %s", fake.AccessToken))
+		w.Header().Add("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPatch:
+			th.TestJSONRequest(t, r, ReconcileUpdateRequest)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := fmt.Fprint(w, ReconcileGetResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	client := fake.ServiceTokenClient("securitygroups", "v1")
+	plan, err := securitygroups.Reconcile(client, groupID, desiredRules)
+	require.NoError(t, err)
+	require.Equal(t, groupID, plan.SecurityGroupID)
+	require.Len(t, plan.Add, 1)
+	require.Equal(t, "192.168.1.0/24", *plan.Add[0].RemoteIPPrefix)
+	require.Len(t, plan.Remove, 2)
+	require.Len(t, plan.Unchanged, 2)
+}
+
+func TestReconcileRemovesDuplicates(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc(prepareGetTestURL(groupID), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		if r.Method == http.MethodPatch {
+			th.TestJSONRequest(t, r, ReconcileDuplicatesUpdateRequest)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := fmt.Fprint(w, ReconcileGetResponse)
+		if err != nil {
+			log.Error(err)
+		}
+	})
+
+	desired := []securitygroups.CreateSecurityGroupRuleOpts{
+		{
+			Direction:      types.RuleDirectionIngress,
+			Protocol:       types.ProtocolTCP,
+			PortRangeMin:   intPtr(443),
+			PortRangeMax:   intPtr(443),
+			RemoteIPPrefix: strPtr("0.0.0.0/0"),
+		},
+		{
+			Direction:      types.RuleDirectionIngress,
+			Protocol:       types.ProtocolTCP,
+			PortRangeMin:   intPtr(22),
+			PortRangeMax:   intPtr(22),
+			RemoteIPPrefix: strPtr("10.1.2.3/8"),
+		},
+		{
+			Direction: types.RuleDirectionEgress,
+		},
+	}
+
+	client := fake.ServiceTokenClient("securitygroups", "v1")
+	plan, err := securitygroups.Reconcile(client, groupID, desired)
+	require.NoError(t, err)
+	require.Empty(t, plan.Add)
+	require.Len(t, plan.Unchanged, 3)
+	// the duplicated "allow all egress" rule is the only one removed
+	require.Len(t, plan.Remove, 1)
+	require.Equal(t, "0d5b6a5e-8f57-4c58-8e07-7b3b9ef4d004", plan.Remove[0].ID)
+}
+
+func TestNormalizeRuleOpts(t *testing.T) {
+	n, err := securitygroups.NormalizeRuleOpts(securitygroups.CreateSecurityGroupRuleOpts{
+		Direction:      types.RuleDirectionIngress,
+		Protocol:       "6",
+		RemoteIPPrefix: strPtr("2001:db8::1/32"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, types.EtherTypeIPv6, n.EtherType)
+	require.Equal(t, types.ProtocolTCP, n.Protocol)
+	require.Equal(t, "2001:db8::/32", n.RemoteIPPrefix)
+	require.Equal(t, 1, n.PortRangeMin)
+	require.Equal(t, 65535, n.PortRangeMax)
+
+	_, err = securitygroups.NormalizeRuleOpts(securitygroups.CreateSecurityGroupRuleOpts{
+		Direction:      types.RuleDirectionIngress,
+		EtherType:      types.EtherTypeIPv4,
+		RemoteIPPrefix: strPtr("::/0"),
+	})
+	require.Error(t, err)
+
+	_, err = securitygroups.NormalizeRuleOpts(securitygroups.CreateSecurityGroupRuleOpts{
+		Direction:    types.RuleDirectionIngress,
+		Protocol:     types.ProtocolUDP,
+		PortRangeMin: intPtr(100),
+		PortRangeMax: intPtr(10),
+	})
+	require.Error(t, err)
+}
@@ -58,6 +58,20 @@ func TestWaitForPredicateExceed(t *testing.T) {
 	th.AssertEquals(t, "a timeout occurred", err.Error())
 }
 
+func TestWaitForNegativeTimeout(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	calls := 0
+	err := gcorecloud.WaitFor(-1, func() (bool, error) {
+		calls++
+		return calls == 2, nil
+	})
+	th.CheckNoErr(t, err)
+	th.AssertEquals(t, 2, calls)
+}
+
 func TestNormalizeURL(t *testing.T) {
 	urls := []string{
 		"NoSlashAtEnd",
@@ -20,6 +20,9 @@ var defaultSleepTimeout = 1
 // predicate will be prematurely cancelled after the timeout.
 // Resource packages will wrap this in a more convenient function that's
 // specific to a certain resource, but it can also be useful on its own.
+// A negative timeout waits forever. This applies to every wrapper that passes
+// its timeout through, such as tasks.WaitForFinishedTask and
+// tasks.WaitTaskAndReturnResult.
 func WaitFor(timeout int, predicate func() (bool, error)) error {
 	type WaitForResult struct {
 		Success bool
@@ -37,6 +40,12 @@ func WaitFor(timeout int, predicate func() (bool, error)) error {
 		time.Sleep(time.Duration(defaultSleepTimeout) * time.Second)
 
 		var result WaitForResult
+		// time.After with a negative duration fires at once, so there is
+		// no timer at all when waiting forever.
+		var expired <-chan time.Time
+		if timeout >= 0 {
+			expired = time.After(time.Duration(timeout) * time.Second)
+		}
 		ch := make(chan bool, 1)
 		go func() {
 			defer close(ch)
@@ -54,7 +63,7 @@ func WaitFor(timeout int, predicate func() (bool, error)) error {
 				return nil
 			}
 		// If the predicate has not finished by the timeout, cancel it.
-		case <-time.After(time.Duration(timeout) * time.Second):
+		case <-expired:
 			return fmt.Errorf("a timeout occurred")
 		}
 	}